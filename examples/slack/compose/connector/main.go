@@ -9,9 +9,18 @@ import (
 )
 
 func main() {
-	c := httpserver.NewConnector(os.Getenv("MESSAGE_URL"))
+	// requests from the bot are only verified when a secret is set
+	var options []func(*httpserver.Connector) error
+	if secret := os.Getenv("CONNECTOR_SECRET"); secret != "" {
+		options = append(options, httpserver.WithSecret(secret))
+	}
 
-	ah, th := c.AddHandler(), c.TypingHandler()
+	c, err := httpserver.NewConnector(os.Getenv("MESSAGE_URL"), options...)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	ah, th, rh, sh := c.AddHandler(), c.TypingHandler(), c.RemoveHandler(), c.SendHandler()
 
 	log.Println("Adding /slack/add")
 	http.HandleFunc("/slack/add", func(res http.ResponseWriter, req *http.Request) {
@@ -25,6 +34,18 @@ func main() {
 		th(res, req)
 	})
 
+	log.Println("Adding /slack/remove")
+	http.HandleFunc("/slack/remove", func(res http.ResponseWriter, req *http.Request) {
+		log.Println("Removing A Bot")
+		rh(res, req)
+	})
+
+	log.Println("Adding /slack/send")
+	http.HandleFunc("/slack/send", func(res http.ResponseWriter, req *http.Request) {
+		log.Println("Sending A Payload For A Bot")
+		sh(res, req)
+	})
+
 	log.Println("Starting on", os.Getenv("PORT"))
 	log.Fatal(http.ListenAndServe(":"+os.Getenv("PORT"), nil))
 }
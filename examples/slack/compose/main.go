@@ -15,7 +15,13 @@ import (
 )
 
 func main() {
-	conn, err := httpclient.NewConnector(os.Getenv("CONNECTOR"))
+	// requests to the connector are only signed when a secret is set
+	var options []func(*httpclient.Connector) error
+	if secret := os.Getenv("CONNECTOR_SECRET"); secret != "" {
+		options = append(options, httpclient.WithSecret(secret))
+	}
+
+	conn, err := httpclient.NewConnector(os.Getenv("CONNECTOR"), options...)
 	if err != nil {
 		log.Fatal(err)
 	}
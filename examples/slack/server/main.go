@@ -12,7 +12,7 @@ import (
 	"suy.io/bots/slack/api/oauth"
 )
 
-var appID, clientID, clientSecret, scopes, redirect, state, port string
+var appID, clientID, clientSecret, scopes, redirect, port string
 
 func main() {
 	flag.StringVar(&appID, "a", "", "App ID")
@@ -20,7 +20,6 @@ func main() {
 	flag.StringVar(&clientSecret, "cs", "", "Client Secret")
 	flag.StringVar(&scopes, "S", "", "Client Scopes")
 	flag.StringVar(&redirect, "r", "", "Client Redirect")
-	flag.StringVar(&state, "s", "", "Client State")
 	flag.StringVar(&port, "p", "8080", "Server Port")
 	flag.Parse()
 
@@ -35,12 +34,7 @@ func main() {
 		log.Fatal(err)
 	}
 
-	url, err := c.CreateAddToSlackURL(strings.Split(scopes, ","), redirect, state)
-	if err != nil {
-		log.Fatal(err)
-	}
-
-	button := createAddToSlackButton(url)
+	button := createAddToSlackButton("/slack/install")
 
 	log.Println("Adding / Handler")
 	http.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
@@ -48,10 +42,13 @@ func main() {
 		fmt.Fprintln(w, button)
 	})
 
+	log.Println("Adding /slack/install Handler")
+	http.HandleFunc("/slack/install", c.InstallHandler(strings.Split(scopes, ","), redirect))
+
 	log.Println("Adding /slack/oauth Handler")
 	http.HandleFunc("/slack/oauth", c.OAuthHandler(
 		redirect,
-		state,
+		"",
 		func(p *oauth.AccessResponse, w http.ResponseWriter, r *http.Request) {
 			log.Println("/slack/oauth")
 			http.Redirect(w, r, "https://slack.com/app_redirect?app="+appID, http.StatusFound)
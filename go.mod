@@ -1,13 +1,16 @@
 module "suy.io/bots"
 
 require (
+	"github.com/alicebob/miniredis/v2" v2.39.0
 	"github.com/go-redis/redis" v0.0.0-20180314104251-877867d2845f
 	"github.com/golang/glog" v0.0.0-20160126235308-23def4e6c14b
 	"github.com/google/go-querystring" v0.0.0-20170111101155-53e6ce116135
 	"github.com/gorilla/websocket" v1.2.0
+	"github.com/mattn/go-sqlite3" v1.14.52
 	"github.com/microcosm-cc/bluemonday" v0.0.0-20180327211928-995366fdf961
 	"github.com/pkg/errors" v0.8.0
 	"github.com/pquerna/ffjson" v0.0.0-20171002144729-d49c2bc1aa13
+	"github.com/yuin/gopher-lua" v1.1.1
 	"golang.org/x/net" v0.0.0-20180406214816-61147c48b25b
 	"gopkg.in/bufio.v1" v0.0.0-20140618132640-567b2bfa514e
 )
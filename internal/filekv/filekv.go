@@ -0,0 +1,489 @@
+// Package filekv implements a small embedded key-value store persisted to a single file.
+//
+// Every committed transaction is appended to the file as one checksummed record and synced,
+// so a crash can at most lose a transaction that was being written, which is discarded
+// on the next Open. A damaged record followed by others is not left by a crash, so Open
+// fails with ErrCorrupt instead of discarding the records after it. Once the file has grown
+// to several times the size of the live data, it is compacted by writing a snapshot to a
+// temporary file and renaming it into place.
+package filekv // import "suy.io/bots/internal/filekv"
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/json"
+	"hash/crc32"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+var ErrClosed = errors.New("Store Closed")
+var ErrCorrupt = errors.New("Store Corrupt")
+
+// errIncomplete is returned for a record that extends past the end of the file.
+var errIncomplete = errors.New("incomplete record")
+
+// CompactionThreshold is the minimum size of the log file before it is compacted.
+const CompactionThreshold = 1 << 20
+
+// CompactionRatio is how many times the size of the live data the log file can grow to.
+const CompactionRatio = 4
+
+// op is a single change in a transaction.
+type op struct {
+	Bucket string  `json:"b"`
+	Key    string  `json:"k,omitempty"`
+	Value  *string `json:"v,omitempty"`
+}
+
+// DB is an open store.
+type DB struct {
+	mu      sync.RWMutex
+	path    string
+	f       *os.File
+	size    int64
+	buckets map[string]map[string]string
+}
+
+// Open opens the store at path, creating it if it does not exist.
+func Open(path string) (*DB, error) {
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, errors.Wrap(err, "Open Failed")
+	}
+
+	db := &DB{path: path, f: f, buckets: make(map[string]map[string]string)}
+
+	if err := db.load(); err != nil {
+		f.Close()
+		return nil, errors.Wrap(err, "Open Failed")
+	}
+
+	return db, nil
+}
+
+// load replays the log, truncating an incomplete or damaged last record.
+func (db *DB) load() error {
+	fi, err := db.f.Stat()
+	if err != nil {
+		return err
+	}
+
+	size := fi.Size()
+	r := bufio.NewReader(db.f)
+
+	var offset int64
+	for offset < size {
+		ops, n, err := readRecord(r, size-offset)
+		if err == errIncomplete || (err == ErrCorrupt && offset+n == size) {
+			// only the last record can be left torn, by a crash while writing it
+			log.Printf("filekv: discarding %d bytes of a torn record at the end of %s", size-offset, db.path)
+			break
+		}
+
+		if err == ErrCorrupt {
+			return errors.Wrapf(err, "damaged record at offset %d", offset)
+		}
+
+		if err != nil {
+			return err
+		}
+
+		db.apply(ops)
+		offset += n
+	}
+
+	if offset < size {
+		if err := db.f.Truncate(offset); err != nil {
+			return err
+		}
+	}
+
+	if _, err := db.f.Seek(offset, io.SeekStart); err != nil {
+		return err
+	}
+
+	db.size = offset
+	return nil
+}
+
+// readRecord reads a record framed as <length><crc32><payload>, of at most max bytes.
+//
+// It returns errIncomplete if the record does not fit in max, and ErrCorrupt
+// along with the size of the record if its payload is damaged.
+func readRecord(r io.Reader, max int64) ([]op, int64, error) {
+	var header [8]byte
+	if max < int64(len(header)) {
+		return nil, 0, errIncomplete
+	}
+
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return nil, 0, err
+	}
+
+	l, sum := binary.BigEndian.Uint32(header[:4]), binary.BigEndian.Uint32(header[4:])
+
+	// checked before allocating, as the length can be damaged too
+	n := int64(len(header)) + int64(l)
+	if n > max {
+		return nil, 0, errIncomplete
+	}
+
+	payload := make([]byte, l)
+	if _, err := io.ReadFull(r, payload); err != nil {
+		return nil, 0, err
+	}
+
+	if crc32.ChecksumIEEE(payload) != sum {
+		return nil, n, ErrCorrupt
+	}
+
+	var ops []op
+	if err := json.Unmarshal(payload, &ops); err != nil {
+		return nil, n, ErrCorrupt
+	}
+
+	return ops, n, nil
+}
+
+func encodeRecord(ops []op) ([]byte, error) {
+	payload, err := json.Marshal(ops)
+	if err != nil {
+		return nil, err
+	}
+
+	rec := make([]byte, 8+len(payload))
+	binary.BigEndian.PutUint32(rec[:4], uint32(len(payload)))
+	binary.BigEndian.PutUint32(rec[4:8], crc32.ChecksumIEEE(payload))
+	copy(rec[8:], payload)
+
+	return rec, nil
+}
+
+// apply applies ops to the in-memory data.
+func (db *DB) apply(ops []op) {
+	for _, o := range ops {
+		switch {
+		case o.Key == "":
+			delete(db.buckets, o.Bucket)
+		case o.Value == nil:
+			if b, ok := db.buckets[o.Bucket]; ok {
+				delete(b, o.Key)
+				if len(b) == 0 {
+					delete(db.buckets, o.Bucket)
+				}
+			}
+		default:
+			b, ok := db.buckets[o.Bucket]
+			if !ok {
+				b = make(map[string]string)
+				db.buckets[o.Bucket] = b
+			}
+
+			b[o.Key] = *o.Value
+		}
+	}
+}
+
+// Get gets the value for a key in a bucket.
+func (db *DB) Get(bucket, key string) (string, bool) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	v, ok := db.buckets[bucket][key]
+	return v, ok
+}
+
+// Keys gets the keys in a bucket in sorted order.
+func (db *DB) Keys(bucket string) []string {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	return sortedKeys(db.buckets[bucket])
+}
+
+// Buckets gets the names of all non-empty buckets starting with prefix, in sorted order.
+func (db *DB) Buckets(prefix string) []string {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	ans := make([]string, 0)
+	for b := range db.buckets {
+		if strings.HasPrefix(b, prefix) {
+			ans = append(ans, b)
+		}
+	}
+
+	sort.Strings(ans)
+	return ans
+}
+
+func sortedKeys(b map[string]string) []string {
+	ans := make([]string, 0, len(b))
+	for k := range b {
+		ans = append(ans, k)
+	}
+
+	sort.Strings(ans)
+	return ans
+}
+
+// Tx is a write transaction, changes are only visible to others once it commits.
+type Tx struct {
+	db  *DB
+	ops []op
+}
+
+// Get gets the value for a key, including changes made in the transaction.
+func (tx *Tx) Get(bucket, key string) (string, bool) {
+	for i := len(tx.ops) - 1; i >= 0; i-- {
+		o := tx.ops[i]
+		if o.Bucket != bucket {
+			continue
+		}
+
+		if o.Key == "" {
+			return "", false
+		}
+
+		if o.Key == key {
+			if o.Value == nil {
+				return "", false
+			}
+
+			return *o.Value, true
+		}
+	}
+
+	v, ok := tx.db.buckets[bucket][key]
+	return v, ok
+}
+
+// Keys gets the keys in a bucket in sorted order, including changes made in the transaction.
+func (tx *Tx) Keys(bucket string) []string {
+	keys := make(map[string]string)
+	for k, v := range tx.db.buckets[bucket] {
+		keys[k] = v
+	}
+
+	for _, o := range tx.ops {
+		if o.Bucket != bucket {
+			continue
+		}
+
+		switch {
+		case o.Key == "":
+			keys = make(map[string]string)
+		case o.Value == nil:
+			delete(keys, o.Key)
+		default:
+			keys[o.Key] = *o.Value
+		}
+	}
+
+	return sortedKeys(keys)
+}
+
+// Buckets gets the names of all non-empty buckets starting with prefix in sorted order,
+// including changes made in the transaction.
+func (tx *Tx) Buckets(prefix string) []string {
+	names := make(map[string]string)
+	for b := range tx.db.buckets {
+		names[b] = ""
+	}
+
+	for _, o := range tx.ops {
+		names[o.Bucket] = ""
+	}
+
+	ans := make([]string, 0)
+	for _, b := range sortedKeys(names) {
+		if strings.HasPrefix(b, prefix) && len(tx.Keys(b)) > 0 {
+			ans = append(ans, b)
+		}
+	}
+
+	return ans
+}
+
+// Put sets the value for a key.
+func (tx *Tx) Put(bucket, key, value string) {
+	tx.ops = append(tx.ops, op{bucket, key, &value})
+}
+
+// Delete deletes a key.
+func (tx *Tx) Delete(bucket, key string) {
+	tx.ops = append(tx.ops, op{bucket, key, nil})
+}
+
+// DeleteBucket deletes all keys in a bucket.
+func (tx *Tx) DeleteBucket(bucket string) {
+	tx.ops = append(tx.ops, op{bucket, "", nil})
+}
+
+// Update runs f in a transaction, writing its changes to disk if it returns nil.
+//
+// Transactions are serialized, so reads inside f are consistent with its writes.
+func (db *DB) Update(f func(*Tx) error) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if db.f == nil {
+		return ErrClosed
+	}
+
+	tx := &Tx{db: db}
+	if err := f(tx); err != nil {
+		return err
+	}
+
+	if len(tx.ops) == 0 {
+		return nil
+	}
+
+	rec, err := encodeRecord(tx.ops)
+	if err != nil {
+		return errors.Wrap(err, "Update Failed")
+	}
+
+	_, err = db.f.Write(rec)
+	if err == nil {
+		err = db.f.Sync()
+	}
+
+	if err != nil {
+		// drop the record, so it is not applied on the next Open either
+		if rerr := db.rollback(); rerr != nil {
+			return errors.Wrapf(err, "Update Failed, store closed as the record could not be dropped (%v)", rerr)
+		}
+
+		return errors.Wrap(err, "Update Failed")
+	}
+
+	db.size += int64(len(rec))
+	db.apply(tx.ops)
+
+	// the transaction is committed, so a failed compaction is retried by the next one
+	if db.size > CompactionThreshold && db.size > CompactionRatio*db.liveSize() {
+		if err := db.compact(); err != nil {
+			log.Println(errors.Wrap(err, "Could not compact"))
+		}
+	}
+
+	return nil
+}
+
+// rollback drops a record that was partly written, closing the store if it cannot,
+// as the next record would be appended after it.
+func (db *DB) rollback() error {
+	err := db.f.Truncate(db.size)
+	if err == nil {
+		_, err = db.f.Seek(db.size, io.SeekStart)
+	}
+
+	if err != nil {
+		db.f.Close()
+		db.f = nil
+	}
+
+	return err
+}
+
+// liveSize estimates the size of a snapshot of the current data.
+func (db *DB) liveSize() int64 {
+	var n int64
+	for b, kv := range db.buckets {
+		for k, v := range kv {
+			n += int64(len(b) + len(k) + len(v) + 16)
+		}
+	}
+
+	return n
+}
+
+// Compact rewrites the file with only the current data.
+func (db *DB) Compact() error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if db.f == nil {
+		return ErrClosed
+	}
+
+	return db.compact()
+}
+
+func (db *DB) compact() error {
+	ops := make([]op, 0)
+	for b, kv := range db.buckets {
+		for k, v := range kv {
+			v := v
+			ops = append(ops, op{b, k, &v})
+		}
+	}
+
+	tmp := db.path + ".tmp"
+	f, err := os.OpenFile(tmp, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+
+	var size int64
+	if len(ops) > 0 {
+		rec, err := encodeRecord(ops)
+		if err != nil {
+			f.Close()
+			return err
+		}
+
+		if _, err := f.Write(rec); err != nil {
+			f.Close()
+			return err
+		}
+
+		size = int64(len(rec))
+	}
+
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+
+	// the snapshot replaces the log atomically, a crash leaves either the old or the new file
+	if err := os.Rename(tmp, db.path); err != nil {
+		f.Close()
+		return err
+	}
+
+	if d, err := os.Open(filepath.Dir(db.path)); err == nil {
+		d.Sync()
+		d.Close()
+	}
+
+	db.f.Close()
+	db.f, db.size = f, size
+
+	_, err = f.Seek(size, io.SeekStart)
+	return err
+}
+
+// Close closes the store.
+func (db *DB) Close() error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if db.f == nil {
+		return ErrClosed
+	}
+
+	err := db.f.Close()
+	db.f = nil
+	return err
+}
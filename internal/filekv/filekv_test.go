@@ -0,0 +1,336 @@
+package filekv
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func testPath(t *testing.T) (string, func()) {
+	dir, err := ioutil.TempDir("", "filekv")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return filepath.Join(dir, "test.db"), func() { os.RemoveAll(dir) }
+}
+
+func TestDB_Update(t *testing.T) {
+	path, done := testPath(t)
+	defer done()
+
+	db, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = db.Update(func(tx *Tx) error {
+		tx.Put("a", "1", "one")
+		tx.Put("a", "2", "two")
+		tx.Put("b", "1", "uno")
+
+		if v, ok := tx.Get("a", "1"); !ok || v != "one" {
+			t.Errorf("Tx.Get() = %v, %v, want one", v, ok)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	failed := errors.New("failed")
+	if err := db.Update(func(tx *Tx) error {
+		tx.Put("a", "3", "three")
+		return failed
+	}); err != failed {
+		t.Errorf("DB.Update() error = %v, want %v", err, failed)
+	}
+
+	db.Update(func(tx *Tx) error {
+		tx.Delete("a", "2")
+		tx.DeleteBucket("b")
+		return nil
+	})
+
+	check := func(db *DB) {
+		if got := db.Keys("a"); !reflect.DeepEqual(got, []string{"1"}) {
+			t.Errorf("DB.Keys() = %v, want %v", got, []string{"1"})
+		}
+
+		if _, ok := db.Get("b", "1"); ok {
+			t.Errorf("DB.Get() found key in deleted bucket")
+		}
+
+		if got := db.Buckets(""); !reflect.DeepEqual(got, []string{"a"}) {
+			t.Errorf("DB.Buckets() = %v, want %v", got, []string{"a"})
+		}
+	}
+
+	check(db)
+	db.Close()
+
+	db, err = Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer db.Close()
+	check(db)
+}
+
+func TestOpen_tornWrite(t *testing.T) {
+	path, done := testPath(t)
+	defer done()
+
+	db, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	db.Update(func(tx *Tx) error {
+		tx.Put("a", "1", "one")
+		return nil
+	})
+
+	db.Update(func(tx *Tx) error {
+		tx.Put("a", "2", "two")
+		return nil
+	})
+
+	db.Close()
+
+	// simulate a crash in the middle of writing the last record
+	fi, _ := os.Stat(path)
+	os.Truncate(path, fi.Size()-3)
+
+	db, err = Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := db.Keys("a"); !reflect.DeepEqual(got, []string{"1"}) {
+		t.Errorf("DB.Keys() after torn write = %v, want %v", got, []string{"1"})
+	}
+
+	db.Update(func(tx *Tx) error {
+		tx.Put("a", "3", "three")
+		return nil
+	})
+
+	db.Close()
+
+	db, err = Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer db.Close()
+
+	if got := db.Keys("a"); !reflect.DeepEqual(got, []string{"1", "3"}) {
+		t.Errorf("DB.Keys() after recovery = %v, want %v", got, []string{"1", "3"})
+	}
+}
+
+func TestOpen_damaged(t *testing.T) {
+	// writes two records, returning the offset of the second
+	write := func(t *testing.T, path string) int64 {
+		db, err := Open(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		defer db.Close()
+
+		db.Update(func(tx *Tx) error {
+			tx.Put("a", "1", "one")
+			return nil
+		})
+
+		offset := db.size
+
+		db.Update(func(tx *Tx) error {
+			tx.Put("a", "2", "two")
+			return nil
+		})
+
+		return offset
+	}
+
+	tests := []struct {
+		name     string
+		damage   func(f *os.File, second int64)
+		wantErr  error
+		wantKeys []string
+	}{
+		{
+			"damaged last record",
+			func(f *os.File, second int64) { f.WriteAt([]byte("x"), second+10) },
+			nil,
+			[]string{"1"},
+		},
+		{
+			"damaged record before others",
+			func(f *os.File, second int64) { f.WriteAt([]byte("x"), 10) },
+			ErrCorrupt,
+			nil,
+		},
+		{
+			"huge length at the end",
+			func(f *os.File, second int64) {
+				fi, _ := f.Stat()
+				f.WriteAt([]byte{0xff, 0xff, 0xff, 0xff, 0, 0, 0, 0}, fi.Size())
+			},
+			nil,
+			[]string{"1", "2"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path, done := testPath(t)
+			defer done()
+
+			second := write(t, path)
+
+			f, err := os.OpenFile(path, os.O_RDWR, 0600)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			tt.damage(f, second)
+			f.Close()
+
+			db, err := Open(path)
+			if errors.Cause(err) != tt.wantErr {
+				t.Fatalf("Open() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if err != nil {
+				return
+			}
+
+			defer db.Close()
+
+			if got := db.Keys("a"); !reflect.DeepEqual(got, tt.wantKeys) {
+				t.Errorf("DB.Keys() = %v, want %v", got, tt.wantKeys)
+			}
+		})
+	}
+}
+
+func TestDB_Update_compactFailure(t *testing.T) {
+	path, done := testPath(t)
+	defer done()
+
+	db, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer db.Close()
+
+	// the snapshot cannot be created
+	if err := os.Mkdir(path+".tmp", 0700); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, v := range []string{strings.Repeat("x", CompactionThreshold), "small"} {
+		err := db.Update(func(tx *Tx) error {
+			tx.Put("a", "1", v)
+			return nil
+		})
+
+		if err != nil {
+			t.Fatalf("DB.Update() error = %v", err)
+		}
+	}
+
+	if v, _ := db.Get("a", "1"); v != "small" {
+		t.Errorf("DB.Get() = %v, want small", v)
+	}
+}
+
+func TestDB_Update_rollbackFailure(t *testing.T) {
+	path, done := testPath(t)
+	defer done()
+
+	db, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// neither the write nor dropping it can succeed on a read only file
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	db.f.Close()
+	db.f = f
+
+	err = db.Update(func(tx *Tx) error {
+		tx.Put("a", "1", "one")
+		return nil
+	})
+
+	if err == nil {
+		t.Fatal("DB.Update() error = nil, want error")
+	}
+
+	if err := db.Update(func(tx *Tx) error { return nil }); err != ErrClosed {
+		t.Errorf("DB.Update() after a failed rollback error = %v, want %v", err, ErrClosed)
+	}
+}
+
+func TestDB_Compact(t *testing.T) {
+	path, done := testPath(t)
+	defer done()
+
+	db, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 100; i++ {
+		db.Update(func(tx *Tx) error {
+			tx.Put("a", "1", "value")
+			return nil
+		})
+	}
+
+	before, _ := os.Stat(path)
+
+	if err := db.Compact(); err != nil {
+		t.Fatal(err)
+	}
+
+	after, _ := os.Stat(path)
+	if after.Size() >= before.Size() {
+		t.Errorf("DB.Compact() size = %v, want less than %v", after.Size(), before.Size())
+	}
+
+	db.Update(func(tx *Tx) error {
+		tx.Put("a", "2", "value")
+		return nil
+	})
+
+	db.Close()
+
+	db, err = Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer db.Close()
+
+	if got := db.Keys("a"); !reflect.DeepEqual(got, []string{"1", "2"}) {
+		t.Errorf("DB.Keys() after compaction = %v, want %v", got, []string{"1", "2"})
+	}
+}
@@ -0,0 +1,190 @@
+// Package redisstream implements the Redis Streams commands used by the stream connectors,
+// on top of a client that has no stream support of its own.
+package redisstream // import "suy.io/bots/internal/redisstream"
+
+import (
+	"strings"
+	"time"
+
+	"github.com/go-redis/redis"
+	"github.com/pkg/errors"
+)
+
+var errInvalidReply = errors.New("Invalid Stream Reply")
+
+// Entry is an entry of a stream.
+type Entry struct {
+	ID     string
+	Values map[string]string
+}
+
+// Add appends an entry with values to a stream, trimming it to about maxLen entries if maxLen > 0.
+func Add(c *redis.Client, stream string, maxLen int64, values map[string]string) (string, error) {
+	args := []interface{}{"XADD", stream}
+	if maxLen > 0 {
+		args = append(args, "MAXLEN", "~", maxLen)
+	}
+
+	args = append(args, "*")
+	for k, v := range values {
+		args = append(args, k, v)
+	}
+
+	cmd := redis.NewStringCmd(args...)
+	c.Process(cmd)
+
+	return cmd.Result()
+}
+
+// CreateGroup creates a consumer group reading new entries of a stream,
+// creating the stream if it does not exist. It does nothing if the group exists.
+func CreateGroup(c *redis.Client, stream, group string) error {
+	cmd := redis.NewStatusCmd("XGROUP", "CREATE", stream, group, "$", "MKSTREAM")
+	c.Process(cmd)
+
+	if err := cmd.Err(); err != nil && !strings.HasPrefix(err.Error(), "BUSYGROUP") {
+		return err
+	}
+
+	return nil
+}
+
+// ReadGroup reads up to count entries of a stream as consumer of group.
+//
+// With id ">" it reads entries never delivered to the group, waiting up to block for them,
+// with id "0" it reads the entries delivered to consumer that it did not acknowledge.
+func ReadGroup(c *redis.Client, stream, group, consumer, id string, count int64, block time.Duration) ([]Entry, error) {
+	args := []interface{}{"XREADGROUP", "GROUP", group, consumer, "COUNT", count}
+	if id == ">" && block > 0 {
+		args = append(args, "BLOCK", int64(block/time.Millisecond))
+	}
+
+	args = append(args, "STREAMS", stream, id)
+
+	cmd := redis.NewCmd(args...)
+	c.Process(cmd)
+
+	v, err := cmd.Result()
+	if err == redis.Nil {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	// [[stream, [entries]]]
+	streams, ok := v.([]interface{})
+	if !ok {
+		return nil, errInvalidReply
+	}
+
+	var entries []Entry
+	for _, s := range streams {
+		s, ok := s.([]interface{})
+		if !ok || len(s) != 2 {
+			return nil, errInvalidReply
+		}
+
+		es, err := parseEntries(s[1])
+		if err != nil {
+			return nil, err
+		}
+
+		entries = append(entries, es...)
+	}
+
+	return entries, nil
+}
+
+// Ack acknowledges entries of a stream for group, so they are not delivered again.
+func Ack(c *redis.Client, stream, group string, ids ...string) error {
+	args := []interface{}{"XACK", stream, group}
+	for _, id := range ids {
+		args = append(args, id)
+	}
+
+	cmd := redis.NewIntCmd(args...)
+	c.Process(cmd)
+
+	return cmd.Err()
+}
+
+// Claim moves up to count entries of group that were not acknowledged for minIdle to consumer,
+// so entries of a consumer that stopped are delivered again.
+func Claim(c *redis.Client, stream, group, consumer string, minIdle time.Duration, count int64) ([]Entry, error) {
+	pending := redis.NewCmd("XPENDING", stream, group, "-", "+", count)
+	c.Process(pending)
+
+	v, err := pending.Result()
+	if err != nil {
+		return nil, err
+	}
+
+	// [[id, consumer, idle, deliveries]]
+	ps, ok := v.([]interface{})
+	if !ok {
+		return nil, errInvalidReply
+	}
+
+	args := []interface{}{"XCLAIM", stream, group, consumer, int64(minIdle / time.Millisecond)}
+	for _, p := range ps {
+		p, ok := p.([]interface{})
+		if !ok || len(p) != 4 {
+			return nil, errInvalidReply
+		}
+
+		if idle, ok := p[2].(int64); ok && time.Duration(idle)*time.Millisecond >= minIdle {
+			args = append(args, p[0])
+		}
+	}
+
+	if len(args) == 5 {
+		return nil, nil
+	}
+
+	cmd := redis.NewCmd(args...)
+	c.Process(cmd)
+
+	v, err = cmd.Result()
+	if err != nil {
+		return nil, err
+	}
+
+	return parseEntries(v)
+}
+
+// parseEntries parses a list of [id, [field, value, ...]] entries.
+func parseEntries(v interface{}) ([]Entry, error) {
+	es, ok := v.([]interface{})
+	if !ok {
+		return nil, errInvalidReply
+	}
+
+	entries := make([]Entry, 0, len(es))
+	for _, e := range es {
+		e, ok := e.([]interface{})
+		if !ok || len(e) != 2 {
+			return nil, errInvalidReply
+		}
+
+		id, ok := e[0].(string)
+		if !ok {
+			return nil, errInvalidReply
+		}
+
+		// entries deleted while pending have no values
+		fields, _ := e[1].([]interface{})
+
+		values := make(map[string]string, len(fields)/2)
+		for i := 0; i+1 < len(fields); i += 2 {
+			k, _ := fields[i].(string)
+			v, _ := fields[i+1].(string)
+			values[k] = v
+		}
+
+		entries = append(entries, Entry{id, values})
+	}
+
+	return entries, nil
+}
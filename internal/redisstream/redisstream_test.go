@@ -0,0 +1,70 @@
+package redisstream
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis"
+)
+
+func TestReadGroup(t *testing.T) {
+	s, err := miniredis.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer s.Close()
+
+	c := redis.NewClient(&redis.Options{Addr: s.Addr()})
+	defer c.Close()
+
+	for i := 0; i < 2; i++ {
+		if err := CreateGroup(c, "s", "g"); err != nil {
+			t.Fatalf("CreateGroup() error = %v", err)
+		}
+	}
+
+	id, err := Add(c, "s", 10, map[string]string{"k": "v"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := ReadGroup(c, "s", "g", "a", ">", 10, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(entries) != 1 || entries[0].ID != id || entries[0].Values["k"] != "v" {
+		t.Fatalf("ReadGroup() = %v, want entry %v", entries, id)
+	}
+
+	// pending for a, not delivered again as new
+	if entries, err := ReadGroup(c, "s", "g", "b", ">", 10, 0); err != nil || len(entries) != 0 {
+		t.Errorf("ReadGroup() new = %v, %v, want none", entries, err)
+	}
+
+	if entries, err := ReadGroup(c, "s", "g", "a", "0", 10, 0); err != nil || len(entries) != 1 {
+		t.Errorf("ReadGroup() pending = %v, %v, want 1 entry", entries, err)
+	}
+
+	if entries, err := Claim(c, "s", "g", "b", time.Hour, 10); err != nil || len(entries) != 0 {
+		t.Errorf("Claim() before idle = %v, %v, want none", entries, err)
+	}
+
+	s.FastForward(2 * time.Hour)
+	time.Sleep(10 * time.Millisecond)
+
+	entries, err = Claim(c, "s", "g", "b", time.Millisecond, 10)
+	if err != nil || len(entries) != 1 || entries[0].ID != id {
+		t.Fatalf("Claim() = %v, %v, want entry %v", entries, err, id)
+	}
+
+	if err := Ack(c, "s", "g", id); err != nil {
+		t.Fatal(err)
+	}
+
+	if entries, err := ReadGroup(c, "s", "g", "b", "0", 10, 0); err != nil || len(entries) != 0 {
+		t.Errorf("ReadGroup() pending after Ack = %v, %v, want none", entries, err)
+	}
+}
@@ -18,6 +18,7 @@ type TestResponse struct {
 	User   string `json:"user"`
 	TeamID string `json:"team_id"`
 	UserID string `json:"user_id"`
+	BotID  string `json:"bot_id,omitempty"`
 }
 
 func Test(req *TestRequest) (*TestResponse, error) {
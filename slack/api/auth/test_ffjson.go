@@ -52,6 +52,8 @@ const (
 	ffjtTestResponseTeamID
 
 	ffjtTestResponseUserID
+
+	ffjtTestResponseBotID
 )
 
 var ffjKeyTestResponseURL = []byte("url")
@@ -64,6 +66,8 @@ var ffjKeyTestResponseTeamID = []byte("team_id")
 
 var ffjKeyTestResponseUserID = []byte("user_id")
 
+var ffjKeyTestResponseBotID = []byte("bot_id")
+
 // UnmarshalJSON umarshall json - template of ffjson
 func (j *TestResponse) UnmarshalJSON(input []byte) error {
 	fs := fflib.NewFFLexer(input)
@@ -125,6 +129,14 @@ mainparse:
 			} else {
 				switch kn[0] {
 
+				case 'b':
+
+					if bytes.Equal(ffjKeyTestResponseBotID, kn) {
+						currentKey = ffjtTestResponseBotID
+						state = fflib.FFParse_want_colon
+						goto mainparse
+					}
+
 				case 't':
 
 					if bytes.Equal(ffjKeyTestResponseTeam, kn) {
@@ -158,6 +170,12 @@ mainparse:
 
 				}
 
+				if fflib.AsciiEqualFold(ffjKeyTestResponseBotID, kn) {
+					currentKey = ffjtTestResponseBotID
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
 				if fflib.EqualFoldRight(ffjKeyTestResponseUserID, kn) {
 					currentKey = ffjtTestResponseUserID
 					state = fflib.FFParse_want_colon
@@ -220,6 +238,9 @@ mainparse:
 				case ffjtTestResponseUserID:
 					goto handle_UserID
 
+				case ffjtTestResponseBotID:
+					goto handle_BotID
+
 				case ffjtTestResponsenosuchkey:
 					err = fs.SkipField(tok)
 					if err != nil {
@@ -364,6 +385,32 @@ handle_UserID:
 	state = fflib.FFParse_after_value
 	goto mainparse
 
+handle_BotID:
+
+	/* handler: j.BotID type=string kind=string quoted=false*/
+
+	{
+
+		{
+			if tok != fflib.FFTok_string && tok != fflib.FFTok_null {
+				return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for string", tok))
+			}
+		}
+
+		if tok == fflib.FFTok_null {
+
+		} else {
+
+			outBuf := fs.Output.Bytes()
+
+			j.BotID = string(string(outBuf))
+
+		}
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
 wantedvalue:
 	return fs.WrapErr(fmt.Errorf("wanted value token, but got token: %v", tok))
 wrongtokenerror:
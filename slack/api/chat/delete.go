@@ -0,0 +1,32 @@
+package chat
+
+import (
+	"github.com/pkg/errors"
+
+	"suy.io/bots/slack/api"
+)
+
+// ffjson: nodecoder
+type DeleteRequest struct {
+	Token   string `json:"token" url:"token"`
+	Channel string `json:"channel" url:"channel"`
+	Ts      string `json:"ts" url:"ts"`
+	AsUser  bool   `json:"as_user,omitempty" url:"as_user,omitempty"`
+}
+
+// ffjson: noencoder
+type DeleteResponse struct {
+	Channel string `json:"channel"`
+	Ts      string `json:"ts"`
+}
+
+func Delete(req *DeleteRequest) (*DeleteResponse, error) {
+	res := &DeleteResponse{}
+	if err := api.Request("chat.delete", req, true, res, req.Token); err != nil {
+		return nil, errors.Wrap(err, "chat.delete failed")
+	}
+
+	return res, nil
+}
+
+//go:generate ffjson $GOFILE
@@ -1,6 +1,8 @@
 package chat // import "suy.io/bots/slack/api/chat"
 
 import (
+	"encoding/json"
+
 	"suy.io/bots/slack/api/rtm"
 )
 
@@ -35,24 +37,25 @@ func RTMMessage(msg *Message) *rtm.Message {
 }
 
 type Attachment struct {
-	Actions    []*Action `json:"actions,omitempty" url:"actions,omitempty"`
-	AuthorIcon string    `json:"author_icon,omitempty" url:"author_icon,omitempty"`
-	AuthorLink string    `json:"author_link,omitempty" url:"author_link,omitempty"`
-	AuthorName string    `json:"author_name,omitempty" url:"author_name,omitempty"`
-	CallbackID string    `json:"callback_id" url:"callback_id"`
-	Color      string    `json:"color,omitempty" url:"color,omitempty"`
-	Fallback   string    `json:"fallback,omitempty" url:"fallback,omitempty"`
-	Fields     []*Field  `json:"fields,omitempty" url:"fields,omitempty"`
-	Footer     string    `json:"footer,omitempty" url:"footer,omitempty"`
-	FooterIcon string    `json:"footer_icon,omitempty" url:"footer_icon,omitempty"`
-	ImageURL   string    `json:"image_url,omitempty" url:"image_url,omitempty"`
-	MrkdwnIn   []string  `json:"mrkdwn_in,omitempty" url:"mrkdwn_in,omitempty"`
-	Pretext    string    `json:"pretext,omitempty" url:"pretext,omitempty"`
-	Text       string    `json:"text,omitempty" url:"text,omitempty"`
-	ThumbURL   string    `json:"thumb_url,omitempty" url:"thumb_url,omitempty"`
-	Title      string    `json:"title,omitempty" url:"title,omitempty"`
-	TitleLink  string    `json:"title_link,omitempty" url:"title_link,omitempty"`
-	Ts         int       `json:"ts,omitempty" url:"ts,omitempty"`
+	Actions    []*Action       `json:"actions,omitempty" url:"actions,omitempty"`
+	AuthorIcon string          `json:"author_icon,omitempty" url:"author_icon,omitempty"`
+	AuthorLink string          `json:"author_link,omitempty" url:"author_link,omitempty"`
+	AuthorName string          `json:"author_name,omitempty" url:"author_name,omitempty"`
+	Blocks     json.RawMessage `json:"blocks,omitempty" url:"blocks,omitempty"`
+	CallbackID string          `json:"callback_id" url:"callback_id"`
+	Color      string          `json:"color,omitempty" url:"color,omitempty"`
+	Fallback   string          `json:"fallback,omitempty" url:"fallback,omitempty"`
+	Fields     []*Field        `json:"fields,omitempty" url:"fields,omitempty"`
+	Footer     string          `json:"footer,omitempty" url:"footer,omitempty"`
+	FooterIcon string          `json:"footer_icon,omitempty" url:"footer_icon,omitempty"`
+	ImageURL   string          `json:"image_url,omitempty" url:"image_url,omitempty"`
+	MrkdwnIn   []string        `json:"mrkdwn_in,omitempty" url:"mrkdwn_in,omitempty"`
+	Pretext    string          `json:"pretext,omitempty" url:"pretext,omitempty"`
+	Text       string          `json:"text,omitempty" url:"text,omitempty"`
+	ThumbURL   string          `json:"thumb_url,omitempty" url:"thumb_url,omitempty"`
+	Title      string          `json:"title,omitempty" url:"title,omitempty"`
+	TitleLink  string          `json:"title_link,omitempty" url:"title_link,omitempty"`
+	Ts         int             `json:"ts,omitempty" url:"ts,omitempty"`
 }
 
 type ActionType string
@@ -1175,6 +1175,20 @@ func (j *Attachment) MarshalJSONBuf(buf fflib.EncodingBuffer) error {
 		fflib.WriteJsonString(buf, string(j.AuthorName))
 		buf.WriteByte(',')
 	}
+	if len(j.Blocks) != 0 {
+		buf.WriteString(`"blocks":`)
+
+		{
+
+			obj, err = j.Blocks.MarshalJSON()
+			if err != nil {
+				return err
+			}
+			buf.Write(obj)
+
+		}
+		buf.WriteByte(',')
+	}
 	buf.WriteString(`"callback_id":`)
 	fflib.WriteJsonString(buf, string(j.CallbackID))
 	buf.WriteByte(',')
@@ -1296,6 +1310,8 @@ const (
 
 	ffjtAttachmentAuthorName
 
+	ffjtAttachmentBlocks
+
 	ffjtAttachmentCallbackID
 
 	ffjtAttachmentColor
@@ -1333,6 +1349,8 @@ var ffjKeyAttachmentAuthorLink = []byte("author_link")
 
 var ffjKeyAttachmentAuthorName = []byte("author_name")
 
+var ffjKeyAttachmentBlocks = []byte("blocks")
+
 var ffjKeyAttachmentCallbackID = []byte("callback_id")
 
 var ffjKeyAttachmentColor = []byte("color")
@@ -1445,6 +1463,14 @@ mainparse:
 						goto mainparse
 					}
 
+				case 'b':
+
+					if bytes.Equal(ffjKeyAttachmentBlocks, kn) {
+						currentKey = ffjtAttachmentBlocks
+						state = fflib.FFParse_want_colon
+						goto mainparse
+					}
+
 				case 'c':
 
 					if bytes.Equal(ffjKeyAttachmentCallbackID, kn) {
@@ -1619,6 +1645,12 @@ mainparse:
 					goto mainparse
 				}
 
+				if fflib.EqualFoldRight(ffjKeyAttachmentBlocks, kn) {
+					currentKey = ffjtAttachmentBlocks
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
 				if fflib.AsciiEqualFold(ffjKeyAttachmentAuthorName, kn) {
 					currentKey = ffjtAttachmentAuthorName
 					state = fflib.FFParse_want_colon
@@ -1672,6 +1704,9 @@ mainparse:
 				case ffjtAttachmentAuthorName:
 					goto handle_AuthorName
 
+				case ffjtAttachmentBlocks:
+					goto handle_Blocks
+
 				case ffjtAttachmentCallbackID:
 					goto handle_CallbackID
 
@@ -1880,6 +1915,31 @@ handle_AuthorName:
 	state = fflib.FFParse_after_value
 	goto mainparse
 
+handle_Blocks:
+
+	/* handler: j.Blocks type=json.RawMessage kind=slice quoted=false*/
+
+	{
+		if tok == fflib.FFTok_null {
+
+		} else {
+
+			tbuf, err := fs.CaptureField(tok)
+			if err != nil {
+				return fs.WrapErr(err)
+			}
+
+			err = j.Blocks.UnmarshalJSON(tbuf)
+			if err != nil {
+				return fs.WrapErr(err)
+			}
+		}
+		state = fflib.FFParse_after_value
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
 handle_CallbackID:
 
 	/* handler: j.CallbackID type=string kind=string quoted=false*/
@@ -0,0 +1,28 @@
+package chat
+
+import (
+	"github.com/pkg/errors"
+
+	"suy.io/bots/slack/api"
+)
+
+// ffjson: nodecoder
+type UnfurlRequest struct {
+	Token            string                 `json:"token" url:"token"`
+	Channel          string                 `json:"channel" url:"channel"`
+	Ts               string                 `json:"ts" url:"ts"`
+	Unfurls          map[string]*Attachment `json:"unfurls" url:"unfurls"`
+	UserAuthMessage  string                 `json:"user_auth_message,omitempty" url:"user_auth_message,omitempty"`
+	UserAuthRequired bool                   `json:"user_auth_required,omitempty" url:"user_auth_required,omitempty"`
+	UserAuthURL      string                 `json:"user_auth_url,omitempty" url:"user_auth_url,omitempty"`
+}
+
+func Unfurl(req *UnfurlRequest) error {
+	if err := api.Request("chat.unfurl", req, true, nil, req.Token); err != nil {
+		return errors.Wrap(err, "chat.unfurl failed")
+	}
+
+	return nil
+}
+
+//go:generate ffjson $GOFILE
@@ -0,0 +1,68 @@
+// Code generated by ffjson <https://github.com/pquerna/ffjson>. DO NOT EDIT.
+// source: unfurl.go
+
+package chat
+
+import (
+	fflib "github.com/pquerna/ffjson/fflib/v1"
+)
+
+// MarshalJSON marshal bytes to json - template
+func (j *UnfurlRequest) MarshalJSON() ([]byte, error) {
+	var buf fflib.Buffer
+	if j == nil {
+		buf.WriteString("null")
+		return buf.Bytes(), nil
+	}
+	err := j.MarshalJSONBuf(&buf)
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// MarshalJSONBuf marshal buff to json - template
+func (j *UnfurlRequest) MarshalJSONBuf(buf fflib.EncodingBuffer) error {
+	if j == nil {
+		buf.WriteString("null")
+		return nil
+	}
+	var err error
+	var obj []byte
+	_ = obj
+	_ = err
+	buf.WriteString(`{ "token":`)
+	fflib.WriteJsonString(buf, string(j.Token))
+	buf.WriteString(`,"channel":`)
+	fflib.WriteJsonString(buf, string(j.Channel))
+	buf.WriteString(`,"ts":`)
+	fflib.WriteJsonString(buf, string(j.Ts))
+	buf.WriteString(`,"unfurls":`)
+	/* Falling back. type=map[string]*chat.Attachment kind=map */
+	err = buf.Encode(j.Unfurls)
+	if err != nil {
+		return err
+	}
+	buf.WriteByte(',')
+	if len(j.UserAuthMessage) != 0 {
+		buf.WriteString(`"user_auth_message":`)
+		fflib.WriteJsonString(buf, string(j.UserAuthMessage))
+		buf.WriteByte(',')
+	}
+	if j.UserAuthRequired != false {
+		if j.UserAuthRequired {
+			buf.WriteString(`"user_auth_required":true`)
+		} else {
+			buf.WriteString(`"user_auth_required":false`)
+		}
+		buf.WriteByte(',')
+	}
+	if len(j.UserAuthURL) != 0 {
+		buf.WriteString(`"user_auth_url":`)
+		fflib.WriteJsonString(buf, string(j.UserAuthURL))
+		buf.WriteByte(',')
+	}
+	buf.Rewind(1)
+	buf.WriteByte('}')
+	return nil
+}
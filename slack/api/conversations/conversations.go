@@ -0,0 +1,55 @@
+package conversations // import "suy.io/bots/slack/api/conversations"
+
+type Channel struct {
+	ID                 string   `json:"id"`
+	Name               string   `json:"name"`
+	IsChannel          bool     `json:"is_channel"`
+	IsGroup            bool     `json:"is_group"`
+	IsIm               bool     `json:"is_im"`
+	IsMpim             bool     `json:"is_mpim"`
+	IsPrivate          bool     `json:"is_private"`
+	Created            int      `json:"created"`
+	Creator            string   `json:"creator"`
+	IsArchived         bool     `json:"is_archived"`
+	IsGeneral          bool     `json:"is_general"`
+	IsMember           bool     `json:"is_member"`
+	IsShared           bool     `json:"is_shared"`
+	IsExtShared        bool     `json:"is_ext_shared"`
+	IsOrgShared        bool     `json:"is_org_shared"`
+	IsPendingExtShared bool     `json:"is_pending_ext_shared"`
+	NameNormalized     string   `json:"name_normalized"`
+	User               string   `json:"user,omitempty"`
+	Locale             string   `json:"locale,omitempty"`
+	NumMembers         int      `json:"num_members,omitempty"`
+	Members            []string `json:"members,omitempty"`
+	Topic              *Topic   `json:"topic,omitempty"`
+	Purpose            *Topic   `json:"purpose,omitempty"`
+}
+
+type Topic struct {
+	Value   string `json:"value"`
+	Creator string `json:"creator"`
+	LastSet int    `json:"last_set"`
+}
+
+type Message struct {
+	Type            string   `json:"type"`
+	SubType         string   `json:"subtype,omitempty"`
+	User            string   `json:"user,omitempty"`
+	BotID           string   `json:"bot_id,omitempty"`
+	Text            string   `json:"text"`
+	Ts              string   `json:"ts"`
+	ThreadTs        string   `json:"thread_ts,omitempty"`
+	ParentUserID    string   `json:"parent_user_id,omitempty"`
+	ReplyCount      int      `json:"reply_count,omitempty"`
+	ReplyUsers      []string `json:"reply_users,omitempty"`
+	ReplyUsersCount int      `json:"reply_users_count,omitempty"`
+	LatestReply     string   `json:"latest_reply,omitempty"`
+}
+
+// ResponseMetadata holds the cursor to be passed to get the next page of a paginated response.
+type ResponseMetadata struct {
+	NextCursor string `json:"next_cursor"`
+}
+
+//go:generate ffjson $GOFILE
@@ -0,0 +1,56 @@
+package conversations
+
+import (
+	"github.com/pkg/errors"
+
+	"suy.io/bots/slack/api"
+)
+
+// ffjson: nodecoder
+type HistoryRequest struct {
+	Token     string `json:"token" url:"token"`
+	Channel   string `json:"channel" url:"channel"`
+	Cursor    string `json:"cursor,omitempty" url:"cursor,omitempty"`
+	Inclusive bool   `json:"inclusive,omitempty" url:"inclusive,omitempty"`
+	Latest    string `json:"latest,omitempty" url:"latest,omitempty"`
+	Limit     int    `json:"limit,omitempty" url:"limit,omitempty"`
+	Oldest    string `json:"oldest,omitempty" url:"oldest,omitempty"`
+}
+
+// ffjson: noencoder
+type HistoryResponse struct {
+	Messages         []*Message        `json:"messages"`
+	HasMore          bool              `json:"has_more"`
+	PinCount         int               `json:"pin_count"`
+	ResponseMetadata *ResponseMetadata `json:"response_metadata"`
+}
+
+func History(req *HistoryRequest) (*HistoryResponse, error) {
+	res := &HistoryResponse{}
+	if err := api.Request("conversations.history", req, false, res, ""); err != nil {
+		return nil, errors.Wrap(err, "conversations.history failed")
+	}
+
+	return res, nil
+}
+
+// HistoryPages calls conversations.history repeatedly, following the returned cursor,
+// and passes each page to f until there are no more pages or f returns false.
+func HistoryPages(req *HistoryRequest, f func(*HistoryResponse) bool) error {
+	r := *req
+
+	for {
+		res, err := History(&r)
+		if err != nil {
+			return err
+		}
+
+		if !f(res) || res.ResponseMetadata == nil || res.ResponseMetadata.NextCursor == "" {
+			return nil
+		}
+
+		r.Cursor = res.ResponseMetadata.NextCursor
+	}
+}
+
+//go:generate ffjson $GOFILE
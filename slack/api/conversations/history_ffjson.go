@@ -0,0 +1,439 @@
+// Code generated by ffjson <https://github.com/pquerna/ffjson>. DO NOT EDIT.
+// source: history.go
+
+package conversations
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	fflib "github.com/pquerna/ffjson/fflib/v1"
+)
+
+// MarshalJSON marshal bytes to json - template
+func (j *HistoryRequest) MarshalJSON() ([]byte, error) {
+	var buf fflib.Buffer
+	if j == nil {
+		buf.WriteString("null")
+		return buf.Bytes(), nil
+	}
+	err := j.MarshalJSONBuf(&buf)
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// MarshalJSONBuf marshal buff to json - template
+func (j *HistoryRequest) MarshalJSONBuf(buf fflib.EncodingBuffer) error {
+	if j == nil {
+		buf.WriteString("null")
+		return nil
+	}
+	var err error
+	var obj []byte
+	_ = obj
+	_ = err
+	buf.WriteString(`{ "token":`)
+	fflib.WriteJsonString(buf, string(j.Token))
+	buf.WriteString(`,"channel":`)
+	fflib.WriteJsonString(buf, string(j.Channel))
+	buf.WriteByte(',')
+	if len(j.Cursor) != 0 {
+		buf.WriteString(`"cursor":`)
+		fflib.WriteJsonString(buf, string(j.Cursor))
+		buf.WriteByte(',')
+	}
+	if j.Inclusive != false {
+		if j.Inclusive {
+			buf.WriteString(`"inclusive":true`)
+		} else {
+			buf.WriteString(`"inclusive":false`)
+		}
+		buf.WriteByte(',')
+	}
+	if len(j.Latest) != 0 {
+		buf.WriteString(`"latest":`)
+		fflib.WriteJsonString(buf, string(j.Latest))
+		buf.WriteByte(',')
+	}
+	if j.Limit != 0 {
+		buf.WriteString(`"limit":`)
+		fflib.FormatBits2(buf, uint64(j.Limit), 10, j.Limit < 0)
+		buf.WriteByte(',')
+	}
+	if len(j.Oldest) != 0 {
+		buf.WriteString(`"oldest":`)
+		fflib.WriteJsonString(buf, string(j.Oldest))
+		buf.WriteByte(',')
+	}
+	buf.Rewind(1)
+	buf.WriteByte('}')
+	return nil
+}
+
+const (
+	ffjtHistoryResponsebase = iota
+	ffjtHistoryResponsenosuchkey
+
+	ffjtHistoryResponseMessages
+
+	ffjtHistoryResponseHasMore
+
+	ffjtHistoryResponsePinCount
+
+	ffjtHistoryResponseResponseMetadata
+)
+
+var ffjKeyHistoryResponseMessages = []byte("messages")
+
+var ffjKeyHistoryResponseHasMore = []byte("has_more")
+
+var ffjKeyHistoryResponsePinCount = []byte("pin_count")
+
+var ffjKeyHistoryResponseResponseMetadata = []byte("response_metadata")
+
+// UnmarshalJSON umarshall json - template of ffjson
+func (j *HistoryResponse) UnmarshalJSON(input []byte) error {
+	fs := fflib.NewFFLexer(input)
+	return j.UnmarshalJSONFFLexer(fs, fflib.FFParse_map_start)
+}
+
+// UnmarshalJSONFFLexer fast json unmarshall - template ffjson
+func (j *HistoryResponse) UnmarshalJSONFFLexer(fs *fflib.FFLexer, state fflib.FFParseState) error {
+	var err error
+	currentKey := ffjtHistoryResponsebase
+	_ = currentKey
+	tok := fflib.FFTok_init
+	wantedTok := fflib.FFTok_init
+
+mainparse:
+	for {
+		tok = fs.Scan()
+		//	println(fmt.Sprintf("debug: tok: %v  state: %v", tok, state))
+		if tok == fflib.FFTok_error {
+			goto tokerror
+		}
+
+		switch state {
+
+		case fflib.FFParse_map_start:
+			if tok != fflib.FFTok_left_bracket {
+				wantedTok = fflib.FFTok_left_bracket
+				goto wrongtokenerror
+			}
+			state = fflib.FFParse_want_key
+			continue
+
+		case fflib.FFParse_after_value:
+			if tok == fflib.FFTok_comma {
+				state = fflib.FFParse_want_key
+			} else if tok == fflib.FFTok_right_bracket {
+				goto done
+			} else {
+				wantedTok = fflib.FFTok_comma
+				goto wrongtokenerror
+			}
+
+		case fflib.FFParse_want_key:
+			// json {} ended. goto exit. woo.
+			if tok == fflib.FFTok_right_bracket {
+				goto done
+			}
+			if tok != fflib.FFTok_string {
+				wantedTok = fflib.FFTok_string
+				goto wrongtokenerror
+			}
+
+			kn := fs.Output.Bytes()
+			if len(kn) <= 0 {
+				// "" case. hrm.
+				currentKey = ffjtHistoryResponsenosuchkey
+				state = fflib.FFParse_want_colon
+				goto mainparse
+			} else {
+				switch kn[0] {
+
+				case 'h':
+
+					if bytes.Equal(ffjKeyHistoryResponseHasMore, kn) {
+						currentKey = ffjtHistoryResponseHasMore
+						state = fflib.FFParse_want_colon
+						goto mainparse
+					}
+
+				case 'm':
+
+					if bytes.Equal(ffjKeyHistoryResponseMessages, kn) {
+						currentKey = ffjtHistoryResponseMessages
+						state = fflib.FFParse_want_colon
+						goto mainparse
+					}
+
+				case 'p':
+
+					if bytes.Equal(ffjKeyHistoryResponsePinCount, kn) {
+						currentKey = ffjtHistoryResponsePinCount
+						state = fflib.FFParse_want_colon
+						goto mainparse
+					}
+
+				case 'r':
+
+					if bytes.Equal(ffjKeyHistoryResponseResponseMetadata, kn) {
+						currentKey = ffjtHistoryResponseResponseMetadata
+						state = fflib.FFParse_want_colon
+						goto mainparse
+					}
+
+				}
+
+				if fflib.EqualFoldRight(ffjKeyHistoryResponseResponseMetadata, kn) {
+					currentKey = ffjtHistoryResponseResponseMetadata
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
+				if fflib.AsciiEqualFold(ffjKeyHistoryResponsePinCount, kn) {
+					currentKey = ffjtHistoryResponsePinCount
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
+				if fflib.EqualFoldRight(ffjKeyHistoryResponseHasMore, kn) {
+					currentKey = ffjtHistoryResponseHasMore
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
+				if fflib.EqualFoldRight(ffjKeyHistoryResponseMessages, kn) {
+					currentKey = ffjtHistoryResponseMessages
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
+				currentKey = ffjtHistoryResponsenosuchkey
+				state = fflib.FFParse_want_colon
+				goto mainparse
+			}
+
+		case fflib.FFParse_want_colon:
+			if tok != fflib.FFTok_colon {
+				wantedTok = fflib.FFTok_colon
+				goto wrongtokenerror
+			}
+			state = fflib.FFParse_want_value
+			continue
+		case fflib.FFParse_want_value:
+
+			if tok == fflib.FFTok_left_brace || tok == fflib.FFTok_left_bracket || tok == fflib.FFTok_integer || tok == fflib.FFTok_double || tok == fflib.FFTok_string || tok == fflib.FFTok_bool || tok == fflib.FFTok_null {
+				switch currentKey {
+
+				case ffjtHistoryResponseMessages:
+					goto handle_Messages
+
+				case ffjtHistoryResponseHasMore:
+					goto handle_HasMore
+
+				case ffjtHistoryResponsePinCount:
+					goto handle_PinCount
+
+				case ffjtHistoryResponseResponseMetadata:
+					goto handle_ResponseMetadata
+
+				case ffjtHistoryResponsenosuchkey:
+					err = fs.SkipField(tok)
+					if err != nil {
+						return fs.WrapErr(err)
+					}
+					state = fflib.FFParse_after_value
+					goto mainparse
+				}
+			} else {
+				goto wantedvalue
+			}
+		}
+	}
+
+handle_Messages:
+
+	/* handler: j.Messages type=[]*conversations.Message kind=slice quoted=false*/
+
+	{
+
+		{
+			if tok != fflib.FFTok_left_brace && tok != fflib.FFTok_null {
+				return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for ", tok))
+			}
+		}
+
+		if tok == fflib.FFTok_null {
+			j.Messages = nil
+		} else {
+
+			j.Messages = []*Message{}
+
+			wantVal := true
+
+			for {
+
+				var tmpJMessages *Message
+
+				tok = fs.Scan()
+				if tok == fflib.FFTok_error {
+					goto tokerror
+				}
+				if tok == fflib.FFTok_right_brace {
+					break
+				}
+
+				if tok == fflib.FFTok_comma {
+					if wantVal == true {
+						// TODO(pquerna): this isn't an ideal error message, this handles
+						// things like [,,,] as an array value.
+						return fs.WrapErr(fmt.Errorf("wanted value token, but got token: %v", tok))
+					}
+					continue
+				} else {
+					wantVal = true
+				}
+
+				/* handler: tmpJMessages type=*conversations.Message kind=ptr quoted=false*/
+
+				{
+					if tok == fflib.FFTok_null {
+
+						tmpJMessages = nil
+
+					} else {
+
+						if tmpJMessages == nil {
+							tmpJMessages = new(Message)
+						}
+
+						err = tmpJMessages.UnmarshalJSONFFLexer(fs, fflib.FFParse_want_key)
+						if err != nil {
+							return err
+						}
+					}
+					state = fflib.FFParse_after_value
+				}
+
+				j.Messages = append(j.Messages, tmpJMessages)
+
+				wantVal = false
+			}
+		}
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+handle_HasMore:
+
+	/* handler: j.HasMore type=bool kind=bool quoted=false*/
+
+	{
+		if tok != fflib.FFTok_bool && tok != fflib.FFTok_null {
+			return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for bool", tok))
+		}
+	}
+
+	{
+		if tok == fflib.FFTok_null {
+
+		} else {
+			tmpb := fs.Output.Bytes()
+
+			if bytes.Compare([]byte{'t', 'r', 'u', 'e'}, tmpb) == 0 {
+
+				j.HasMore = true
+
+			} else if bytes.Compare([]byte{'f', 'a', 'l', 's', 'e'}, tmpb) == 0 {
+
+				j.HasMore = false
+
+			} else {
+				err = errors.New("unexpected bytes for true/false value")
+				return fs.WrapErr(err)
+			}
+
+		}
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+handle_PinCount:
+
+	/* handler: j.PinCount type=int kind=int quoted=false*/
+
+	{
+		if tok != fflib.FFTok_integer && tok != fflib.FFTok_null {
+			return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for int", tok))
+		}
+	}
+
+	{
+
+		if tok == fflib.FFTok_null {
+
+		} else {
+
+			tval, err := fflib.ParseInt(fs.Output.Bytes(), 10, 64)
+
+			if err != nil {
+				return fs.WrapErr(err)
+			}
+
+			j.PinCount = int(tval)
+
+		}
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+handle_ResponseMetadata:
+
+	/* handler: j.ResponseMetadata type=conversations.ResponseMetadata kind=struct quoted=false*/
+
+	{
+		if tok == fflib.FFTok_null {
+
+			j.ResponseMetadata = nil
+
+		} else {
+
+			if j.ResponseMetadata == nil {
+				j.ResponseMetadata = new(ResponseMetadata)
+			}
+
+			err = j.ResponseMetadata.UnmarshalJSONFFLexer(fs, fflib.FFParse_want_key)
+			if err != nil {
+				return err
+			}
+		}
+		state = fflib.FFParse_after_value
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+wantedvalue:
+	return fs.WrapErr(fmt.Errorf("wanted value token, but got token: %v", tok))
+wrongtokenerror:
+	return fs.WrapErr(fmt.Errorf("ffjson: wanted token: %v, but got token: %v output=%s", wantedTok, tok, fs.Output.String()))
+tokerror:
+	if fs.BigError != nil {
+		return fs.WrapErr(fs.BigError)
+	}
+	err = fs.Error.ToError()
+	if err != nil {
+		return fs.WrapErr(err)
+	}
+	panic("ffjson-generated: unreachable, please report bug.")
+done:
+
+	return nil
+}
@@ -0,0 +1,31 @@
+package conversations
+
+import (
+	"github.com/pkg/errors"
+
+	"suy.io/bots/slack/api"
+)
+
+// ffjson: nodecoder
+type InfoRequest struct {
+	Token             string `json:"token" url:"token"`
+	Channel           string `json:"channel" url:"channel"`
+	IncludeLocale     bool   `json:"include_locale,omitempty" url:"include_locale,omitempty"`
+	IncludeNumMembers bool   `json:"include_num_members,omitempty" url:"include_num_members,omitempty"`
+}
+
+// ffjson: noencoder
+type InfoResponse struct {
+	Channel *Channel `json:"channel"`
+}
+
+func Info(req *InfoRequest) (*InfoResponse, error) {
+	res := &InfoResponse{}
+	if err := api.Request("conversations.info", req, false, res, ""); err != nil {
+		return nil, errors.Wrap(err, "conversations.info failed")
+	}
+
+	return res, nil
+}
+
+//go:generate ffjson $GOFILE
@@ -0,0 +1,30 @@
+package conversations
+
+import (
+	"github.com/pkg/errors"
+
+	"suy.io/bots/slack/api"
+)
+
+// ffjson: nodecoder
+type InviteRequest struct {
+	Token   string `json:"token" url:"token"`
+	Channel string `json:"channel" url:"channel"`
+	Users   string `json:"users" url:"users"`
+}
+
+// ffjson: noencoder
+type InviteResponse struct {
+	Channel *Channel `json:"channel"`
+}
+
+func Invite(req *InviteRequest) (*InviteResponse, error) {
+	res := &InviteResponse{}
+	if err := api.Request("conversations.invite", req, true, res, req.Token); err != nil {
+		return nil, errors.Wrap(err, "conversations.invite failed")
+	}
+
+	return res, nil
+}
+
+//go:generate ffjson $GOFILE
@@ -0,0 +1,30 @@
+package conversations
+
+import (
+	"github.com/pkg/errors"
+
+	"suy.io/bots/slack/api"
+)
+
+// ffjson: nodecoder
+type JoinRequest struct {
+	Token   string `json:"token" url:"token"`
+	Channel string `json:"channel" url:"channel"`
+}
+
+// ffjson: noencoder
+type JoinResponse struct {
+	Channel *Channel `json:"channel"`
+	Warning string   `json:"warning"`
+}
+
+func Join(req *JoinRequest) (*JoinResponse, error) {
+	res := &JoinResponse{}
+	if err := api.Request("conversations.join", req, true, res, req.Token); err != nil {
+		return nil, errors.Wrap(err, "conversations.join failed")
+	}
+
+	return res, nil
+}
+
+//go:generate ffjson $GOFILE
@@ -0,0 +1,52 @@
+package conversations
+
+import (
+	"github.com/pkg/errors"
+
+	"suy.io/bots/slack/api"
+)
+
+// ffjson: nodecoder
+type ListRequest struct {
+	Token           string `json:"token" url:"token"`
+	Cursor          string `json:"cursor,omitempty" url:"cursor,omitempty"`
+	ExcludeArchived bool   `json:"exclude_archived,omitempty" url:"exclude_archived,omitempty"`
+	Limit           int    `json:"limit,omitempty" url:"limit,omitempty"`
+	Types           string `json:"types,omitempty" url:"types,omitempty"`
+}
+
+// ffjson: noencoder
+type ListResponse struct {
+	Channels         []*Channel        `json:"channels"`
+	ResponseMetadata *ResponseMetadata `json:"response_metadata"`
+}
+
+func List(req *ListRequest) (*ListResponse, error) {
+	res := &ListResponse{}
+	if err := api.Request("conversations.list", req, false, res, ""); err != nil {
+		return nil, errors.Wrap(err, "conversations.list failed")
+	}
+
+	return res, nil
+}
+
+// ListPages calls conversations.list repeatedly, following the returned cursor,
+// and passes each page to f until there are no more pages or f returns false.
+func ListPages(req *ListRequest, f func(*ListResponse) bool) error {
+	r := *req
+
+	for {
+		res, err := List(&r)
+		if err != nil {
+			return err
+		}
+
+		if !f(res) || res.ResponseMetadata == nil || res.ResponseMetadata.NextCursor == "" {
+			return nil
+		}
+
+		r.Cursor = res.ResponseMetadata.NextCursor
+	}
+}
+
+//go:generate ffjson $GOFILE
@@ -0,0 +1,51 @@
+package conversations
+
+import (
+	"github.com/pkg/errors"
+
+	"suy.io/bots/slack/api"
+)
+
+// ffjson: nodecoder
+type MembersRequest struct {
+	Token   string `json:"token" url:"token"`
+	Channel string `json:"channel" url:"channel"`
+	Cursor  string `json:"cursor,omitempty" url:"cursor,omitempty"`
+	Limit   int    `json:"limit,omitempty" url:"limit,omitempty"`
+}
+
+// ffjson: noencoder
+type MembersResponse struct {
+	Members          []string          `json:"members"`
+	ResponseMetadata *ResponseMetadata `json:"response_metadata"`
+}
+
+func Members(req *MembersRequest) (*MembersResponse, error) {
+	res := &MembersResponse{}
+	if err := api.Request("conversations.members", req, false, res, ""); err != nil {
+		return nil, errors.Wrap(err, "conversations.members failed")
+	}
+
+	return res, nil
+}
+
+// MembersPages calls conversations.members repeatedly, following the returned cursor,
+// and passes each page to f until there are no more pages or f returns false.
+func MembersPages(req *MembersRequest, f func(*MembersResponse) bool) error {
+	r := *req
+
+	for {
+		res, err := Members(&r)
+		if err != nil {
+			return err
+		}
+
+		if !f(res) || res.ResponseMetadata == nil || res.ResponseMetadata.NextCursor == "" {
+			return nil
+		}
+
+		r.Cursor = res.ResponseMetadata.NextCursor
+	}
+}
+
+//go:generate ffjson $GOFILE
@@ -0,0 +1,33 @@
+package conversations
+
+import (
+	"github.com/pkg/errors"
+
+	"suy.io/bots/slack/api"
+)
+
+// ffjson: nodecoder
+type OpenRequest struct {
+	Token    string `json:"token" url:"token"`
+	Channel  string `json:"channel,omitempty" url:"channel,omitempty"`
+	ReturnIm bool   `json:"return_im,omitempty" url:"return_im,omitempty"`
+	Users    string `json:"users,omitempty" url:"users,omitempty"`
+}
+
+// ffjson: noencoder
+type OpenResponse struct {
+	NoOp        bool     `json:"no_op"`
+	AlreadyOpen bool     `json:"already_open"`
+	Channel     *Channel `json:"channel"`
+}
+
+func Open(req *OpenRequest) (*OpenResponse, error) {
+	res := &OpenResponse{}
+	if err := api.Request("conversations.open", req, true, res, req.Token); err != nil {
+		return nil, errors.Wrap(err, "conversations.open failed")
+	}
+
+	return res, nil
+}
+
+//go:generate ffjson $GOFILE
@@ -0,0 +1,56 @@
+package conversations
+
+import (
+	"github.com/pkg/errors"
+
+	"suy.io/bots/slack/api"
+)
+
+// ffjson: nodecoder
+type RepliesRequest struct {
+	Token     string `json:"token" url:"token"`
+	Channel   string `json:"channel" url:"channel"`
+	Ts        string `json:"ts" url:"ts"`
+	Cursor    string `json:"cursor,omitempty" url:"cursor,omitempty"`
+	Inclusive bool   `json:"inclusive,omitempty" url:"inclusive,omitempty"`
+	Latest    string `json:"latest,omitempty" url:"latest,omitempty"`
+	Limit     int    `json:"limit,omitempty" url:"limit,omitempty"`
+	Oldest    string `json:"oldest,omitempty" url:"oldest,omitempty"`
+}
+
+// ffjson: noencoder
+type RepliesResponse struct {
+	Messages         []*Message        `json:"messages"`
+	HasMore          bool              `json:"has_more"`
+	ResponseMetadata *ResponseMetadata `json:"response_metadata"`
+}
+
+func Replies(req *RepliesRequest) (*RepliesResponse, error) {
+	res := &RepliesResponse{}
+	if err := api.Request("conversations.replies", req, false, res, ""); err != nil {
+		return nil, errors.Wrap(err, "conversations.replies failed")
+	}
+
+	return res, nil
+}
+
+// RepliesPages calls conversations.replies repeatedly, following the returned cursor,
+// and passes each page to f until there are no more pages or f returns false.
+func RepliesPages(req *RepliesRequest, f func(*RepliesResponse) bool) error {
+	r := *req
+
+	for {
+		res, err := Replies(&r)
+		if err != nil {
+			return err
+		}
+
+		if !f(res) || res.ResponseMetadata == nil || res.ResponseMetadata.NextCursor == "" {
+			return nil
+		}
+
+		r.Cursor = res.ResponseMetadata.NextCursor
+	}
+}
+
+//go:generate ffjson $GOFILE
@@ -0,0 +1,36 @@
+package files
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/pkg/errors"
+)
+
+// Download writes the contents of a private file URL to w,
+// authenticating the request with the passed token.
+func Download(url, token string, w io.Writer) error {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return errors.Wrap(err, "Download Failed, could not create request")
+	}
+
+	req.Header.Add("Authorization", "Bearer "+token)
+
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "Download Failed, could not send request")
+	}
+
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return errors.New("Download Failed, " + res.Status)
+	}
+
+	if _, err := io.Copy(w, res.Body); err != nil {
+		return errors.Wrap(err, "Download Failed, could not read file")
+	}
+
+	return nil
+}
@@ -0,0 +1,32 @@
+package files // import "suy.io/bots/slack/api/files"
+
+type File struct {
+	ID                 string   `json:"id"`
+	Created            int      `json:"created"`
+	Timestamp          int      `json:"timestamp"`
+	Name               string   `json:"name"`
+	Title              string   `json:"title"`
+	Mimetype           string   `json:"mimetype"`
+	Filetype           string   `json:"filetype"`
+	PrettyType         string   `json:"pretty_type"`
+	User               string   `json:"user"`
+	Mode               string   `json:"mode"`
+	Editable           bool     `json:"editable"`
+	IsExternal         bool     `json:"is_external"`
+	ExternalType       string   `json:"external_type"`
+	IsPublic           bool     `json:"is_public"`
+	Size               int      `json:"size"`
+	URLPrivate         string   `json:"url_private"`
+	URLPrivateDownload string   `json:"url_private_download"`
+	Thumb64            string   `json:"thumb_64,omitempty"`
+	Thumb360           string   `json:"thumb_360,omitempty"`
+	Thumb480           string   `json:"thumb_480,omitempty"`
+	Permalink          string   `json:"permalink"`
+	PermalinkPublic    string   `json:"permalink_public"`
+	Preview            string   `json:"preview,omitempty"`
+	Channels           []string `json:"channels"`
+	Groups             []string `json:"groups"`
+	Ims                []string `json:"ims"`
+}
+
+//go:generate ffjson $GOFILE
@@ -0,0 +1,1624 @@
+// Code generated by ffjson <https://github.com/pquerna/ffjson>. DO NOT EDIT.
+// source: files.go
+
+package files
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	fflib "github.com/pquerna/ffjson/fflib/v1"
+)
+
+// MarshalJSON marshal bytes to json - template
+func (j *File) MarshalJSON() ([]byte, error) {
+	var buf fflib.Buffer
+	if j == nil {
+		buf.WriteString("null")
+		return buf.Bytes(), nil
+	}
+	err := j.MarshalJSONBuf(&buf)
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// MarshalJSONBuf marshal buff to json - template
+func (j *File) MarshalJSONBuf(buf fflib.EncodingBuffer) error {
+	if j == nil {
+		buf.WriteString("null")
+		return nil
+	}
+	var err error
+	var obj []byte
+	_ = obj
+	_ = err
+	buf.WriteString(`{"id":`)
+	fflib.WriteJsonString(buf, string(j.ID))
+	buf.WriteString(`,"created":`)
+	fflib.FormatBits2(buf, uint64(j.Created), 10, j.Created < 0)
+	buf.WriteString(`,"timestamp":`)
+	fflib.FormatBits2(buf, uint64(j.Timestamp), 10, j.Timestamp < 0)
+	buf.WriteString(`,"name":`)
+	fflib.WriteJsonString(buf, string(j.Name))
+	buf.WriteString(`,"title":`)
+	fflib.WriteJsonString(buf, string(j.Title))
+	buf.WriteString(`,"mimetype":`)
+	fflib.WriteJsonString(buf, string(j.Mimetype))
+	buf.WriteString(`,"filetype":`)
+	fflib.WriteJsonString(buf, string(j.Filetype))
+	buf.WriteString(`,"pretty_type":`)
+	fflib.WriteJsonString(buf, string(j.PrettyType))
+	buf.WriteString(`,"user":`)
+	fflib.WriteJsonString(buf, string(j.User))
+	buf.WriteString(`,"mode":`)
+	fflib.WriteJsonString(buf, string(j.Mode))
+	if j.Editable {
+		buf.WriteString(`,"editable":true`)
+	} else {
+		buf.WriteString(`,"editable":false`)
+	}
+	if j.IsExternal {
+		buf.WriteString(`,"is_external":true`)
+	} else {
+		buf.WriteString(`,"is_external":false`)
+	}
+	buf.WriteString(`,"external_type":`)
+	fflib.WriteJsonString(buf, string(j.ExternalType))
+	if j.IsPublic {
+		buf.WriteString(`,"is_public":true`)
+	} else {
+		buf.WriteString(`,"is_public":false`)
+	}
+	buf.WriteString(`,"size":`)
+	fflib.FormatBits2(buf, uint64(j.Size), 10, j.Size < 0)
+	buf.WriteString(`,"url_private":`)
+	fflib.WriteJsonString(buf, string(j.URLPrivate))
+	buf.WriteString(`,"url_private_download":`)
+	fflib.WriteJsonString(buf, string(j.URLPrivateDownload))
+	buf.WriteByte(',')
+	if len(j.Thumb64) != 0 {
+		buf.WriteString(`"thumb_64":`)
+		fflib.WriteJsonString(buf, string(j.Thumb64))
+		buf.WriteByte(',')
+	}
+	if len(j.Thumb360) != 0 {
+		buf.WriteString(`"thumb_360":`)
+		fflib.WriteJsonString(buf, string(j.Thumb360))
+		buf.WriteByte(',')
+	}
+	if len(j.Thumb480) != 0 {
+		buf.WriteString(`"thumb_480":`)
+		fflib.WriteJsonString(buf, string(j.Thumb480))
+		buf.WriteByte(',')
+	}
+	buf.WriteString(`"permalink":`)
+	fflib.WriteJsonString(buf, string(j.Permalink))
+	buf.WriteString(`,"permalink_public":`)
+	fflib.WriteJsonString(buf, string(j.PermalinkPublic))
+	buf.WriteByte(',')
+	if len(j.Preview) != 0 {
+		buf.WriteString(`"preview":`)
+		fflib.WriteJsonString(buf, string(j.Preview))
+		buf.WriteByte(',')
+	}
+	buf.WriteString(`"channels":`)
+	if j.Channels != nil {
+		buf.WriteString(`[`)
+		for i, v := range j.Channels {
+			if i != 0 {
+				buf.WriteString(`,`)
+			}
+			fflib.WriteJsonString(buf, string(v))
+		}
+		buf.WriteString(`]`)
+	} else {
+		buf.WriteString(`null`)
+	}
+	buf.WriteString(`,"groups":`)
+	if j.Groups != nil {
+		buf.WriteString(`[`)
+		for i, v := range j.Groups {
+			if i != 0 {
+				buf.WriteString(`,`)
+			}
+			fflib.WriteJsonString(buf, string(v))
+		}
+		buf.WriteString(`]`)
+	} else {
+		buf.WriteString(`null`)
+	}
+	buf.WriteString(`,"ims":`)
+	if j.Ims != nil {
+		buf.WriteString(`[`)
+		for i, v := range j.Ims {
+			if i != 0 {
+				buf.WriteString(`,`)
+			}
+			fflib.WriteJsonString(buf, string(v))
+		}
+		buf.WriteString(`]`)
+	} else {
+		buf.WriteString(`null`)
+	}
+	buf.WriteByte('}')
+	return nil
+}
+
+const (
+	ffjtFilebase = iota
+	ffjtFilenosuchkey
+
+	ffjtFileID
+
+	ffjtFileCreated
+
+	ffjtFileTimestamp
+
+	ffjtFileName
+
+	ffjtFileTitle
+
+	ffjtFileMimetype
+
+	ffjtFileFiletype
+
+	ffjtFilePrettyType
+
+	ffjtFileUser
+
+	ffjtFileMode
+
+	ffjtFileEditable
+
+	ffjtFileIsExternal
+
+	ffjtFileExternalType
+
+	ffjtFileIsPublic
+
+	ffjtFileSize
+
+	ffjtFileURLPrivate
+
+	ffjtFileURLPrivateDownload
+
+	ffjtFileThumb64
+
+	ffjtFileThumb360
+
+	ffjtFileThumb480
+
+	ffjtFilePermalink
+
+	ffjtFilePermalinkPublic
+
+	ffjtFilePreview
+
+	ffjtFileChannels
+
+	ffjtFileGroups
+
+	ffjtFileIms
+)
+
+var ffjKeyFileID = []byte("id")
+
+var ffjKeyFileCreated = []byte("created")
+
+var ffjKeyFileTimestamp = []byte("timestamp")
+
+var ffjKeyFileName = []byte("name")
+
+var ffjKeyFileTitle = []byte("title")
+
+var ffjKeyFileMimetype = []byte("mimetype")
+
+var ffjKeyFileFiletype = []byte("filetype")
+
+var ffjKeyFilePrettyType = []byte("pretty_type")
+
+var ffjKeyFileUser = []byte("user")
+
+var ffjKeyFileMode = []byte("mode")
+
+var ffjKeyFileEditable = []byte("editable")
+
+var ffjKeyFileIsExternal = []byte("is_external")
+
+var ffjKeyFileExternalType = []byte("external_type")
+
+var ffjKeyFileIsPublic = []byte("is_public")
+
+var ffjKeyFileSize = []byte("size")
+
+var ffjKeyFileURLPrivate = []byte("url_private")
+
+var ffjKeyFileURLPrivateDownload = []byte("url_private_download")
+
+var ffjKeyFileThumb64 = []byte("thumb_64")
+
+var ffjKeyFileThumb360 = []byte("thumb_360")
+
+var ffjKeyFileThumb480 = []byte("thumb_480")
+
+var ffjKeyFilePermalink = []byte("permalink")
+
+var ffjKeyFilePermalinkPublic = []byte("permalink_public")
+
+var ffjKeyFilePreview = []byte("preview")
+
+var ffjKeyFileChannels = []byte("channels")
+
+var ffjKeyFileGroups = []byte("groups")
+
+var ffjKeyFileIms = []byte("ims")
+
+// UnmarshalJSON umarshall json - template of ffjson
+func (j *File) UnmarshalJSON(input []byte) error {
+	fs := fflib.NewFFLexer(input)
+	return j.UnmarshalJSONFFLexer(fs, fflib.FFParse_map_start)
+}
+
+// UnmarshalJSONFFLexer fast json unmarshall - template ffjson
+func (j *File) UnmarshalJSONFFLexer(fs *fflib.FFLexer, state fflib.FFParseState) error {
+	var err error
+	currentKey := ffjtFilebase
+	_ = currentKey
+	tok := fflib.FFTok_init
+	wantedTok := fflib.FFTok_init
+
+mainparse:
+	for {
+		tok = fs.Scan()
+		//	println(fmt.Sprintf("debug: tok: %v  state: %v", tok, state))
+		if tok == fflib.FFTok_error {
+			goto tokerror
+		}
+
+		switch state {
+
+		case fflib.FFParse_map_start:
+			if tok != fflib.FFTok_left_bracket {
+				wantedTok = fflib.FFTok_left_bracket
+				goto wrongtokenerror
+			}
+			state = fflib.FFParse_want_key
+			continue
+
+		case fflib.FFParse_after_value:
+			if tok == fflib.FFTok_comma {
+				state = fflib.FFParse_want_key
+			} else if tok == fflib.FFTok_right_bracket {
+				goto done
+			} else {
+				wantedTok = fflib.FFTok_comma
+				goto wrongtokenerror
+			}
+
+		case fflib.FFParse_want_key:
+			// json {} ended. goto exit. woo.
+			if tok == fflib.FFTok_right_bracket {
+				goto done
+			}
+			if tok != fflib.FFTok_string {
+				wantedTok = fflib.FFTok_string
+				goto wrongtokenerror
+			}
+
+			kn := fs.Output.Bytes()
+			if len(kn) <= 0 {
+				// "" case. hrm.
+				currentKey = ffjtFilenosuchkey
+				state = fflib.FFParse_want_colon
+				goto mainparse
+			} else {
+				switch kn[0] {
+
+				case 'c':
+
+					if bytes.Equal(ffjKeyFileCreated, kn) {
+						currentKey = ffjtFileCreated
+						state = fflib.FFParse_want_colon
+						goto mainparse
+
+					} else if bytes.Equal(ffjKeyFileChannels, kn) {
+						currentKey = ffjtFileChannels
+						state = fflib.FFParse_want_colon
+						goto mainparse
+					}
+
+				case 'e':
+
+					if bytes.Equal(ffjKeyFileEditable, kn) {
+						currentKey = ffjtFileEditable
+						state = fflib.FFParse_want_colon
+						goto mainparse
+
+					} else if bytes.Equal(ffjKeyFileExternalType, kn) {
+						currentKey = ffjtFileExternalType
+						state = fflib.FFParse_want_colon
+						goto mainparse
+					}
+
+				case 'f':
+
+					if bytes.Equal(ffjKeyFileFiletype, kn) {
+						currentKey = ffjtFileFiletype
+						state = fflib.FFParse_want_colon
+						goto mainparse
+					}
+
+				case 'g':
+
+					if bytes.Equal(ffjKeyFileGroups, kn) {
+						currentKey = ffjtFileGroups
+						state = fflib.FFParse_want_colon
+						goto mainparse
+					}
+
+				case 'i':
+
+					if bytes.Equal(ffjKeyFileID, kn) {
+						currentKey = ffjtFileID
+						state = fflib.FFParse_want_colon
+						goto mainparse
+
+					} else if bytes.Equal(ffjKeyFileIsExternal, kn) {
+						currentKey = ffjtFileIsExternal
+						state = fflib.FFParse_want_colon
+						goto mainparse
+
+					} else if bytes.Equal(ffjKeyFileIsPublic, kn) {
+						currentKey = ffjtFileIsPublic
+						state = fflib.FFParse_want_colon
+						goto mainparse
+
+					} else if bytes.Equal(ffjKeyFileIms, kn) {
+						currentKey = ffjtFileIms
+						state = fflib.FFParse_want_colon
+						goto mainparse
+					}
+
+				case 'm':
+
+					if bytes.Equal(ffjKeyFileMimetype, kn) {
+						currentKey = ffjtFileMimetype
+						state = fflib.FFParse_want_colon
+						goto mainparse
+
+					} else if bytes.Equal(ffjKeyFileMode, kn) {
+						currentKey = ffjtFileMode
+						state = fflib.FFParse_want_colon
+						goto mainparse
+					}
+
+				case 'n':
+
+					if bytes.Equal(ffjKeyFileName, kn) {
+						currentKey = ffjtFileName
+						state = fflib.FFParse_want_colon
+						goto mainparse
+					}
+
+				case 'p':
+
+					if bytes.Equal(ffjKeyFilePrettyType, kn) {
+						currentKey = ffjtFilePrettyType
+						state = fflib.FFParse_want_colon
+						goto mainparse
+
+					} else if bytes.Equal(ffjKeyFilePermalink, kn) {
+						currentKey = ffjtFilePermalink
+						state = fflib.FFParse_want_colon
+						goto mainparse
+
+					} else if bytes.Equal(ffjKeyFilePermalinkPublic, kn) {
+						currentKey = ffjtFilePermalinkPublic
+						state = fflib.FFParse_want_colon
+						goto mainparse
+
+					} else if bytes.Equal(ffjKeyFilePreview, kn) {
+						currentKey = ffjtFilePreview
+						state = fflib.FFParse_want_colon
+						goto mainparse
+					}
+
+				case 's':
+
+					if bytes.Equal(ffjKeyFileSize, kn) {
+						currentKey = ffjtFileSize
+						state = fflib.FFParse_want_colon
+						goto mainparse
+					}
+
+				case 't':
+
+					if bytes.Equal(ffjKeyFileTimestamp, kn) {
+						currentKey = ffjtFileTimestamp
+						state = fflib.FFParse_want_colon
+						goto mainparse
+
+					} else if bytes.Equal(ffjKeyFileTitle, kn) {
+						currentKey = ffjtFileTitle
+						state = fflib.FFParse_want_colon
+						goto mainparse
+
+					} else if bytes.Equal(ffjKeyFileThumb64, kn) {
+						currentKey = ffjtFileThumb64
+						state = fflib.FFParse_want_colon
+						goto mainparse
+
+					} else if bytes.Equal(ffjKeyFileThumb360, kn) {
+						currentKey = ffjtFileThumb360
+						state = fflib.FFParse_want_colon
+						goto mainparse
+
+					} else if bytes.Equal(ffjKeyFileThumb480, kn) {
+						currentKey = ffjtFileThumb480
+						state = fflib.FFParse_want_colon
+						goto mainparse
+					}
+
+				case 'u':
+
+					if bytes.Equal(ffjKeyFileUser, kn) {
+						currentKey = ffjtFileUser
+						state = fflib.FFParse_want_colon
+						goto mainparse
+
+					} else if bytes.Equal(ffjKeyFileURLPrivate, kn) {
+						currentKey = ffjtFileURLPrivate
+						state = fflib.FFParse_want_colon
+						goto mainparse
+
+					} else if bytes.Equal(ffjKeyFileURLPrivateDownload, kn) {
+						currentKey = ffjtFileURLPrivateDownload
+						state = fflib.FFParse_want_colon
+						goto mainparse
+					}
+
+				}
+
+				if fflib.EqualFoldRight(ffjKeyFileIms, kn) {
+					currentKey = ffjtFileIms
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
+				if fflib.EqualFoldRight(ffjKeyFileGroups, kn) {
+					currentKey = ffjtFileGroups
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
+				if fflib.EqualFoldRight(ffjKeyFileChannels, kn) {
+					currentKey = ffjtFileChannels
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
+				if fflib.SimpleLetterEqualFold(ffjKeyFilePreview, kn) {
+					currentKey = ffjtFilePreview
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
+				if fflib.EqualFoldRight(ffjKeyFilePermalinkPublic, kn) {
+					currentKey = ffjtFilePermalinkPublic
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
+				if fflib.EqualFoldRight(ffjKeyFilePermalink, kn) {
+					currentKey = ffjtFilePermalink
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
+				if fflib.AsciiEqualFold(ffjKeyFileThumb480, kn) {
+					currentKey = ffjtFileThumb480
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
+				if fflib.AsciiEqualFold(ffjKeyFileThumb360, kn) {
+					currentKey = ffjtFileThumb360
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
+				if fflib.AsciiEqualFold(ffjKeyFileThumb64, kn) {
+					currentKey = ffjtFileThumb64
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
+				if fflib.AsciiEqualFold(ffjKeyFileURLPrivateDownload, kn) {
+					currentKey = ffjtFileURLPrivateDownload
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
+				if fflib.AsciiEqualFold(ffjKeyFileURLPrivate, kn) {
+					currentKey = ffjtFileURLPrivate
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
+				if fflib.EqualFoldRight(ffjKeyFileSize, kn) {
+					currentKey = ffjtFileSize
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
+				if fflib.EqualFoldRight(ffjKeyFileIsPublic, kn) {
+					currentKey = ffjtFileIsPublic
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
+				if fflib.AsciiEqualFold(ffjKeyFileExternalType, kn) {
+					currentKey = ffjtFileExternalType
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
+				if fflib.EqualFoldRight(ffjKeyFileIsExternal, kn) {
+					currentKey = ffjtFileIsExternal
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
+				if fflib.SimpleLetterEqualFold(ffjKeyFileEditable, kn) {
+					currentKey = ffjtFileEditable
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
+				if fflib.SimpleLetterEqualFold(ffjKeyFileMode, kn) {
+					currentKey = ffjtFileMode
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
+				if fflib.EqualFoldRight(ffjKeyFileUser, kn) {
+					currentKey = ffjtFileUser
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
+				if fflib.AsciiEqualFold(ffjKeyFilePrettyType, kn) {
+					currentKey = ffjtFilePrettyType
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
+				if fflib.SimpleLetterEqualFold(ffjKeyFileFiletype, kn) {
+					currentKey = ffjtFileFiletype
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
+				if fflib.SimpleLetterEqualFold(ffjKeyFileMimetype, kn) {
+					currentKey = ffjtFileMimetype
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
+				if fflib.SimpleLetterEqualFold(ffjKeyFileTitle, kn) {
+					currentKey = ffjtFileTitle
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
+				if fflib.SimpleLetterEqualFold(ffjKeyFileName, kn) {
+					currentKey = ffjtFileName
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
+				if fflib.EqualFoldRight(ffjKeyFileTimestamp, kn) {
+					currentKey = ffjtFileTimestamp
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
+				if fflib.SimpleLetterEqualFold(ffjKeyFileCreated, kn) {
+					currentKey = ffjtFileCreated
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
+				if fflib.SimpleLetterEqualFold(ffjKeyFileID, kn) {
+					currentKey = ffjtFileID
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
+				currentKey = ffjtFilenosuchkey
+				state = fflib.FFParse_want_colon
+				goto mainparse
+			}
+
+		case fflib.FFParse_want_colon:
+			if tok != fflib.FFTok_colon {
+				wantedTok = fflib.FFTok_colon
+				goto wrongtokenerror
+			}
+			state = fflib.FFParse_want_value
+			continue
+		case fflib.FFParse_want_value:
+
+			if tok == fflib.FFTok_left_brace || tok == fflib.FFTok_left_bracket || tok == fflib.FFTok_integer || tok == fflib.FFTok_double || tok == fflib.FFTok_string || tok == fflib.FFTok_bool || tok == fflib.FFTok_null {
+				switch currentKey {
+
+				case ffjtFileID:
+					goto handle_ID
+
+				case ffjtFileCreated:
+					goto handle_Created
+
+				case ffjtFileTimestamp:
+					goto handle_Timestamp
+
+				case ffjtFileName:
+					goto handle_Name
+
+				case ffjtFileTitle:
+					goto handle_Title
+
+				case ffjtFileMimetype:
+					goto handle_Mimetype
+
+				case ffjtFileFiletype:
+					goto handle_Filetype
+
+				case ffjtFilePrettyType:
+					goto handle_PrettyType
+
+				case ffjtFileUser:
+					goto handle_User
+
+				case ffjtFileMode:
+					goto handle_Mode
+
+				case ffjtFileEditable:
+					goto handle_Editable
+
+				case ffjtFileIsExternal:
+					goto handle_IsExternal
+
+				case ffjtFileExternalType:
+					goto handle_ExternalType
+
+				case ffjtFileIsPublic:
+					goto handle_IsPublic
+
+				case ffjtFileSize:
+					goto handle_Size
+
+				case ffjtFileURLPrivate:
+					goto handle_URLPrivate
+
+				case ffjtFileURLPrivateDownload:
+					goto handle_URLPrivateDownload
+
+				case ffjtFileThumb64:
+					goto handle_Thumb64
+
+				case ffjtFileThumb360:
+					goto handle_Thumb360
+
+				case ffjtFileThumb480:
+					goto handle_Thumb480
+
+				case ffjtFilePermalink:
+					goto handle_Permalink
+
+				case ffjtFilePermalinkPublic:
+					goto handle_PermalinkPublic
+
+				case ffjtFilePreview:
+					goto handle_Preview
+
+				case ffjtFileChannels:
+					goto handle_Channels
+
+				case ffjtFileGroups:
+					goto handle_Groups
+
+				case ffjtFileIms:
+					goto handle_Ims
+
+				case ffjtFilenosuchkey:
+					err = fs.SkipField(tok)
+					if err != nil {
+						return fs.WrapErr(err)
+					}
+					state = fflib.FFParse_after_value
+					goto mainparse
+				}
+			} else {
+				goto wantedvalue
+			}
+		}
+	}
+
+handle_ID:
+
+	/* handler: j.ID type=string kind=string quoted=false*/
+
+	{
+
+		{
+			if tok != fflib.FFTok_string && tok != fflib.FFTok_null {
+				return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for string", tok))
+			}
+		}
+
+		if tok == fflib.FFTok_null {
+
+		} else {
+
+			outBuf := fs.Output.Bytes()
+
+			j.ID = string(string(outBuf))
+
+		}
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+handle_Created:
+
+	/* handler: j.Created type=int kind=int quoted=false*/
+
+	{
+		if tok != fflib.FFTok_integer && tok != fflib.FFTok_null {
+			return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for int", tok))
+		}
+	}
+
+	{
+
+		if tok == fflib.FFTok_null {
+
+		} else {
+
+			tval, err := fflib.ParseInt(fs.Output.Bytes(), 10, 64)
+
+			if err != nil {
+				return fs.WrapErr(err)
+			}
+
+			j.Created = int(tval)
+
+		}
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+handle_Timestamp:
+
+	/* handler: j.Timestamp type=int kind=int quoted=false*/
+
+	{
+		if tok != fflib.FFTok_integer && tok != fflib.FFTok_null {
+			return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for int", tok))
+		}
+	}
+
+	{
+
+		if tok == fflib.FFTok_null {
+
+		} else {
+
+			tval, err := fflib.ParseInt(fs.Output.Bytes(), 10, 64)
+
+			if err != nil {
+				return fs.WrapErr(err)
+			}
+
+			j.Timestamp = int(tval)
+
+		}
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+handle_Name:
+
+	/* handler: j.Name type=string kind=string quoted=false*/
+
+	{
+
+		{
+			if tok != fflib.FFTok_string && tok != fflib.FFTok_null {
+				return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for string", tok))
+			}
+		}
+
+		if tok == fflib.FFTok_null {
+
+		} else {
+
+			outBuf := fs.Output.Bytes()
+
+			j.Name = string(string(outBuf))
+
+		}
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+handle_Title:
+
+	/* handler: j.Title type=string kind=string quoted=false*/
+
+	{
+
+		{
+			if tok != fflib.FFTok_string && tok != fflib.FFTok_null {
+				return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for string", tok))
+			}
+		}
+
+		if tok == fflib.FFTok_null {
+
+		} else {
+
+			outBuf := fs.Output.Bytes()
+
+			j.Title = string(string(outBuf))
+
+		}
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+handle_Mimetype:
+
+	/* handler: j.Mimetype type=string kind=string quoted=false*/
+
+	{
+
+		{
+			if tok != fflib.FFTok_string && tok != fflib.FFTok_null {
+				return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for string", tok))
+			}
+		}
+
+		if tok == fflib.FFTok_null {
+
+		} else {
+
+			outBuf := fs.Output.Bytes()
+
+			j.Mimetype = string(string(outBuf))
+
+		}
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+handle_Filetype:
+
+	/* handler: j.Filetype type=string kind=string quoted=false*/
+
+	{
+
+		{
+			if tok != fflib.FFTok_string && tok != fflib.FFTok_null {
+				return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for string", tok))
+			}
+		}
+
+		if tok == fflib.FFTok_null {
+
+		} else {
+
+			outBuf := fs.Output.Bytes()
+
+			j.Filetype = string(string(outBuf))
+
+		}
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+handle_PrettyType:
+
+	/* handler: j.PrettyType type=string kind=string quoted=false*/
+
+	{
+
+		{
+			if tok != fflib.FFTok_string && tok != fflib.FFTok_null {
+				return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for string", tok))
+			}
+		}
+
+		if tok == fflib.FFTok_null {
+
+		} else {
+
+			outBuf := fs.Output.Bytes()
+
+			j.PrettyType = string(string(outBuf))
+
+		}
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+handle_User:
+
+	/* handler: j.User type=string kind=string quoted=false*/
+
+	{
+
+		{
+			if tok != fflib.FFTok_string && tok != fflib.FFTok_null {
+				return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for string", tok))
+			}
+		}
+
+		if tok == fflib.FFTok_null {
+
+		} else {
+
+			outBuf := fs.Output.Bytes()
+
+			j.User = string(string(outBuf))
+
+		}
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+handle_Mode:
+
+	/* handler: j.Mode type=string kind=string quoted=false*/
+
+	{
+
+		{
+			if tok != fflib.FFTok_string && tok != fflib.FFTok_null {
+				return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for string", tok))
+			}
+		}
+
+		if tok == fflib.FFTok_null {
+
+		} else {
+
+			outBuf := fs.Output.Bytes()
+
+			j.Mode = string(string(outBuf))
+
+		}
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+handle_Editable:
+
+	/* handler: j.Editable type=bool kind=bool quoted=false*/
+
+	{
+		if tok != fflib.FFTok_bool && tok != fflib.FFTok_null {
+			return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for bool", tok))
+		}
+	}
+
+	{
+		if tok == fflib.FFTok_null {
+
+		} else {
+			tmpb := fs.Output.Bytes()
+
+			if bytes.Compare([]byte{'t', 'r', 'u', 'e'}, tmpb) == 0 {
+
+				j.Editable = true
+
+			} else if bytes.Compare([]byte{'f', 'a', 'l', 's', 'e'}, tmpb) == 0 {
+
+				j.Editable = false
+
+			} else {
+				err = errors.New("unexpected bytes for true/false value")
+				return fs.WrapErr(err)
+			}
+
+		}
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+handle_IsExternal:
+
+	/* handler: j.IsExternal type=bool kind=bool quoted=false*/
+
+	{
+		if tok != fflib.FFTok_bool && tok != fflib.FFTok_null {
+			return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for bool", tok))
+		}
+	}
+
+	{
+		if tok == fflib.FFTok_null {
+
+		} else {
+			tmpb := fs.Output.Bytes()
+
+			if bytes.Compare([]byte{'t', 'r', 'u', 'e'}, tmpb) == 0 {
+
+				j.IsExternal = true
+
+			} else if bytes.Compare([]byte{'f', 'a', 'l', 's', 'e'}, tmpb) == 0 {
+
+				j.IsExternal = false
+
+			} else {
+				err = errors.New("unexpected bytes for true/false value")
+				return fs.WrapErr(err)
+			}
+
+		}
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+handle_ExternalType:
+
+	/* handler: j.ExternalType type=string kind=string quoted=false*/
+
+	{
+
+		{
+			if tok != fflib.FFTok_string && tok != fflib.FFTok_null {
+				return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for string", tok))
+			}
+		}
+
+		if tok == fflib.FFTok_null {
+
+		} else {
+
+			outBuf := fs.Output.Bytes()
+
+			j.ExternalType = string(string(outBuf))
+
+		}
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+handle_IsPublic:
+
+	/* handler: j.IsPublic type=bool kind=bool quoted=false*/
+
+	{
+		if tok != fflib.FFTok_bool && tok != fflib.FFTok_null {
+			return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for bool", tok))
+		}
+	}
+
+	{
+		if tok == fflib.FFTok_null {
+
+		} else {
+			tmpb := fs.Output.Bytes()
+
+			if bytes.Compare([]byte{'t', 'r', 'u', 'e'}, tmpb) == 0 {
+
+				j.IsPublic = true
+
+			} else if bytes.Compare([]byte{'f', 'a', 'l', 's', 'e'}, tmpb) == 0 {
+
+				j.IsPublic = false
+
+			} else {
+				err = errors.New("unexpected bytes for true/false value")
+				return fs.WrapErr(err)
+			}
+
+		}
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+handle_Size:
+
+	/* handler: j.Size type=int kind=int quoted=false*/
+
+	{
+		if tok != fflib.FFTok_integer && tok != fflib.FFTok_null {
+			return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for int", tok))
+		}
+	}
+
+	{
+
+		if tok == fflib.FFTok_null {
+
+		} else {
+
+			tval, err := fflib.ParseInt(fs.Output.Bytes(), 10, 64)
+
+			if err != nil {
+				return fs.WrapErr(err)
+			}
+
+			j.Size = int(tval)
+
+		}
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+handle_URLPrivate:
+
+	/* handler: j.URLPrivate type=string kind=string quoted=false*/
+
+	{
+
+		{
+			if tok != fflib.FFTok_string && tok != fflib.FFTok_null {
+				return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for string", tok))
+			}
+		}
+
+		if tok == fflib.FFTok_null {
+
+		} else {
+
+			outBuf := fs.Output.Bytes()
+
+			j.URLPrivate = string(string(outBuf))
+
+		}
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+handle_URLPrivateDownload:
+
+	/* handler: j.URLPrivateDownload type=string kind=string quoted=false*/
+
+	{
+
+		{
+			if tok != fflib.FFTok_string && tok != fflib.FFTok_null {
+				return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for string", tok))
+			}
+		}
+
+		if tok == fflib.FFTok_null {
+
+		} else {
+
+			outBuf := fs.Output.Bytes()
+
+			j.URLPrivateDownload = string(string(outBuf))
+
+		}
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+handle_Thumb64:
+
+	/* handler: j.Thumb64 type=string kind=string quoted=false*/
+
+	{
+
+		{
+			if tok != fflib.FFTok_string && tok != fflib.FFTok_null {
+				return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for string", tok))
+			}
+		}
+
+		if tok == fflib.FFTok_null {
+
+		} else {
+
+			outBuf := fs.Output.Bytes()
+
+			j.Thumb64 = string(string(outBuf))
+
+		}
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+handle_Thumb360:
+
+	/* handler: j.Thumb360 type=string kind=string quoted=false*/
+
+	{
+
+		{
+			if tok != fflib.FFTok_string && tok != fflib.FFTok_null {
+				return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for string", tok))
+			}
+		}
+
+		if tok == fflib.FFTok_null {
+
+		} else {
+
+			outBuf := fs.Output.Bytes()
+
+			j.Thumb360 = string(string(outBuf))
+
+		}
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+handle_Thumb480:
+
+	/* handler: j.Thumb480 type=string kind=string quoted=false*/
+
+	{
+
+		{
+			if tok != fflib.FFTok_string && tok != fflib.FFTok_null {
+				return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for string", tok))
+			}
+		}
+
+		if tok == fflib.FFTok_null {
+
+		} else {
+
+			outBuf := fs.Output.Bytes()
+
+			j.Thumb480 = string(string(outBuf))
+
+		}
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+handle_Permalink:
+
+	/* handler: j.Permalink type=string kind=string quoted=false*/
+
+	{
+
+		{
+			if tok != fflib.FFTok_string && tok != fflib.FFTok_null {
+				return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for string", tok))
+			}
+		}
+
+		if tok == fflib.FFTok_null {
+
+		} else {
+
+			outBuf := fs.Output.Bytes()
+
+			j.Permalink = string(string(outBuf))
+
+		}
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+handle_PermalinkPublic:
+
+	/* handler: j.PermalinkPublic type=string kind=string quoted=false*/
+
+	{
+
+		{
+			if tok != fflib.FFTok_string && tok != fflib.FFTok_null {
+				return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for string", tok))
+			}
+		}
+
+		if tok == fflib.FFTok_null {
+
+		} else {
+
+			outBuf := fs.Output.Bytes()
+
+			j.PermalinkPublic = string(string(outBuf))
+
+		}
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+handle_Preview:
+
+	/* handler: j.Preview type=string kind=string quoted=false*/
+
+	{
+
+		{
+			if tok != fflib.FFTok_string && tok != fflib.FFTok_null {
+				return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for string", tok))
+			}
+		}
+
+		if tok == fflib.FFTok_null {
+
+		} else {
+
+			outBuf := fs.Output.Bytes()
+
+			j.Preview = string(string(outBuf))
+
+		}
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+handle_Channels:
+
+	/* handler: j.Channels type=[]string kind=slice quoted=false*/
+
+	{
+
+		{
+			if tok != fflib.FFTok_left_brace && tok != fflib.FFTok_null {
+				return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for ", tok))
+			}
+		}
+
+		if tok == fflib.FFTok_null {
+			j.Channels = nil
+		} else {
+
+			j.Channels = []string{}
+
+			wantVal := true
+
+			for {
+
+				var tmpJChannels string
+
+				tok = fs.Scan()
+				if tok == fflib.FFTok_error {
+					goto tokerror
+				}
+				if tok == fflib.FFTok_right_brace {
+					break
+				}
+
+				if tok == fflib.FFTok_comma {
+					if wantVal == true {
+						// TODO(pquerna): this isn't an ideal error message, this handles
+						// things like [,,,] as an array value.
+						return fs.WrapErr(fmt.Errorf("wanted value token, but got token: %v", tok))
+					}
+					continue
+				} else {
+					wantVal = true
+				}
+
+				/* handler: tmpJChannels type=string kind=string quoted=false*/
+
+				{
+
+					{
+						if tok != fflib.FFTok_string && tok != fflib.FFTok_null {
+							return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for string", tok))
+						}
+					}
+
+					if tok == fflib.FFTok_null {
+
+					} else {
+
+						outBuf := fs.Output.Bytes()
+
+						tmpJChannels = string(string(outBuf))
+
+					}
+				}
+
+				j.Channels = append(j.Channels, tmpJChannels)
+
+				wantVal = false
+			}
+		}
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+handle_Groups:
+
+	/* handler: j.Groups type=[]string kind=slice quoted=false*/
+
+	{
+
+		{
+			if tok != fflib.FFTok_left_brace && tok != fflib.FFTok_null {
+				return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for ", tok))
+			}
+		}
+
+		if tok == fflib.FFTok_null {
+			j.Groups = nil
+		} else {
+
+			j.Groups = []string{}
+
+			wantVal := true
+
+			for {
+
+				var tmpJGroups string
+
+				tok = fs.Scan()
+				if tok == fflib.FFTok_error {
+					goto tokerror
+				}
+				if tok == fflib.FFTok_right_brace {
+					break
+				}
+
+				if tok == fflib.FFTok_comma {
+					if wantVal == true {
+						// TODO(pquerna): this isn't an ideal error message, this handles
+						// things like [,,,] as an array value.
+						return fs.WrapErr(fmt.Errorf("wanted value token, but got token: %v", tok))
+					}
+					continue
+				} else {
+					wantVal = true
+				}
+
+				/* handler: tmpJGroups type=string kind=string quoted=false*/
+
+				{
+
+					{
+						if tok != fflib.FFTok_string && tok != fflib.FFTok_null {
+							return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for string", tok))
+						}
+					}
+
+					if tok == fflib.FFTok_null {
+
+					} else {
+
+						outBuf := fs.Output.Bytes()
+
+						tmpJGroups = string(string(outBuf))
+
+					}
+				}
+
+				j.Groups = append(j.Groups, tmpJGroups)
+
+				wantVal = false
+			}
+		}
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+handle_Ims:
+
+	/* handler: j.Ims type=[]string kind=slice quoted=false*/
+
+	{
+
+		{
+			if tok != fflib.FFTok_left_brace && tok != fflib.FFTok_null {
+				return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for ", tok))
+			}
+		}
+
+		if tok == fflib.FFTok_null {
+			j.Ims = nil
+		} else {
+
+			j.Ims = []string{}
+
+			wantVal := true
+
+			for {
+
+				var tmpJIms string
+
+				tok = fs.Scan()
+				if tok == fflib.FFTok_error {
+					goto tokerror
+				}
+				if tok == fflib.FFTok_right_brace {
+					break
+				}
+
+				if tok == fflib.FFTok_comma {
+					if wantVal == true {
+						// TODO(pquerna): this isn't an ideal error message, this handles
+						// things like [,,,] as an array value.
+						return fs.WrapErr(fmt.Errorf("wanted value token, but got token: %v", tok))
+					}
+					continue
+				} else {
+					wantVal = true
+				}
+
+				/* handler: tmpJIms type=string kind=string quoted=false*/
+
+				{
+
+					{
+						if tok != fflib.FFTok_string && tok != fflib.FFTok_null {
+							return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for string", tok))
+						}
+					}
+
+					if tok == fflib.FFTok_null {
+
+					} else {
+
+						outBuf := fs.Output.Bytes()
+
+						tmpJIms = string(string(outBuf))
+
+					}
+				}
+
+				j.Ims = append(j.Ims, tmpJIms)
+
+				wantVal = false
+			}
+		}
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+wantedvalue:
+	return fs.WrapErr(fmt.Errorf("wanted value token, but got token: %v", tok))
+wrongtokenerror:
+	return fs.WrapErr(fmt.Errorf("ffjson: wanted token: %v, but got token: %v output=%s", wantedTok, tok, fs.Output.String()))
+tokerror:
+	if fs.BigError != nil {
+		return fs.WrapErr(fs.BigError)
+	}
+	err = fs.Error.ToError()
+	if err != nil {
+		return fs.WrapErr(err)
+	}
+	panic("ffjson-generated: unreachable, please report bug.")
+done:
+
+	return nil
+}
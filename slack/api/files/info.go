@@ -0,0 +1,29 @@
+package files
+
+import (
+	"github.com/pkg/errors"
+
+	"suy.io/bots/slack/api"
+)
+
+// ffjson: nodecoder
+type InfoRequest struct {
+	Token string `json:"token" url:"token"`
+	File  string `json:"file" url:"file"`
+}
+
+// ffjson: noencoder
+type InfoResponse struct {
+	File *File `json:"file"`
+}
+
+func Info(req *InfoRequest) (*InfoResponse, error) {
+	res := &InfoResponse{}
+	if err := api.Request("files.info", req, false, res, ""); err != nil {
+		return nil, errors.Wrap(err, "files.info failed")
+	}
+
+	return res, nil
+}
+
+//go:generate ffjson $GOFILE
@@ -0,0 +1,56 @@
+package files
+
+import (
+	"io"
+
+	"github.com/pkg/errors"
+
+	"suy.io/bots/slack/api"
+)
+
+// UploadRequest contains the parameters for files.upload.
+// If File is set, it is streamed to slack as a multipart upload,
+// otherwise Content is sent as a text snippet.
+//
+// ffjson: skip
+type UploadRequest struct {
+	Token          string    `json:"token" url:"token"`
+	Channels       string    `json:"channels,omitempty" url:"channels,omitempty"`
+	Content        string    `json:"content,omitempty" url:"content,omitempty"`
+	Filename       string    `json:"filename,omitempty" url:"filename,omitempty"`
+	Filetype       string    `json:"filetype,omitempty" url:"filetype,omitempty"`
+	InitialComment string    `json:"initial_comment,omitempty" url:"initial_comment,omitempty"`
+	ThreadTs       string    `json:"thread_ts,omitempty" url:"thread_ts,omitempty"`
+	Title          string    `json:"title,omitempty" url:"title,omitempty"`
+	File           io.Reader `json:"-" url:"-"`
+}
+
+// ffjson: noencoder
+type UploadResponse struct {
+	File *File `json:"file"`
+}
+
+func Upload(req *UploadRequest) (*UploadResponse, error) {
+	res := &UploadResponse{}
+
+	if req.File != nil {
+		name := req.Filename
+		if name == "" {
+			name = "file"
+		}
+
+		if err := api.Upload("files.upload", req, "file", name, req.File, res, req.Token); err != nil {
+			return nil, errors.Wrap(err, "files.upload failed")
+		}
+
+		return res, nil
+	}
+
+	if err := api.Request("files.upload", req, false, res, ""); err != nil {
+		return nil, errors.Wrap(err, "files.upload failed")
+	}
+
+	return res, nil
+}
+
+//go:generate ffjson $GOFILE
@@ -15,17 +15,29 @@ type AccessRequest struct {
 }
 
 type AccessResponse struct {
-	AccessToken string `json:"access_token"`
-	Scope       string `json:"scope"`
-	UserID      string `json:"user_id"`
-	TeamName    string `json:"team_name"`
-	TeamID      string `json:"team_id"`
-	Bot         *Bot   `json:"bot"`
+	AccessToken         string `json:"access_token"`
+	Scope               string `json:"scope"`
+	UserID              string `json:"user_id"`
+	TeamName            string `json:"team_name"`
+	TeamID              string `json:"team_id"`
+	EnterpriseID        string `json:"enterprise_id,omitempty"`
+	EnterpriseName      string `json:"enterprise_name,omitempty"`
+	IsEnterpriseInstall bool   `json:"is_enterprise_install,omitempty"`
+	AppID               string `json:"app_id,omitempty"`
+	RefreshToken        string `json:"refresh_token,omitempty"`
+	ExpiresAt           int64  `json:"expires_at,omitempty"`
+	Bot                 *Bot   `json:"bot"`
 }
 
 type Bot struct {
 	BotUserID      string `json:"bot_user_id"`
 	BotAccessToken string `json:"bot_access_token"`
+	Scope          string `json:"scope,omitempty"`
+	RefreshToken   string `json:"refresh_token,omitempty"`
+	ExpiresAt      int64  `json:"expires_at,omitempty"`
+
+	// BotID is not part of the response, it is set from auth.test when the bot is added
+	BotID string `json:"bot_id,omitempty"`
 }
 
 func Access(req *AccessRequest) (*AccessResponse, error) {
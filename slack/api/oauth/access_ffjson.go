@@ -5,6 +5,7 @@ package oauth
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	fflib "github.com/pquerna/ffjson/fflib/v1"
 )
@@ -84,8 +85,42 @@ func (j *AccessResponse) MarshalJSONBuf(buf fflib.EncodingBuffer) error {
 	fflib.WriteJsonString(buf, string(j.TeamName))
 	buf.WriteString(`,"team_id":`)
 	fflib.WriteJsonString(buf, string(j.TeamID))
+	buf.WriteByte(',')
+	if len(j.EnterpriseID) != 0 {
+		buf.WriteString(`"enterprise_id":`)
+		fflib.WriteJsonString(buf, string(j.EnterpriseID))
+		buf.WriteByte(',')
+	}
+	if len(j.EnterpriseName) != 0 {
+		buf.WriteString(`"enterprise_name":`)
+		fflib.WriteJsonString(buf, string(j.EnterpriseName))
+		buf.WriteByte(',')
+	}
+	if j.IsEnterpriseInstall != false {
+		if j.IsEnterpriseInstall {
+			buf.WriteString(`"is_enterprise_install":true`)
+		} else {
+			buf.WriteString(`"is_enterprise_install":false`)
+		}
+		buf.WriteByte(',')
+	}
+	if len(j.AppID) != 0 {
+		buf.WriteString(`"app_id":`)
+		fflib.WriteJsonString(buf, string(j.AppID))
+		buf.WriteByte(',')
+	}
+	if len(j.RefreshToken) != 0 {
+		buf.WriteString(`"refresh_token":`)
+		fflib.WriteJsonString(buf, string(j.RefreshToken))
+		buf.WriteByte(',')
+	}
+	if j.ExpiresAt != 0 {
+		buf.WriteString(`"expires_at":`)
+		fflib.FormatBits2(buf, uint64(j.ExpiresAt), 10, j.ExpiresAt < 0)
+		buf.WriteByte(',')
+	}
 	if j.Bot != nil {
-		buf.WriteString(`,"bot":`)
+		buf.WriteString(`"bot":`)
 
 		{
 
@@ -96,7 +131,7 @@ func (j *AccessResponse) MarshalJSONBuf(buf fflib.EncodingBuffer) error {
 
 		}
 	} else {
-		buf.WriteString(`,"bot":null`)
+		buf.WriteString(`"bot":null`)
 	}
 	buf.WriteByte('}')
 	return nil
@@ -116,6 +151,18 @@ const (
 
 	ffjtAccessResponseTeamID
 
+	ffjtAccessResponseEnterpriseID
+
+	ffjtAccessResponseEnterpriseName
+
+	ffjtAccessResponseIsEnterpriseInstall
+
+	ffjtAccessResponseAppID
+
+	ffjtAccessResponseRefreshToken
+
+	ffjtAccessResponseExpiresAt
+
 	ffjtAccessResponseBot
 )
 
@@ -129,6 +176,18 @@ var ffjKeyAccessResponseTeamName = []byte("team_name")
 
 var ffjKeyAccessResponseTeamID = []byte("team_id")
 
+var ffjKeyAccessResponseEnterpriseID = []byte("enterprise_id")
+
+var ffjKeyAccessResponseEnterpriseName = []byte("enterprise_name")
+
+var ffjKeyAccessResponseIsEnterpriseInstall = []byte("is_enterprise_install")
+
+var ffjKeyAccessResponseAppID = []byte("app_id")
+
+var ffjKeyAccessResponseRefreshToken = []byte("refresh_token")
+
+var ffjKeyAccessResponseExpiresAt = []byte("expires_at")
+
 var ffjKeyAccessResponseBot = []byte("bot")
 
 // UnmarshalJSON umarshall json - template of ffjson
@@ -198,6 +257,11 @@ mainparse:
 						currentKey = ffjtAccessResponseAccessToken
 						state = fflib.FFParse_want_colon
 						goto mainparse
+
+					} else if bytes.Equal(ffjKeyAccessResponseAppID, kn) {
+						currentKey = ffjtAccessResponseAppID
+						state = fflib.FFParse_want_colon
+						goto mainparse
 					}
 
 				case 'b':
@@ -208,6 +272,40 @@ mainparse:
 						goto mainparse
 					}
 
+				case 'e':
+
+					if bytes.Equal(ffjKeyAccessResponseEnterpriseID, kn) {
+						currentKey = ffjtAccessResponseEnterpriseID
+						state = fflib.FFParse_want_colon
+						goto mainparse
+
+					} else if bytes.Equal(ffjKeyAccessResponseEnterpriseName, kn) {
+						currentKey = ffjtAccessResponseEnterpriseName
+						state = fflib.FFParse_want_colon
+						goto mainparse
+
+					} else if bytes.Equal(ffjKeyAccessResponseExpiresAt, kn) {
+						currentKey = ffjtAccessResponseExpiresAt
+						state = fflib.FFParse_want_colon
+						goto mainparse
+					}
+
+				case 'i':
+
+					if bytes.Equal(ffjKeyAccessResponseIsEnterpriseInstall, kn) {
+						currentKey = ffjtAccessResponseIsEnterpriseInstall
+						state = fflib.FFParse_want_colon
+						goto mainparse
+					}
+
+				case 'r':
+
+					if bytes.Equal(ffjKeyAccessResponseRefreshToken, kn) {
+						currentKey = ffjtAccessResponseRefreshToken
+						state = fflib.FFParse_want_colon
+						goto mainparse
+					}
+
 				case 's':
 
 					if bytes.Equal(ffjKeyAccessResponseScope, kn) {
@@ -245,6 +343,42 @@ mainparse:
 					goto mainparse
 				}
 
+				if fflib.EqualFoldRight(ffjKeyAccessResponseExpiresAt, kn) {
+					currentKey = ffjtAccessResponseExpiresAt
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
+				if fflib.EqualFoldRight(ffjKeyAccessResponseRefreshToken, kn) {
+					currentKey = ffjtAccessResponseRefreshToken
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
+				if fflib.AsciiEqualFold(ffjKeyAccessResponseAppID, kn) {
+					currentKey = ffjtAccessResponseAppID
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
+				if fflib.EqualFoldRight(ffjKeyAccessResponseIsEnterpriseInstall, kn) {
+					currentKey = ffjtAccessResponseIsEnterpriseInstall
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
+				if fflib.EqualFoldRight(ffjKeyAccessResponseEnterpriseName, kn) {
+					currentKey = ffjtAccessResponseEnterpriseName
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
+				if fflib.EqualFoldRight(ffjKeyAccessResponseEnterpriseID, kn) {
+					currentKey = ffjtAccessResponseEnterpriseID
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
 				if fflib.AsciiEqualFold(ffjKeyAccessResponseTeamID, kn) {
 					currentKey = ffjtAccessResponseTeamID
 					state = fflib.FFParse_want_colon
@@ -307,6 +441,24 @@ mainparse:
 				case ffjtAccessResponseTeamID:
 					goto handle_TeamID
 
+				case ffjtAccessResponseEnterpriseID:
+					goto handle_EnterpriseID
+
+				case ffjtAccessResponseEnterpriseName:
+					goto handle_EnterpriseName
+
+				case ffjtAccessResponseIsEnterpriseInstall:
+					goto handle_IsEnterpriseInstall
+
+				case ffjtAccessResponseAppID:
+					goto handle_AppID
+
+				case ffjtAccessResponseRefreshToken:
+					goto handle_RefreshToken
+
+				case ffjtAccessResponseExpiresAt:
+					goto handle_ExpiresAt
+
 				case ffjtAccessResponseBot:
 					goto handle_Bot
 
@@ -454,6 +606,175 @@ handle_TeamID:
 	state = fflib.FFParse_after_value
 	goto mainparse
 
+handle_EnterpriseID:
+
+	/* handler: j.EnterpriseID type=string kind=string quoted=false*/
+
+	{
+
+		{
+			if tok != fflib.FFTok_string && tok != fflib.FFTok_null {
+				return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for string", tok))
+			}
+		}
+
+		if tok == fflib.FFTok_null {
+
+		} else {
+
+			outBuf := fs.Output.Bytes()
+
+			j.EnterpriseID = string(string(outBuf))
+
+		}
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+handle_EnterpriseName:
+
+	/* handler: j.EnterpriseName type=string kind=string quoted=false*/
+
+	{
+
+		{
+			if tok != fflib.FFTok_string && tok != fflib.FFTok_null {
+				return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for string", tok))
+			}
+		}
+
+		if tok == fflib.FFTok_null {
+
+		} else {
+
+			outBuf := fs.Output.Bytes()
+
+			j.EnterpriseName = string(string(outBuf))
+
+		}
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+handle_IsEnterpriseInstall:
+
+	/* handler: j.IsEnterpriseInstall type=bool kind=bool quoted=false*/
+
+	{
+		if tok != fflib.FFTok_bool && tok != fflib.FFTok_null {
+			return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for bool", tok))
+		}
+	}
+
+	{
+		if tok == fflib.FFTok_null {
+
+		} else {
+			tmpb := fs.Output.Bytes()
+
+			if bytes.Compare([]byte{'t', 'r', 'u', 'e'}, tmpb) == 0 {
+
+				j.IsEnterpriseInstall = true
+
+			} else if bytes.Compare([]byte{'f', 'a', 'l', 's', 'e'}, tmpb) == 0 {
+
+				j.IsEnterpriseInstall = false
+
+			} else {
+				err = errors.New("unexpected bytes for true/false value")
+				return fs.WrapErr(err)
+			}
+
+		}
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+handle_AppID:
+
+	/* handler: j.AppID type=string kind=string quoted=false*/
+
+	{
+
+		{
+			if tok != fflib.FFTok_string && tok != fflib.FFTok_null {
+				return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for string", tok))
+			}
+		}
+
+		if tok == fflib.FFTok_null {
+
+		} else {
+
+			outBuf := fs.Output.Bytes()
+
+			j.AppID = string(string(outBuf))
+
+		}
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+handle_RefreshToken:
+
+	/* handler: j.RefreshToken type=string kind=string quoted=false*/
+
+	{
+
+		{
+			if tok != fflib.FFTok_string && tok != fflib.FFTok_null {
+				return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for string", tok))
+			}
+		}
+
+		if tok == fflib.FFTok_null {
+
+		} else {
+
+			outBuf := fs.Output.Bytes()
+
+			j.RefreshToken = string(string(outBuf))
+
+		}
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+handle_ExpiresAt:
+
+	/* handler: j.ExpiresAt type=int64 kind=int64 quoted=false*/
+
+	{
+		if tok != fflib.FFTok_integer && tok != fflib.FFTok_null {
+			return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for int64", tok))
+		}
+	}
+
+	{
+
+		if tok == fflib.FFTok_null {
+
+		} else {
+
+			tval, err := fflib.ParseInt(fs.Output.Bytes(), 10, 64)
+
+			if err != nil {
+				return fs.WrapErr(err)
+			}
+
+			j.ExpiresAt = int64(tval)
+
+		}
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
 handle_Bot:
 
 	/* handler: j.Bot type=oauth.Bot kind=struct quoted=false*/
@@ -522,10 +843,32 @@ func (j *Bot) MarshalJSONBuf(buf fflib.EncodingBuffer) error {
 	var obj []byte
 	_ = obj
 	_ = err
-	buf.WriteString(`{"bot_user_id":`)
+	buf.WriteString(`{ "bot_user_id":`)
 	fflib.WriteJsonString(buf, string(j.BotUserID))
 	buf.WriteString(`,"bot_access_token":`)
 	fflib.WriteJsonString(buf, string(j.BotAccessToken))
+	buf.WriteByte(',')
+	if len(j.Scope) != 0 {
+		buf.WriteString(`"scope":`)
+		fflib.WriteJsonString(buf, string(j.Scope))
+		buf.WriteByte(',')
+	}
+	if len(j.RefreshToken) != 0 {
+		buf.WriteString(`"refresh_token":`)
+		fflib.WriteJsonString(buf, string(j.RefreshToken))
+		buf.WriteByte(',')
+	}
+	if j.ExpiresAt != 0 {
+		buf.WriteString(`"expires_at":`)
+		fflib.FormatBits2(buf, uint64(j.ExpiresAt), 10, j.ExpiresAt < 0)
+		buf.WriteByte(',')
+	}
+	if len(j.BotID) != 0 {
+		buf.WriteString(`"bot_id":`)
+		fflib.WriteJsonString(buf, string(j.BotID))
+		buf.WriteByte(',')
+	}
+	buf.Rewind(1)
 	buf.WriteByte('}')
 	return nil
 }
@@ -537,12 +880,28 @@ const (
 	ffjtBotBotUserID
 
 	ffjtBotBotAccessToken
+
+	ffjtBotScope
+
+	ffjtBotRefreshToken
+
+	ffjtBotExpiresAt
+
+	ffjtBotBotID
 )
 
 var ffjKeyBotBotUserID = []byte("bot_user_id")
 
 var ffjKeyBotBotAccessToken = []byte("bot_access_token")
 
+var ffjKeyBotScope = []byte("scope")
+
+var ffjKeyBotRefreshToken = []byte("refresh_token")
+
+var ffjKeyBotExpiresAt = []byte("expires_at")
+
+var ffjKeyBotBotID = []byte("bot_id")
+
 // UnmarshalJSON umarshall json - template of ffjson
 func (j *Bot) UnmarshalJSON(input []byte) error {
 	fs := fflib.NewFFLexer(input)
@@ -615,10 +974,63 @@ mainparse:
 						currentKey = ffjtBotBotAccessToken
 						state = fflib.FFParse_want_colon
 						goto mainparse
+
+					} else if bytes.Equal(ffjKeyBotBotID, kn) {
+						currentKey = ffjtBotBotID
+						state = fflib.FFParse_want_colon
+						goto mainparse
+					}
+
+				case 'e':
+
+					if bytes.Equal(ffjKeyBotExpiresAt, kn) {
+						currentKey = ffjtBotExpiresAt
+						state = fflib.FFParse_want_colon
+						goto mainparse
+					}
+
+				case 'r':
+
+					if bytes.Equal(ffjKeyBotRefreshToken, kn) {
+						currentKey = ffjtBotRefreshToken
+						state = fflib.FFParse_want_colon
+						goto mainparse
+					}
+
+				case 's':
+
+					if bytes.Equal(ffjKeyBotScope, kn) {
+						currentKey = ffjtBotScope
+						state = fflib.FFParse_want_colon
+						goto mainparse
 					}
 
 				}
 
+				if fflib.AsciiEqualFold(ffjKeyBotBotID, kn) {
+					currentKey = ffjtBotBotID
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
+				if fflib.EqualFoldRight(ffjKeyBotExpiresAt, kn) {
+					currentKey = ffjtBotExpiresAt
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
+				if fflib.EqualFoldRight(ffjKeyBotRefreshToken, kn) {
+					currentKey = ffjtBotRefreshToken
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
+				if fflib.EqualFoldRight(ffjKeyBotScope, kn) {
+					currentKey = ffjtBotScope
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
 				if fflib.EqualFoldRight(ffjKeyBotBotAccessToken, kn) {
 					currentKey = ffjtBotBotAccessToken
 					state = fflib.FFParse_want_colon
@@ -654,6 +1066,18 @@ mainparse:
 				case ffjtBotBotAccessToken:
 					goto handle_BotAccessToken
 
+				case ffjtBotScope:
+					goto handle_Scope
+
+				case ffjtBotRefreshToken:
+					goto handle_RefreshToken
+
+				case ffjtBotExpiresAt:
+					goto handle_ExpiresAt
+
+				case ffjtBotBotID:
+					goto handle_BotID
+
 				case ffjtBotnosuchkey:
 					err = fs.SkipField(tok)
 					if err != nil {
@@ -720,6 +1144,114 @@ handle_BotAccessToken:
 	state = fflib.FFParse_after_value
 	goto mainparse
 
+handle_Scope:
+
+	/* handler: j.Scope type=string kind=string quoted=false*/
+
+	{
+
+		{
+			if tok != fflib.FFTok_string && tok != fflib.FFTok_null {
+				return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for string", tok))
+			}
+		}
+
+		if tok == fflib.FFTok_null {
+
+		} else {
+
+			outBuf := fs.Output.Bytes()
+
+			j.Scope = string(string(outBuf))
+
+		}
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+handle_RefreshToken:
+
+	/* handler: j.RefreshToken type=string kind=string quoted=false*/
+
+	{
+
+		{
+			if tok != fflib.FFTok_string && tok != fflib.FFTok_null {
+				return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for string", tok))
+			}
+		}
+
+		if tok == fflib.FFTok_null {
+
+		} else {
+
+			outBuf := fs.Output.Bytes()
+
+			j.RefreshToken = string(string(outBuf))
+
+		}
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+handle_ExpiresAt:
+
+	/* handler: j.ExpiresAt type=int64 kind=int64 quoted=false*/
+
+	{
+		if tok != fflib.FFTok_integer && tok != fflib.FFTok_null {
+			return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for int64", tok))
+		}
+	}
+
+	{
+
+		if tok == fflib.FFTok_null {
+
+		} else {
+
+			tval, err := fflib.ParseInt(fs.Output.Bytes(), 10, 64)
+
+			if err != nil {
+				return fs.WrapErr(err)
+			}
+
+			j.ExpiresAt = int64(tval)
+
+		}
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+handle_BotID:
+
+	/* handler: j.BotID type=string kind=string quoted=false*/
+
+	{
+
+		{
+			if tok != fflib.FFTok_string && tok != fflib.FFTok_null {
+				return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for string", tok))
+			}
+		}
+
+		if tok == fflib.FFTok_null {
+
+		} else {
+
+			outBuf := fs.Output.Bytes()
+
+			j.BotID = string(string(outBuf))
+
+		}
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
 wantedvalue:
 	return fs.WrapErr(fmt.Errorf("wanted value token, but got token: %v", tok))
 wrongtokenerror:
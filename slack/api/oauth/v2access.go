@@ -0,0 +1,107 @@
+package oauth
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+
+	"suy.io/bots/slack/api"
+)
+
+// ffjson: nodecoder
+type V2AccessRequest struct {
+	ClientID     string `json:"client_id" url:"client_id"`
+	ClientSecret string `json:"client_secret" url:"client_secret"`
+	Code         string `json:"code,omitempty" url:"code,omitempty"`
+	Redirect     string `json:"redirect_uri,omitempty" url:"redirect_uri,omitempty"`
+	GrantType    string `json:"grant_type,omitempty" url:"grant_type,omitempty"`
+	RefreshToken string `json:"refresh_token,omitempty" url:"refresh_token,omitempty"`
+}
+
+// ffjson: noencoder
+type V2AccessResponse struct {
+	AccessToken  string `json:"access_token"`
+	TokenType    string `json:"token_type"`
+	Scope        string `json:"scope"`
+	BotUserID    string `json:"bot_user_id"`
+	AppID        string `json:"app_id"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int    `json:"expires_in"`
+	Team         *struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"team"`
+	Enterprise *struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"enterprise"`
+	IsEnterpriseInstall bool `json:"is_enterprise_install"`
+	AuthedUser          *struct {
+		ID           string `json:"id"`
+		Scope        string `json:"scope"`
+		AccessToken  string `json:"access_token"`
+		TokenType    string `json:"token_type"`
+		RefreshToken string `json:"refresh_token"`
+		ExpiresIn    int    `json:"expires_in"`
+	} `json:"authed_user"`
+}
+
+func V2Access(req *V2AccessRequest) (*V2AccessResponse, error) {
+	res := &V2AccessResponse{}
+	if err := api.Request("oauth.v2.access", req, false, res, ""); err != nil {
+		return nil, errors.Wrap(err, "oauth.v2.access failed")
+	}
+
+	return res, nil
+}
+
+// AccessResponse converts the response to the AccessResponse format used by oauth.access,
+// with the bot token and its rotation details in Bot, and the user token at the top level.
+// For enterprise installs, TeamID is set to the enterprise ID.
+func (res *V2AccessResponse) AccessResponse() *AccessResponse {
+	ans := &AccessResponse{
+		AppID:               res.AppID,
+		IsEnterpriseInstall: res.IsEnterpriseInstall,
+		Bot: &Bot{
+			BotUserID:      res.BotUserID,
+			BotAccessToken: res.AccessToken,
+			Scope:          res.Scope,
+			RefreshToken:   res.RefreshToken,
+			ExpiresAt:      ExpiresAt(res.ExpiresIn),
+		},
+	}
+
+	if res.Team != nil {
+		ans.TeamID, ans.TeamName = res.Team.ID, res.Team.Name
+	}
+
+	if res.Enterprise != nil {
+		ans.EnterpriseID, ans.EnterpriseName = res.Enterprise.ID, res.Enterprise.Name
+
+		if res.IsEnterpriseInstall {
+			ans.TeamID, ans.TeamName = res.Enterprise.ID, res.Enterprise.Name
+		}
+	}
+
+	if res.AuthedUser != nil {
+		ans.UserID = res.AuthedUser.ID
+		ans.AccessToken = res.AuthedUser.AccessToken
+		ans.Scope = res.AuthedUser.Scope
+		ans.RefreshToken = res.AuthedUser.RefreshToken
+		ans.ExpiresAt = ExpiresAt(res.AuthedUser.ExpiresIn)
+	}
+
+	return ans
+}
+
+// ExpiresAt converts a token lifetime in seconds to a unix timestamp,
+// tokens that do not expire have a lifetime of 0.
+func ExpiresAt(expiresIn int) int64 {
+	if expiresIn == 0 {
+		return 0
+	}
+
+	return time.Now().Add(time.Duration(expiresIn) * time.Second).Unix()
+}
+
+//go:generate ffjson $GOFILE
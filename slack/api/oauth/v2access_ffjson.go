@@ -0,0 +1,683 @@
+// Code generated by ffjson <https://github.com/pquerna/ffjson>. DO NOT EDIT.
+// source: v2access.go
+
+package oauth
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	fflib "github.com/pquerna/ffjson/fflib/v1"
+)
+
+// MarshalJSON marshal bytes to json - template
+func (j *V2AccessRequest) MarshalJSON() ([]byte, error) {
+	var buf fflib.Buffer
+	if j == nil {
+		buf.WriteString("null")
+		return buf.Bytes(), nil
+	}
+	err := j.MarshalJSONBuf(&buf)
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// MarshalJSONBuf marshal buff to json - template
+func (j *V2AccessRequest) MarshalJSONBuf(buf fflib.EncodingBuffer) error {
+	if j == nil {
+		buf.WriteString("null")
+		return nil
+	}
+	var err error
+	var obj []byte
+	_ = obj
+	_ = err
+	buf.WriteString(`{ "client_id":`)
+	fflib.WriteJsonString(buf, string(j.ClientID))
+	buf.WriteString(`,"client_secret":`)
+	fflib.WriteJsonString(buf, string(j.ClientSecret))
+	buf.WriteByte(',')
+	if len(j.Code) != 0 {
+		buf.WriteString(`"code":`)
+		fflib.WriteJsonString(buf, string(j.Code))
+		buf.WriteByte(',')
+	}
+	if len(j.Redirect) != 0 {
+		buf.WriteString(`"redirect_uri":`)
+		fflib.WriteJsonString(buf, string(j.Redirect))
+		buf.WriteByte(',')
+	}
+	if len(j.GrantType) != 0 {
+		buf.WriteString(`"grant_type":`)
+		fflib.WriteJsonString(buf, string(j.GrantType))
+		buf.WriteByte(',')
+	}
+	if len(j.RefreshToken) != 0 {
+		buf.WriteString(`"refresh_token":`)
+		fflib.WriteJsonString(buf, string(j.RefreshToken))
+		buf.WriteByte(',')
+	}
+	buf.Rewind(1)
+	buf.WriteByte('}')
+	return nil
+}
+
+const (
+	ffjtV2AccessResponsebase = iota
+	ffjtV2AccessResponsenosuchkey
+
+	ffjtV2AccessResponseAccessToken
+
+	ffjtV2AccessResponseTokenType
+
+	ffjtV2AccessResponseScope
+
+	ffjtV2AccessResponseBotUserID
+
+	ffjtV2AccessResponseAppID
+
+	ffjtV2AccessResponseRefreshToken
+
+	ffjtV2AccessResponseExpiresIn
+
+	ffjtV2AccessResponseTeam
+
+	ffjtV2AccessResponseEnterprise
+
+	ffjtV2AccessResponseIsEnterpriseInstall
+
+	ffjtV2AccessResponseAuthedUser
+)
+
+var ffjKeyV2AccessResponseAccessToken = []byte("access_token")
+
+var ffjKeyV2AccessResponseTokenType = []byte("token_type")
+
+var ffjKeyV2AccessResponseScope = []byte("scope")
+
+var ffjKeyV2AccessResponseBotUserID = []byte("bot_user_id")
+
+var ffjKeyV2AccessResponseAppID = []byte("app_id")
+
+var ffjKeyV2AccessResponseRefreshToken = []byte("refresh_token")
+
+var ffjKeyV2AccessResponseExpiresIn = []byte("expires_in")
+
+var ffjKeyV2AccessResponseTeam = []byte("team")
+
+var ffjKeyV2AccessResponseEnterprise = []byte("enterprise")
+
+var ffjKeyV2AccessResponseIsEnterpriseInstall = []byte("is_enterprise_install")
+
+var ffjKeyV2AccessResponseAuthedUser = []byte("authed_user")
+
+// UnmarshalJSON umarshall json - template of ffjson
+func (j *V2AccessResponse) UnmarshalJSON(input []byte) error {
+	fs := fflib.NewFFLexer(input)
+	return j.UnmarshalJSONFFLexer(fs, fflib.FFParse_map_start)
+}
+
+// UnmarshalJSONFFLexer fast json unmarshall - template ffjson
+func (j *V2AccessResponse) UnmarshalJSONFFLexer(fs *fflib.FFLexer, state fflib.FFParseState) error {
+	var err error
+	currentKey := ffjtV2AccessResponsebase
+	_ = currentKey
+	tok := fflib.FFTok_init
+	wantedTok := fflib.FFTok_init
+
+mainparse:
+	for {
+		tok = fs.Scan()
+		//	println(fmt.Sprintf("debug: tok: %v  state: %v", tok, state))
+		if tok == fflib.FFTok_error {
+			goto tokerror
+		}
+
+		switch state {
+
+		case fflib.FFParse_map_start:
+			if tok != fflib.FFTok_left_bracket {
+				wantedTok = fflib.FFTok_left_bracket
+				goto wrongtokenerror
+			}
+			state = fflib.FFParse_want_key
+			continue
+
+		case fflib.FFParse_after_value:
+			if tok == fflib.FFTok_comma {
+				state = fflib.FFParse_want_key
+			} else if tok == fflib.FFTok_right_bracket {
+				goto done
+			} else {
+				wantedTok = fflib.FFTok_comma
+				goto wrongtokenerror
+			}
+
+		case fflib.FFParse_want_key:
+			// json {} ended. goto exit. woo.
+			if tok == fflib.FFTok_right_bracket {
+				goto done
+			}
+			if tok != fflib.FFTok_string {
+				wantedTok = fflib.FFTok_string
+				goto wrongtokenerror
+			}
+
+			kn := fs.Output.Bytes()
+			if len(kn) <= 0 {
+				// "" case. hrm.
+				currentKey = ffjtV2AccessResponsenosuchkey
+				state = fflib.FFParse_want_colon
+				goto mainparse
+			} else {
+				switch kn[0] {
+
+				case 'a':
+
+					if bytes.Equal(ffjKeyV2AccessResponseAccessToken, kn) {
+						currentKey = ffjtV2AccessResponseAccessToken
+						state = fflib.FFParse_want_colon
+						goto mainparse
+
+					} else if bytes.Equal(ffjKeyV2AccessResponseAppID, kn) {
+						currentKey = ffjtV2AccessResponseAppID
+						state = fflib.FFParse_want_colon
+						goto mainparse
+
+					} else if bytes.Equal(ffjKeyV2AccessResponseAuthedUser, kn) {
+						currentKey = ffjtV2AccessResponseAuthedUser
+						state = fflib.FFParse_want_colon
+						goto mainparse
+					}
+
+				case 'b':
+
+					if bytes.Equal(ffjKeyV2AccessResponseBotUserID, kn) {
+						currentKey = ffjtV2AccessResponseBotUserID
+						state = fflib.FFParse_want_colon
+						goto mainparse
+					}
+
+				case 'e':
+
+					if bytes.Equal(ffjKeyV2AccessResponseExpiresIn, kn) {
+						currentKey = ffjtV2AccessResponseExpiresIn
+						state = fflib.FFParse_want_colon
+						goto mainparse
+
+					} else if bytes.Equal(ffjKeyV2AccessResponseEnterprise, kn) {
+						currentKey = ffjtV2AccessResponseEnterprise
+						state = fflib.FFParse_want_colon
+						goto mainparse
+					}
+
+				case 'i':
+
+					if bytes.Equal(ffjKeyV2AccessResponseIsEnterpriseInstall, kn) {
+						currentKey = ffjtV2AccessResponseIsEnterpriseInstall
+						state = fflib.FFParse_want_colon
+						goto mainparse
+					}
+
+				case 'r':
+
+					if bytes.Equal(ffjKeyV2AccessResponseRefreshToken, kn) {
+						currentKey = ffjtV2AccessResponseRefreshToken
+						state = fflib.FFParse_want_colon
+						goto mainparse
+					}
+
+				case 's':
+
+					if bytes.Equal(ffjKeyV2AccessResponseScope, kn) {
+						currentKey = ffjtV2AccessResponseScope
+						state = fflib.FFParse_want_colon
+						goto mainparse
+					}
+
+				case 't':
+
+					if bytes.Equal(ffjKeyV2AccessResponseTokenType, kn) {
+						currentKey = ffjtV2AccessResponseTokenType
+						state = fflib.FFParse_want_colon
+						goto mainparse
+
+					} else if bytes.Equal(ffjKeyV2AccessResponseTeam, kn) {
+						currentKey = ffjtV2AccessResponseTeam
+						state = fflib.FFParse_want_colon
+						goto mainparse
+					}
+
+				}
+
+				if fflib.EqualFoldRight(ffjKeyV2AccessResponseAuthedUser, kn) {
+					currentKey = ffjtV2AccessResponseAuthedUser
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
+				if fflib.EqualFoldRight(ffjKeyV2AccessResponseIsEnterpriseInstall, kn) {
+					currentKey = ffjtV2AccessResponseIsEnterpriseInstall
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
+				if fflib.EqualFoldRight(ffjKeyV2AccessResponseEnterprise, kn) {
+					currentKey = ffjtV2AccessResponseEnterprise
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
+				if fflib.SimpleLetterEqualFold(ffjKeyV2AccessResponseTeam, kn) {
+					currentKey = ffjtV2AccessResponseTeam
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
+				if fflib.EqualFoldRight(ffjKeyV2AccessResponseExpiresIn, kn) {
+					currentKey = ffjtV2AccessResponseExpiresIn
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
+				if fflib.EqualFoldRight(ffjKeyV2AccessResponseRefreshToken, kn) {
+					currentKey = ffjtV2AccessResponseRefreshToken
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
+				if fflib.AsciiEqualFold(ffjKeyV2AccessResponseAppID, kn) {
+					currentKey = ffjtV2AccessResponseAppID
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
+				if fflib.EqualFoldRight(ffjKeyV2AccessResponseBotUserID, kn) {
+					currentKey = ffjtV2AccessResponseBotUserID
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
+				if fflib.EqualFoldRight(ffjKeyV2AccessResponseScope, kn) {
+					currentKey = ffjtV2AccessResponseScope
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
+				if fflib.EqualFoldRight(ffjKeyV2AccessResponseTokenType, kn) {
+					currentKey = ffjtV2AccessResponseTokenType
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
+				if fflib.EqualFoldRight(ffjKeyV2AccessResponseAccessToken, kn) {
+					currentKey = ffjtV2AccessResponseAccessToken
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
+				currentKey = ffjtV2AccessResponsenosuchkey
+				state = fflib.FFParse_want_colon
+				goto mainparse
+			}
+
+		case fflib.FFParse_want_colon:
+			if tok != fflib.FFTok_colon {
+				wantedTok = fflib.FFTok_colon
+				goto wrongtokenerror
+			}
+			state = fflib.FFParse_want_value
+			continue
+		case fflib.FFParse_want_value:
+
+			if tok == fflib.FFTok_left_brace || tok == fflib.FFTok_left_bracket || tok == fflib.FFTok_integer || tok == fflib.FFTok_double || tok == fflib.FFTok_string || tok == fflib.FFTok_bool || tok == fflib.FFTok_null {
+				switch currentKey {
+
+				case ffjtV2AccessResponseAccessToken:
+					goto handle_AccessToken
+
+				case ffjtV2AccessResponseTokenType:
+					goto handle_TokenType
+
+				case ffjtV2AccessResponseScope:
+					goto handle_Scope
+
+				case ffjtV2AccessResponseBotUserID:
+					goto handle_BotUserID
+
+				case ffjtV2AccessResponseAppID:
+					goto handle_AppID
+
+				case ffjtV2AccessResponseRefreshToken:
+					goto handle_RefreshToken
+
+				case ffjtV2AccessResponseExpiresIn:
+					goto handle_ExpiresIn
+
+				case ffjtV2AccessResponseTeam:
+					goto handle_Team
+
+				case ffjtV2AccessResponseEnterprise:
+					goto handle_Enterprise
+
+				case ffjtV2AccessResponseIsEnterpriseInstall:
+					goto handle_IsEnterpriseInstall
+
+				case ffjtV2AccessResponseAuthedUser:
+					goto handle_AuthedUser
+
+				case ffjtV2AccessResponsenosuchkey:
+					err = fs.SkipField(tok)
+					if err != nil {
+						return fs.WrapErr(err)
+					}
+					state = fflib.FFParse_after_value
+					goto mainparse
+				}
+			} else {
+				goto wantedvalue
+			}
+		}
+	}
+
+handle_AccessToken:
+
+	/* handler: j.AccessToken type=string kind=string quoted=false*/
+
+	{
+
+		{
+			if tok != fflib.FFTok_string && tok != fflib.FFTok_null {
+				return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for string", tok))
+			}
+		}
+
+		if tok == fflib.FFTok_null {
+
+		} else {
+
+			outBuf := fs.Output.Bytes()
+
+			j.AccessToken = string(string(outBuf))
+
+		}
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+handle_TokenType:
+
+	/* handler: j.TokenType type=string kind=string quoted=false*/
+
+	{
+
+		{
+			if tok != fflib.FFTok_string && tok != fflib.FFTok_null {
+				return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for string", tok))
+			}
+		}
+
+		if tok == fflib.FFTok_null {
+
+		} else {
+
+			outBuf := fs.Output.Bytes()
+
+			j.TokenType = string(string(outBuf))
+
+		}
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+handle_Scope:
+
+	/* handler: j.Scope type=string kind=string quoted=false*/
+
+	{
+
+		{
+			if tok != fflib.FFTok_string && tok != fflib.FFTok_null {
+				return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for string", tok))
+			}
+		}
+
+		if tok == fflib.FFTok_null {
+
+		} else {
+
+			outBuf := fs.Output.Bytes()
+
+			j.Scope = string(string(outBuf))
+
+		}
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+handle_BotUserID:
+
+	/* handler: j.BotUserID type=string kind=string quoted=false*/
+
+	{
+
+		{
+			if tok != fflib.FFTok_string && tok != fflib.FFTok_null {
+				return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for string", tok))
+			}
+		}
+
+		if tok == fflib.FFTok_null {
+
+		} else {
+
+			outBuf := fs.Output.Bytes()
+
+			j.BotUserID = string(string(outBuf))
+
+		}
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+handle_AppID:
+
+	/* handler: j.AppID type=string kind=string quoted=false*/
+
+	{
+
+		{
+			if tok != fflib.FFTok_string && tok != fflib.FFTok_null {
+				return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for string", tok))
+			}
+		}
+
+		if tok == fflib.FFTok_null {
+
+		} else {
+
+			outBuf := fs.Output.Bytes()
+
+			j.AppID = string(string(outBuf))
+
+		}
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+handle_RefreshToken:
+
+	/* handler: j.RefreshToken type=string kind=string quoted=false*/
+
+	{
+
+		{
+			if tok != fflib.FFTok_string && tok != fflib.FFTok_null {
+				return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for string", tok))
+			}
+		}
+
+		if tok == fflib.FFTok_null {
+
+		} else {
+
+			outBuf := fs.Output.Bytes()
+
+			j.RefreshToken = string(string(outBuf))
+
+		}
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+handle_ExpiresIn:
+
+	/* handler: j.ExpiresIn type=int kind=int quoted=false*/
+
+	{
+		if tok != fflib.FFTok_integer && tok != fflib.FFTok_null {
+			return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for int", tok))
+		}
+	}
+
+	{
+
+		if tok == fflib.FFTok_null {
+
+		} else {
+
+			tval, err := fflib.ParseInt(fs.Output.Bytes(), 10, 64)
+
+			if err != nil {
+				return fs.WrapErr(err)
+			}
+
+			j.ExpiresIn = int(tval)
+
+		}
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+handle_Team:
+
+	/* handler: j.Team type=struct { ID string "json:\"id\""; Name string "json:\"name\"" } kind=struct quoted=false*/
+
+	{
+		/* Falling back. type=struct { ID string "json:\"id\""; Name string "json:\"name\"" } kind=struct */
+		tbuf, err := fs.CaptureField(tok)
+		if err != nil {
+			return fs.WrapErr(err)
+		}
+
+		err = json.Unmarshal(tbuf, &j.Team)
+		if err != nil {
+			return fs.WrapErr(err)
+		}
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+handle_Enterprise:
+
+	/* handler: j.Enterprise type=struct { ID string "json:\"id\""; Name string "json:\"name\"" } kind=struct quoted=false*/
+
+	{
+		/* Falling back. type=struct { ID string "json:\"id\""; Name string "json:\"name\"" } kind=struct */
+		tbuf, err := fs.CaptureField(tok)
+		if err != nil {
+			return fs.WrapErr(err)
+		}
+
+		err = json.Unmarshal(tbuf, &j.Enterprise)
+		if err != nil {
+			return fs.WrapErr(err)
+		}
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+handle_IsEnterpriseInstall:
+
+	/* handler: j.IsEnterpriseInstall type=bool kind=bool quoted=false*/
+
+	{
+		if tok != fflib.FFTok_bool && tok != fflib.FFTok_null {
+			return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for bool", tok))
+		}
+	}
+
+	{
+		if tok == fflib.FFTok_null {
+
+		} else {
+			tmpb := fs.Output.Bytes()
+
+			if bytes.Compare([]byte{'t', 'r', 'u', 'e'}, tmpb) == 0 {
+
+				j.IsEnterpriseInstall = true
+
+			} else if bytes.Compare([]byte{'f', 'a', 'l', 's', 'e'}, tmpb) == 0 {
+
+				j.IsEnterpriseInstall = false
+
+			} else {
+				err = errors.New("unexpected bytes for true/false value")
+				return fs.WrapErr(err)
+			}
+
+		}
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+handle_AuthedUser:
+
+	/* handler: j.AuthedUser type=struct { ID string "json:\"id\""; Scope string "json:\"scope\""; AccessToken string "json:\"access_token\""; TokenType string "json:\"token_type\""; RefreshToken string "json:\"refresh_token\""; ExpiresIn int "json:\"expires_in\"" } kind=struct quoted=false*/
+
+	{
+		/* Falling back. type=struct { ID string "json:\"id\""; Scope string "json:\"scope\""; AccessToken string "json:\"access_token\""; TokenType string "json:\"token_type\""; RefreshToken string "json:\"refresh_token\""; ExpiresIn int "json:\"expires_in\"" } kind=struct */
+		tbuf, err := fs.CaptureField(tok)
+		if err != nil {
+			return fs.WrapErr(err)
+		}
+
+		err = json.Unmarshal(tbuf, &j.AuthedUser)
+		if err != nil {
+			return fs.WrapErr(err)
+		}
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+wantedvalue:
+	return fs.WrapErr(fmt.Errorf("wanted value token, but got token: %v", tok))
+wrongtokenerror:
+	return fs.WrapErr(fmt.Errorf("ffjson: wanted token: %v, but got token: %v output=%s", wantedTok, tok, fs.Output.String()))
+tokerror:
+	if fs.BigError != nil {
+		return fs.WrapErr(fs.BigError)
+	}
+	err = fs.Error.ToError()
+	if err != nil {
+		return fs.WrapErr(err)
+	}
+	panic("ffjson-generated: unreachable, please report bug.")
+done:
+
+	return nil
+}
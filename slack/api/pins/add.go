@@ -0,0 +1,24 @@
+package pins // import "suy.io/bots/slack/api/pins"
+
+import (
+	"github.com/pkg/errors"
+
+	"suy.io/bots/slack/api"
+)
+
+// ffjson: nodecoder
+type AddRequest struct {
+	Token     string `json:"token" url:"token"`
+	Channel   string `json:"channel" url:"channel"`
+	Timestamp string `json:"timestamp" url:"timestamp"`
+}
+
+func Add(req *AddRequest) error {
+	if err := api.Request("pins.add", req, true, nil, req.Token); err != nil {
+		return errors.Wrap(err, "pins.add failed")
+	}
+
+	return nil
+}
+
+//go:generate ffjson $GOFILE
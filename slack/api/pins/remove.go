@@ -0,0 +1,24 @@
+package pins
+
+import (
+	"github.com/pkg/errors"
+
+	"suy.io/bots/slack/api"
+)
+
+// ffjson: nodecoder
+type RemoveRequest struct {
+	Token     string `json:"token" url:"token"`
+	Channel   string `json:"channel" url:"channel"`
+	Timestamp string `json:"timestamp" url:"timestamp"`
+}
+
+func Remove(req *RemoveRequest) error {
+	if err := api.Request("pins.remove", req, true, nil, req.Token); err != nil {
+		return errors.Wrap(err, "pins.remove failed")
+	}
+
+	return nil
+}
+
+//go:generate ffjson $GOFILE
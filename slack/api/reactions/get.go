@@ -0,0 +1,39 @@
+package reactions
+
+import (
+	"github.com/pkg/errors"
+
+	"suy.io/bots/slack/api"
+)
+
+// ffjson: nodecoder
+type GetRequest struct {
+	Token     string `json:"token" url:"token"`
+	Channel   string `json:"channel" url:"channel"`
+	Timestamp string `json:"timestamp" url:"timestamp"`
+	Full      bool   `json:"full,omitempty" url:"full,omitempty"`
+}
+
+// ffjson: noencoder
+type GetResponse struct {
+	Type    string `json:"type"`
+	Channel string `json:"channel"`
+	Message struct {
+		Type      string      `json:"type"`
+		Text      string      `json:"text"`
+		User      string      `json:"user"`
+		Ts        string      `json:"ts"`
+		Reactions []*Reaction `json:"reactions"`
+	} `json:"message"`
+}
+
+func Get(req *GetRequest) (*GetResponse, error) {
+	res := &GetResponse{}
+	if err := api.Request("reactions.get", req, false, res, ""); err != nil {
+		return nil, errors.Wrap(err, "reactions.get failed")
+	}
+
+	return res, nil
+}
+
+//go:generate ffjson $GOFILE
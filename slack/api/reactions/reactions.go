@@ -0,0 +1,9 @@
+package reactions // import "suy.io/bots/slack/api/reactions"
+
+type Reaction struct {
+	Name  string   `json:"name"`
+	Count int      `json:"count"`
+	Users []string `json:"users"`
+}
+
+//go:generate ffjson $GOFILE
@@ -0,0 +1,25 @@
+package reactions
+
+import (
+	"github.com/pkg/errors"
+
+	"suy.io/bots/slack/api"
+)
+
+// ffjson: nodecoder
+type RemoveRequest struct {
+	Token     string `json:"token" url:"token"`
+	Name      string `json:"name" url:"name"`
+	Channel   string `json:"channel" url:"channel"`
+	Timestamp string `json:"timestamp" url:"timestamp"`
+}
+
+func Remove(req *RemoveRequest) error {
+	if err := api.Request("reactions.remove", req, true, nil, req.Token); err != nil {
+		return errors.Wrap(err, "reactions.remove failed")
+	}
+
+	return nil
+}
+
+//go:generate ffjson $GOFILE
@@ -72,6 +72,11 @@ func Request(method string, body interface{}, isJSON bool, res interface{}, toke
 		return errors.Wrap(err, "Request Failed, could not read response")
 	}
 
+	return parseResponse(rawres, res)
+}
+
+// parseResponse checks the status of a raw slack API response and unmarshals it into res.
+func parseResponse(rawres []byte, res interface{}) error {
 	stat := &status{}
 
 	if err := json.Unmarshal(rawres, stat); err != nil {
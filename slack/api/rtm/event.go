@@ -0,0 +1,86 @@
+package rtm
+
+// ffjson: noencoder
+type Edited struct {
+	User string `json:"user" url:"user"`
+	Ts   string `json:"ts" url:"ts"`
+}
+
+// MessageChangedMessage is sent when a message is edited, Message is the edited message.
+//
+// ffjson: noencoder
+type MessageChangedMessage struct {
+	Type            string   `json:"type" url:"type"`
+	Subtype         string   `json:"subtype" url:"subtype"`
+	Channel         string   `json:"channel" url:"channel"`
+	Hidden          bool     `json:"hidden" url:"hidden"`
+	Message         *Message `json:"message" url:"message"`
+	PreviousMessage *Message `json:"previous_message" url:"previous_message"`
+	EventTs         string   `json:"event_ts" url:"event_ts"`
+	Ts              string   `json:"ts" url:"ts"`
+}
+
+// MessageDeletedMessage is sent when a message is deleted.
+//
+// ffjson: noencoder
+type MessageDeletedMessage struct {
+	Type            string   `json:"type" url:"type"`
+	Subtype         string   `json:"subtype" url:"subtype"`
+	Channel         string   `json:"channel" url:"channel"`
+	Hidden          bool     `json:"hidden" url:"hidden"`
+	DeletedTs       string   `json:"deleted_ts" url:"deleted_ts"`
+	PreviousMessage *Message `json:"previous_message" url:"previous_message"`
+	EventTs         string   `json:"event_ts" url:"event_ts"`
+	Ts              string   `json:"ts" url:"ts"`
+}
+
+// BotMessage is a message sent by an integration or a bot,
+// User is the bot user that sent it, if it was sent by a bot user.
+//
+// ffjson: noencoder
+type BotMessage struct {
+	Type     string `json:"type" url:"type"`
+	Subtype  string `json:"subtype" url:"subtype"`
+	Channel  string `json:"channel" url:"channel"`
+	BotID    string `json:"bot_id" url:"bot_id"`
+	User     string `json:"user" url:"user"`
+	Username string `json:"username" url:"username"`
+	Text     string `json:"text" url:"text"`
+	ThreadTs string `json:"thread_ts" url:"thread_ts"`
+	Ts       string `json:"ts" url:"ts"`
+}
+
+// MemberChannelEvent is sent when a user joins or leaves a channel the bot is in.
+//
+// ffjson: noencoder
+type MemberChannelEvent struct {
+	Type        string `json:"type" url:"type"`
+	User        string `json:"user" url:"user"`
+	Channel     string `json:"channel" url:"channel"`
+	ChannelType string `json:"channel_type" url:"channel_type"`
+	Team        string `json:"team" url:"team"`
+	Inviter     string `json:"inviter" url:"inviter"`
+	EventTs     string `json:"event_ts" url:"event_ts"`
+}
+
+// PresenceChangeEvent is sent when the presence of a user changes, for the users the bot subscribed to
+// with a presence_sub. Users is set instead of User when several users changed at once.
+//
+// ffjson: noencoder
+type PresenceChangeEvent struct {
+	Type     string   `json:"type" url:"type"`
+	User     string   `json:"user" url:"user"`
+	Users    []string `json:"users" url:"users"`
+	Presence string   `json:"presence" url:"presence"`
+}
+
+// UserTypingEvent is sent when a user is typing in a channel the bot is in.
+//
+// ffjson: noencoder
+type UserTypingEvent struct {
+	Type    string `json:"type" url:"type"`
+	Channel string `json:"channel" url:"channel"`
+	User    string `json:"user" url:"user"`
+}
+
+//go:generate ffjson $GOFILE
@@ -0,0 +1,14 @@
+package rtm
+
+// ffjson: noencoder
+type FileSharedMessage struct {
+	Type   string `json:"type" url:"type"`
+	FileID string `json:"file_id" url:"file_id"`
+	UserID string `json:"user_id" url:"user_id"`
+	File   struct {
+		ID string `json:"id" url:"id"`
+	} `json:"file" url:"file"`
+	EventTs string `json:"event_ts" url:"event_ts"`
+}
+
+//go:generate ffjson $GOFILE
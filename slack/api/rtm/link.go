@@ -0,0 +1,19 @@
+package rtm
+
+// ffjson: noencoder
+type Link struct {
+	Domain string `json:"domain" url:"domain"`
+	URL    string `json:"url" url:"url"`
+}
+
+// ffjson: noencoder
+type LinkSharedMessage struct {
+	Type      string  `json:"type" url:"type"`
+	Channel   string  `json:"channel" url:"channel"`
+	User      string  `json:"user" url:"user"`
+	MessageTs string  `json:"message_ts" url:"message_ts"`
+	ThreadTs  string  `json:"thread_ts" url:"thread_ts"`
+	Links     []*Link `json:"links" url:"links"`
+}
+
+//go:generate ffjson $GOFILE
@@ -1,14 +1,21 @@
 package rtm // import "suy.io/bots/slack/api/rtm"
 
+import "suy.io/bots/slack/api/files"
+
 // ffjson: noencoder
 type Message struct {
-	Channel    string `json:"channel" url:"channel"`
-	SourceTeam string `json:"source_team" url:"source_team"`
-	Team       string `json:"team" url:"team"`
-	Text       string `json:"text" url:"text"`
-	ThreadTs   string `json:"thread_ts" url:"thread_ts"`
-	Ts         string `json:"ts" url:"ts"`
-	User       string `json:"user" url:"user"`
+	Channel     string        `json:"channel" url:"channel"`
+	SourceTeam  string        `json:"source_team" url:"source_team"`
+	Team        string        `json:"team" url:"team"`
+	Text        string        `json:"text" url:"text"`
+	ThreadTs    string        `json:"thread_ts" url:"thread_ts"`
+	Ts          string        `json:"ts" url:"ts"`
+	User        string        `json:"user" url:"user"`
+	BotID       string        `json:"bot_id,omitempty" url:"bot_id,omitempty"`
+	ClientMsgID string        `json:"client_msg_id,omitempty" url:"client_msg_id,omitempty"`
+	UserTeam    string        `json:"user_team" url:"user_team"`
+	Files       []*files.File `json:"files,omitempty" url:"files,omitempty"`
+	Edited      *Edited       `json:"edited,omitempty" url:"edited,omitempty"`
 }
 
 // ffjson: noencoder
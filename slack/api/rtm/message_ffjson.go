@@ -8,6 +8,7 @@ import (
 	"encoding/json"
 	"fmt"
 	fflib "github.com/pquerna/ffjson/fflib/v1"
+	"suy.io/bots/slack/api/files"
 )
 
 const (
@@ -754,6 +755,16 @@ const (
 	ffjtMessageTs
 
 	ffjtMessageUser
+
+	ffjtMessageBotID
+
+	ffjtMessageClientMsgID
+
+	ffjtMessageUserTeam
+
+	ffjtMessageFiles
+
+	ffjtMessageEdited
 )
 
 var ffjKeyMessageChannel = []byte("channel")
@@ -770,6 +781,16 @@ var ffjKeyMessageTs = []byte("ts")
 
 var ffjKeyMessageUser = []byte("user")
 
+var ffjKeyMessageBotID = []byte("bot_id")
+
+var ffjKeyMessageClientMsgID = []byte("client_msg_id")
+
+var ffjKeyMessageUserTeam = []byte("user_team")
+
+var ffjKeyMessageFiles = []byte("files")
+
+var ffjKeyMessageEdited = []byte("edited")
+
 // UnmarshalJSON umarshall json - template of ffjson
 func (j *Message) UnmarshalJSON(input []byte) error {
 	fs := fflib.NewFFLexer(input)
@@ -831,12 +852,41 @@ mainparse:
 			} else {
 				switch kn[0] {
 
+				case 'b':
+
+					if bytes.Equal(ffjKeyMessageBotID, kn) {
+						currentKey = ffjtMessageBotID
+						state = fflib.FFParse_want_colon
+						goto mainparse
+					}
+
 				case 'c':
 
 					if bytes.Equal(ffjKeyMessageChannel, kn) {
 						currentKey = ffjtMessageChannel
 						state = fflib.FFParse_want_colon
 						goto mainparse
+
+					} else if bytes.Equal(ffjKeyMessageClientMsgID, kn) {
+						currentKey = ffjtMessageClientMsgID
+						state = fflib.FFParse_want_colon
+						goto mainparse
+					}
+
+				case 'e':
+
+					if bytes.Equal(ffjKeyMessageEdited, kn) {
+						currentKey = ffjtMessageEdited
+						state = fflib.FFParse_want_colon
+						goto mainparse
+					}
+
+				case 'f':
+
+					if bytes.Equal(ffjKeyMessageFiles, kn) {
+						currentKey = ffjtMessageFiles
+						state = fflib.FFParse_want_colon
+						goto mainparse
 					}
 
 				case 's':
@@ -876,10 +926,45 @@ mainparse:
 						currentKey = ffjtMessageUser
 						state = fflib.FFParse_want_colon
 						goto mainparse
+
+					} else if bytes.Equal(ffjKeyMessageUserTeam, kn) {
+						currentKey = ffjtMessageUserTeam
+						state = fflib.FFParse_want_colon
+						goto mainparse
 					}
 
 				}
 
+				if fflib.SimpleLetterEqualFold(ffjKeyMessageEdited, kn) {
+					currentKey = ffjtMessageEdited
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
+				if fflib.EqualFoldRight(ffjKeyMessageFiles, kn) {
+					currentKey = ffjtMessageFiles
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
+				if fflib.EqualFoldRight(ffjKeyMessageUserTeam, kn) {
+					currentKey = ffjtMessageUserTeam
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
+				if fflib.EqualFoldRight(ffjKeyMessageClientMsgID, kn) {
+					currentKey = ffjtMessageClientMsgID
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
+				if fflib.AsciiEqualFold(ffjKeyMessageBotID, kn) {
+					currentKey = ffjtMessageBotID
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
 				if fflib.EqualFoldRight(ffjKeyMessageUser, kn) {
 					currentKey = ffjtMessageUser
 					state = fflib.FFParse_want_colon
@@ -960,6 +1045,21 @@ mainparse:
 				case ffjtMessageUser:
 					goto handle_User
 
+				case ffjtMessageBotID:
+					goto handle_BotID
+
+				case ffjtMessageClientMsgID:
+					goto handle_ClientMsgID
+
+				case ffjtMessageUserTeam:
+					goto handle_UserTeam
+
+				case ffjtMessageFiles:
+					goto handle_Files
+
+				case ffjtMessageEdited:
+					goto handle_Edited
+
 				case ffjtMessagenosuchkey:
 					err = fs.SkipField(tok)
 					if err != nil {
@@ -1156,6 +1256,184 @@ handle_User:
 	state = fflib.FFParse_after_value
 	goto mainparse
 
+handle_BotID:
+
+	/* handler: j.BotID type=string kind=string quoted=false*/
+
+	{
+
+		{
+			if tok != fflib.FFTok_string && tok != fflib.FFTok_null {
+				return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for string", tok))
+			}
+		}
+
+		if tok == fflib.FFTok_null {
+
+		} else {
+
+			outBuf := fs.Output.Bytes()
+
+			j.BotID = string(string(outBuf))
+
+		}
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+handle_ClientMsgID:
+
+	/* handler: j.ClientMsgID type=string kind=string quoted=false*/
+
+	{
+
+		{
+			if tok != fflib.FFTok_string && tok != fflib.FFTok_null {
+				return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for string", tok))
+			}
+		}
+
+		if tok == fflib.FFTok_null {
+
+		} else {
+
+			outBuf := fs.Output.Bytes()
+
+			j.ClientMsgID = string(string(outBuf))
+
+		}
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+handle_UserTeam:
+
+	/* handler: j.UserTeam type=string kind=string quoted=false*/
+
+	{
+
+		{
+			if tok != fflib.FFTok_string && tok != fflib.FFTok_null {
+				return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for string", tok))
+			}
+		}
+
+		if tok == fflib.FFTok_null {
+
+		} else {
+
+			outBuf := fs.Output.Bytes()
+
+			j.UserTeam = string(string(outBuf))
+
+		}
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+handle_Files:
+
+	/* handler: j.Files type=[]*files.File kind=slice quoted=false*/
+
+	{
+
+		{
+			if tok != fflib.FFTok_left_brace && tok != fflib.FFTok_null {
+				return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for ", tok))
+			}
+		}
+
+		if tok == fflib.FFTok_null {
+			j.Files = nil
+		} else {
+
+			j.Files = []*files.File{}
+
+			wantVal := true
+
+			for {
+
+				var tmpJFiles *files.File
+
+				tok = fs.Scan()
+				if tok == fflib.FFTok_error {
+					goto tokerror
+				}
+				if tok == fflib.FFTok_right_brace {
+					break
+				}
+
+				if tok == fflib.FFTok_comma {
+					if wantVal == true {
+						// TODO(pquerna): this isn't an ideal error message, this handles
+						// things like [,,,] as an array value.
+						return fs.WrapErr(fmt.Errorf("wanted value token, but got token: %v", tok))
+					}
+					continue
+				} else {
+					wantVal = true
+				}
+
+				/* handler: tmpJFiles type=*files.File kind=ptr quoted=false*/
+
+				{
+					if tok == fflib.FFTok_null {
+
+						tmpJFiles = nil
+
+					} else {
+
+						if tmpJFiles == nil {
+							tmpJFiles = new(files.File)
+						}
+
+						err = tmpJFiles.UnmarshalJSONFFLexer(fs, fflib.FFParse_want_key)
+						if err != nil {
+							return err
+						}
+					}
+					state = fflib.FFParse_after_value
+				}
+
+				j.Files = append(j.Files, tmpJFiles)
+
+				wantVal = false
+			}
+		}
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+handle_Edited:
+
+	/* handler: j.Edited type=rtm.Edited kind=struct quoted=false*/
+
+	{
+		if tok == fflib.FFTok_null {
+
+			j.Edited = nil
+
+		} else {
+
+			if j.Edited == nil {
+				j.Edited = new(Edited)
+			}
+
+			err = j.Edited.UnmarshalJSONFFLexer(fs, fflib.FFParse_want_key)
+			if err != nil {
+				return err
+			}
+		}
+		state = fflib.FFParse_after_value
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
 wantedvalue:
 	return fs.WrapErr(fmt.Errorf("wanted value token, but got token: %v", tok))
 wrongtokenerror:
@@ -0,0 +1,22 @@
+package rtm
+
+// ffjson: noencoder
+type ReactionItem struct {
+	Type        string `json:"type" url:"type"`
+	Channel     string `json:"channel" url:"channel"`
+	Ts          string `json:"ts" url:"ts"`
+	File        string `json:"file" url:"file"`
+	FileComment string `json:"file_comment" url:"file_comment"`
+}
+
+// ffjson: noencoder
+type ReactionMessage struct {
+	Type     string        `json:"type" url:"type"`
+	User     string        `json:"user" url:"user"`
+	Reaction string        `json:"reaction" url:"reaction"`
+	ItemUser string        `json:"item_user" url:"item_user"`
+	Item     *ReactionItem `json:"item" url:"item"`
+	EventTs  string        `json:"event_ts" url:"event_ts"`
+}
+
+//go:generate ffjson $GOFILE
@@ -0,0 +1,78 @@
+package api
+
+import (
+	"io"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+
+	"github.com/google/go-querystring/query"
+	"github.com/pkg/errors"
+)
+
+// Upload can be used to make a raw slack API request with a file.
+// The fields in body are sent alongside the file as a multipart form,
+// and the file is streamed to slack as it is read.
+func Upload(method string, body interface{}, field, filename string, file io.Reader, res interface{}, token string) error {
+	v, err := query.Values(body)
+	if err != nil {
+		return errors.Wrap(err, "Upload Failed, could not create form fields")
+	}
+
+	pr, pw := io.Pipe()
+	mw := multipart.NewWriter(pw)
+
+	go func() {
+		pw.CloseWithError(writeMultipart(mw, v, field, filename, file))
+	}()
+
+	url := SLACK_API_ROOT + "/" + method
+	req, err := http.NewRequest(http.MethodPost, url, pr)
+	if err != nil {
+		pr.CloseWithError(err)
+		return errors.Wrap(err, "Upload Failed, could not create request")
+	}
+
+	req.Header.Add("Content-Type", mw.FormDataContentType())
+
+	if token != "" {
+		req.Header.Add("Authorization", "Bearer "+token)
+	}
+
+	apires, err := http.DefaultClient.Do(req)
+	if err != nil {
+		pr.CloseWithError(err)
+		return errors.Wrap(err, "Upload Failed, could not send request")
+	}
+
+	defer apires.Body.Close()
+
+	rawres, err := ioutil.ReadAll(apires.Body)
+	if err != nil {
+		return errors.Wrap(err, "Upload Failed, could not read response")
+	}
+
+	return parseResponse(rawres, res)
+}
+
+func writeMultipart(mw *multipart.Writer, fields url.Values, field, filename string, file io.Reader) error {
+	for k, vs := range fields {
+		for _, v := range vs {
+			if err := mw.WriteField(k, v); err != nil {
+				return err
+			}
+		}
+	}
+
+	part, err := mw.CreateFormFile(field, filename)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(part, file); err != nil {
+		return err
+	}
+
+	return mw.Close()
+}
@@ -0,0 +1,30 @@
+package users
+
+import (
+	"github.com/pkg/errors"
+
+	"suy.io/bots/slack/api"
+)
+
+// ffjson: nodecoder
+type InfoRequest struct {
+	Token         string `json:"token" url:"token"`
+	User          string `json:"user" url:"user"`
+	IncludeLocale bool   `json:"include_locale,omitempty" url:"include_locale,omitempty"`
+}
+
+// ffjson: noencoder
+type InfoResponse struct {
+	User *User `json:"user"`
+}
+
+func Info(req *InfoRequest) (*InfoResponse, error) {
+	res := &InfoResponse{}
+	if err := api.Request("users.info", req, false, res, ""); err != nil {
+		return nil, errors.Wrap(err, "users.info failed")
+	}
+
+	return res, nil
+}
+
+//go:generate ffjson $GOFILE
@@ -0,0 +1,52 @@
+package users
+
+import (
+	"github.com/pkg/errors"
+
+	"suy.io/bots/slack/api"
+)
+
+// ffjson: nodecoder
+type ListRequest struct {
+	Token         string `json:"token" url:"token"`
+	Cursor        string `json:"cursor,omitempty" url:"cursor,omitempty"`
+	IncludeLocale bool   `json:"include_locale,omitempty" url:"include_locale,omitempty"`
+	Limit         int    `json:"limit,omitempty" url:"limit,omitempty"`
+}
+
+// ffjson: noencoder
+type ListResponse struct {
+	Members          []*User           `json:"members"`
+	CacheTs          int               `json:"cache_ts"`
+	ResponseMetadata *ResponseMetadata `json:"response_metadata"`
+}
+
+func List(req *ListRequest) (*ListResponse, error) {
+	res := &ListResponse{}
+	if err := api.Request("users.list", req, false, res, ""); err != nil {
+		return nil, errors.Wrap(err, "users.list failed")
+	}
+
+	return res, nil
+}
+
+// ListPages calls users.list repeatedly, following the returned cursor,
+// and passes each page to f until there are no more pages or f returns false.
+func ListPages(req *ListRequest, f func(*ListResponse) bool) error {
+	r := *req
+
+	for {
+		res, err := List(&r)
+		if err != nil {
+			return err
+		}
+
+		if !f(res) || res.ResponseMetadata == nil || res.ResponseMetadata.NextCursor == "" {
+			return nil
+		}
+
+		r.Cursor = res.ResponseMetadata.NextCursor
+	}
+}
+
+//go:generate ffjson $GOFILE
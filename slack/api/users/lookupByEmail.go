@@ -0,0 +1,29 @@
+package users
+
+import (
+	"github.com/pkg/errors"
+
+	"suy.io/bots/slack/api"
+)
+
+// ffjson: nodecoder
+type LookupByEmailRequest struct {
+	Token string `json:"token" url:"token"`
+	Email string `json:"email" url:"email"`
+}
+
+// ffjson: noencoder
+type LookupByEmailResponse struct {
+	User *User `json:"user"`
+}
+
+func LookupByEmail(req *LookupByEmailRequest) (*LookupByEmailResponse, error) {
+	res := &LookupByEmailResponse{}
+	if err := api.Request("users.lookupByEmail", req, false, res, ""); err != nil {
+		return nil, errors.Wrap(err, "users.lookupByEmail failed")
+	}
+
+	return res, nil
+}
+
+//go:generate ffjson $GOFILE
@@ -0,0 +1,30 @@
+package users
+
+import (
+	"github.com/pkg/errors"
+
+	"suy.io/bots/slack/api"
+)
+
+// ffjson: nodecoder
+type ProfileGetRequest struct {
+	Token         string `json:"token" url:"token"`
+	User          string `json:"user,omitempty" url:"user,omitempty"`
+	IncludeLabels bool   `json:"include_labels,omitempty" url:"include_labels,omitempty"`
+}
+
+// ffjson: noencoder
+type ProfileGetResponse struct {
+	Profile *Profile `json:"profile"`
+}
+
+func ProfileGet(req *ProfileGetRequest) (*ProfileGetResponse, error) {
+	res := &ProfileGetResponse{}
+	if err := api.Request("users.profile.get", req, false, res, ""); err != nil {
+		return nil, errors.Wrap(err, "users.profile.get failed")
+	}
+
+	return res, nil
+}
+
+//go:generate ffjson $GOFILE
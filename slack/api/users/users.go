@@ -0,0 +1,54 @@
+package users // import "suy.io/bots/slack/api/users"
+
+type User struct {
+	ID                string   `json:"id"`
+	TeamID            string   `json:"team_id"`
+	Name              string   `json:"name"`
+	Deleted           bool     `json:"deleted"`
+	Color             string   `json:"color"`
+	RealName          string   `json:"real_name"`
+	Tz                string   `json:"tz"`
+	TzLabel           string   `json:"tz_label"`
+	TzOffset          int      `json:"tz_offset"`
+	Profile           *Profile `json:"profile"`
+	IsAdmin           bool     `json:"is_admin"`
+	IsOwner           bool     `json:"is_owner"`
+	IsPrimaryOwner    bool     `json:"is_primary_owner"`
+	IsRestricted      bool     `json:"is_restricted"`
+	IsUltraRestricted bool     `json:"is_ultra_restricted"`
+	IsBot             bool     `json:"is_bot"`
+	IsAppUser         bool     `json:"is_app_user"`
+	Updated           int      `json:"updated"`
+	Locale            string   `json:"locale,omitempty"`
+}
+
+type Profile struct {
+	AvatarHash            string `json:"avatar_hash"`
+	StatusText            string `json:"status_text"`
+	StatusEmoji           string `json:"status_emoji"`
+	StatusExpiration      int    `json:"status_expiration"`
+	Title                 string `json:"title"`
+	Phone                 string `json:"phone"`
+	RealName              string `json:"real_name"`
+	RealNameNormalized    string `json:"real_name_normalized"`
+	DisplayName           string `json:"display_name"`
+	DisplayNameNormalized string `json:"display_name_normalized"`
+	FirstName             string `json:"first_name"`
+	LastName              string `json:"last_name"`
+	Email                 string `json:"email"`
+	Image24               string `json:"image_24"`
+	Image32               string `json:"image_32"`
+	Image48               string `json:"image_48"`
+	Image72               string `json:"image_72"`
+	Image192              string `json:"image_192"`
+	Image512              string `json:"image_512"`
+	Team                  string `json:"team"`
+	BotID                 string `json:"bot_id,omitempty"`
+}
+
+// ResponseMetadata holds the cursor to be passed to get the next page of a paginated response.
+type ResponseMetadata struct {
+	NextCursor string `json:"next_cursor"`
+}
+
+//go:generate ffjson $GOFILE
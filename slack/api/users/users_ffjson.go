@@ -0,0 +1,2525 @@
+// Code generated by ffjson <https://github.com/pquerna/ffjson>. DO NOT EDIT.
+// source: users.go
+
+package users
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	fflib "github.com/pquerna/ffjson/fflib/v1"
+)
+
+// MarshalJSON marshal bytes to json - template
+func (j *Profile) MarshalJSON() ([]byte, error) {
+	var buf fflib.Buffer
+	if j == nil {
+		buf.WriteString("null")
+		return buf.Bytes(), nil
+	}
+	err := j.MarshalJSONBuf(&buf)
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// MarshalJSONBuf marshal buff to json - template
+func (j *Profile) MarshalJSONBuf(buf fflib.EncodingBuffer) error {
+	if j == nil {
+		buf.WriteString("null")
+		return nil
+	}
+	var err error
+	var obj []byte
+	_ = obj
+	_ = err
+	buf.WriteString(`{ "avatar_hash":`)
+	fflib.WriteJsonString(buf, string(j.AvatarHash))
+	buf.WriteString(`,"status_text":`)
+	fflib.WriteJsonString(buf, string(j.StatusText))
+	buf.WriteString(`,"status_emoji":`)
+	fflib.WriteJsonString(buf, string(j.StatusEmoji))
+	buf.WriteString(`,"status_expiration":`)
+	fflib.FormatBits2(buf, uint64(j.StatusExpiration), 10, j.StatusExpiration < 0)
+	buf.WriteString(`,"title":`)
+	fflib.WriteJsonString(buf, string(j.Title))
+	buf.WriteString(`,"phone":`)
+	fflib.WriteJsonString(buf, string(j.Phone))
+	buf.WriteString(`,"real_name":`)
+	fflib.WriteJsonString(buf, string(j.RealName))
+	buf.WriteString(`,"real_name_normalized":`)
+	fflib.WriteJsonString(buf, string(j.RealNameNormalized))
+	buf.WriteString(`,"display_name":`)
+	fflib.WriteJsonString(buf, string(j.DisplayName))
+	buf.WriteString(`,"display_name_normalized":`)
+	fflib.WriteJsonString(buf, string(j.DisplayNameNormalized))
+	buf.WriteString(`,"first_name":`)
+	fflib.WriteJsonString(buf, string(j.FirstName))
+	buf.WriteString(`,"last_name":`)
+	fflib.WriteJsonString(buf, string(j.LastName))
+	buf.WriteString(`,"email":`)
+	fflib.WriteJsonString(buf, string(j.Email))
+	buf.WriteString(`,"image_24":`)
+	fflib.WriteJsonString(buf, string(j.Image24))
+	buf.WriteString(`,"image_32":`)
+	fflib.WriteJsonString(buf, string(j.Image32))
+	buf.WriteString(`,"image_48":`)
+	fflib.WriteJsonString(buf, string(j.Image48))
+	buf.WriteString(`,"image_72":`)
+	fflib.WriteJsonString(buf, string(j.Image72))
+	buf.WriteString(`,"image_192":`)
+	fflib.WriteJsonString(buf, string(j.Image192))
+	buf.WriteString(`,"image_512":`)
+	fflib.WriteJsonString(buf, string(j.Image512))
+	buf.WriteString(`,"team":`)
+	fflib.WriteJsonString(buf, string(j.Team))
+	buf.WriteByte(',')
+	if len(j.BotID) != 0 {
+		buf.WriteString(`"bot_id":`)
+		fflib.WriteJsonString(buf, string(j.BotID))
+		buf.WriteByte(',')
+	}
+	buf.Rewind(1)
+	buf.WriteByte('}')
+	return nil
+}
+
+const (
+	ffjtProfilebase = iota
+	ffjtProfilenosuchkey
+
+	ffjtProfileAvatarHash
+
+	ffjtProfileStatusText
+
+	ffjtProfileStatusEmoji
+
+	ffjtProfileStatusExpiration
+
+	ffjtProfileTitle
+
+	ffjtProfilePhone
+
+	ffjtProfileRealName
+
+	ffjtProfileRealNameNormalized
+
+	ffjtProfileDisplayName
+
+	ffjtProfileDisplayNameNormalized
+
+	ffjtProfileFirstName
+
+	ffjtProfileLastName
+
+	ffjtProfileEmail
+
+	ffjtProfileImage24
+
+	ffjtProfileImage32
+
+	ffjtProfileImage48
+
+	ffjtProfileImage72
+
+	ffjtProfileImage192
+
+	ffjtProfileImage512
+
+	ffjtProfileTeam
+
+	ffjtProfileBotID
+)
+
+var ffjKeyProfileAvatarHash = []byte("avatar_hash")
+
+var ffjKeyProfileStatusText = []byte("status_text")
+
+var ffjKeyProfileStatusEmoji = []byte("status_emoji")
+
+var ffjKeyProfileStatusExpiration = []byte("status_expiration")
+
+var ffjKeyProfileTitle = []byte("title")
+
+var ffjKeyProfilePhone = []byte("phone")
+
+var ffjKeyProfileRealName = []byte("real_name")
+
+var ffjKeyProfileRealNameNormalized = []byte("real_name_normalized")
+
+var ffjKeyProfileDisplayName = []byte("display_name")
+
+var ffjKeyProfileDisplayNameNormalized = []byte("display_name_normalized")
+
+var ffjKeyProfileFirstName = []byte("first_name")
+
+var ffjKeyProfileLastName = []byte("last_name")
+
+var ffjKeyProfileEmail = []byte("email")
+
+var ffjKeyProfileImage24 = []byte("image_24")
+
+var ffjKeyProfileImage32 = []byte("image_32")
+
+var ffjKeyProfileImage48 = []byte("image_48")
+
+var ffjKeyProfileImage72 = []byte("image_72")
+
+var ffjKeyProfileImage192 = []byte("image_192")
+
+var ffjKeyProfileImage512 = []byte("image_512")
+
+var ffjKeyProfileTeam = []byte("team")
+
+var ffjKeyProfileBotID = []byte("bot_id")
+
+// UnmarshalJSON umarshall json - template of ffjson
+func (j *Profile) UnmarshalJSON(input []byte) error {
+	fs := fflib.NewFFLexer(input)
+	return j.UnmarshalJSONFFLexer(fs, fflib.FFParse_map_start)
+}
+
+// UnmarshalJSONFFLexer fast json unmarshall - template ffjson
+func (j *Profile) UnmarshalJSONFFLexer(fs *fflib.FFLexer, state fflib.FFParseState) error {
+	var err error
+	currentKey := ffjtProfilebase
+	_ = currentKey
+	tok := fflib.FFTok_init
+	wantedTok := fflib.FFTok_init
+
+mainparse:
+	for {
+		tok = fs.Scan()
+		//	println(fmt.Sprintf("debug: tok: %v  state: %v", tok, state))
+		if tok == fflib.FFTok_error {
+			goto tokerror
+		}
+
+		switch state {
+
+		case fflib.FFParse_map_start:
+			if tok != fflib.FFTok_left_bracket {
+				wantedTok = fflib.FFTok_left_bracket
+				goto wrongtokenerror
+			}
+			state = fflib.FFParse_want_key
+			continue
+
+		case fflib.FFParse_after_value:
+			if tok == fflib.FFTok_comma {
+				state = fflib.FFParse_want_key
+			} else if tok == fflib.FFTok_right_bracket {
+				goto done
+			} else {
+				wantedTok = fflib.FFTok_comma
+				goto wrongtokenerror
+			}
+
+		case fflib.FFParse_want_key:
+			// json {} ended. goto exit. woo.
+			if tok == fflib.FFTok_right_bracket {
+				goto done
+			}
+			if tok != fflib.FFTok_string {
+				wantedTok = fflib.FFTok_string
+				goto wrongtokenerror
+			}
+
+			kn := fs.Output.Bytes()
+			if len(kn) <= 0 {
+				// "" case. hrm.
+				currentKey = ffjtProfilenosuchkey
+				state = fflib.FFParse_want_colon
+				goto mainparse
+			} else {
+				switch kn[0] {
+
+				case 'a':
+
+					if bytes.Equal(ffjKeyProfileAvatarHash, kn) {
+						currentKey = ffjtProfileAvatarHash
+						state = fflib.FFParse_want_colon
+						goto mainparse
+					}
+
+				case 'b':
+
+					if bytes.Equal(ffjKeyProfileBotID, kn) {
+						currentKey = ffjtProfileBotID
+						state = fflib.FFParse_want_colon
+						goto mainparse
+					}
+
+				case 'd':
+
+					if bytes.Equal(ffjKeyProfileDisplayName, kn) {
+						currentKey = ffjtProfileDisplayName
+						state = fflib.FFParse_want_colon
+						goto mainparse
+
+					} else if bytes.Equal(ffjKeyProfileDisplayNameNormalized, kn) {
+						currentKey = ffjtProfileDisplayNameNormalized
+						state = fflib.FFParse_want_colon
+						goto mainparse
+					}
+
+				case 'e':
+
+					if bytes.Equal(ffjKeyProfileEmail, kn) {
+						currentKey = ffjtProfileEmail
+						state = fflib.FFParse_want_colon
+						goto mainparse
+					}
+
+				case 'f':
+
+					if bytes.Equal(ffjKeyProfileFirstName, kn) {
+						currentKey = ffjtProfileFirstName
+						state = fflib.FFParse_want_colon
+						goto mainparse
+					}
+
+				case 'i':
+
+					if bytes.Equal(ffjKeyProfileImage24, kn) {
+						currentKey = ffjtProfileImage24
+						state = fflib.FFParse_want_colon
+						goto mainparse
+
+					} else if bytes.Equal(ffjKeyProfileImage32, kn) {
+						currentKey = ffjtProfileImage32
+						state = fflib.FFParse_want_colon
+						goto mainparse
+
+					} else if bytes.Equal(ffjKeyProfileImage48, kn) {
+						currentKey = ffjtProfileImage48
+						state = fflib.FFParse_want_colon
+						goto mainparse
+
+					} else if bytes.Equal(ffjKeyProfileImage72, kn) {
+						currentKey = ffjtProfileImage72
+						state = fflib.FFParse_want_colon
+						goto mainparse
+
+					} else if bytes.Equal(ffjKeyProfileImage192, kn) {
+						currentKey = ffjtProfileImage192
+						state = fflib.FFParse_want_colon
+						goto mainparse
+
+					} else if bytes.Equal(ffjKeyProfileImage512, kn) {
+						currentKey = ffjtProfileImage512
+						state = fflib.FFParse_want_colon
+						goto mainparse
+					}
+
+				case 'l':
+
+					if bytes.Equal(ffjKeyProfileLastName, kn) {
+						currentKey = ffjtProfileLastName
+						state = fflib.FFParse_want_colon
+						goto mainparse
+					}
+
+				case 'p':
+
+					if bytes.Equal(ffjKeyProfilePhone, kn) {
+						currentKey = ffjtProfilePhone
+						state = fflib.FFParse_want_colon
+						goto mainparse
+					}
+
+				case 'r':
+
+					if bytes.Equal(ffjKeyProfileRealName, kn) {
+						currentKey = ffjtProfileRealName
+						state = fflib.FFParse_want_colon
+						goto mainparse
+
+					} else if bytes.Equal(ffjKeyProfileRealNameNormalized, kn) {
+						currentKey = ffjtProfileRealNameNormalized
+						state = fflib.FFParse_want_colon
+						goto mainparse
+					}
+
+				case 's':
+
+					if bytes.Equal(ffjKeyProfileStatusText, kn) {
+						currentKey = ffjtProfileStatusText
+						state = fflib.FFParse_want_colon
+						goto mainparse
+
+					} else if bytes.Equal(ffjKeyProfileStatusEmoji, kn) {
+						currentKey = ffjtProfileStatusEmoji
+						state = fflib.FFParse_want_colon
+						goto mainparse
+
+					} else if bytes.Equal(ffjKeyProfileStatusExpiration, kn) {
+						currentKey = ffjtProfileStatusExpiration
+						state = fflib.FFParse_want_colon
+						goto mainparse
+					}
+
+				case 't':
+
+					if bytes.Equal(ffjKeyProfileTitle, kn) {
+						currentKey = ffjtProfileTitle
+						state = fflib.FFParse_want_colon
+						goto mainparse
+
+					} else if bytes.Equal(ffjKeyProfileTeam, kn) {
+						currentKey = ffjtProfileTeam
+						state = fflib.FFParse_want_colon
+						goto mainparse
+					}
+
+				}
+
+				if fflib.AsciiEqualFold(ffjKeyProfileBotID, kn) {
+					currentKey = ffjtProfileBotID
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
+				if fflib.SimpleLetterEqualFold(ffjKeyProfileTeam, kn) {
+					currentKey = ffjtProfileTeam
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
+				if fflib.AsciiEqualFold(ffjKeyProfileImage512, kn) {
+					currentKey = ffjtProfileImage512
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
+				if fflib.AsciiEqualFold(ffjKeyProfileImage192, kn) {
+					currentKey = ffjtProfileImage192
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
+				if fflib.AsciiEqualFold(ffjKeyProfileImage72, kn) {
+					currentKey = ffjtProfileImage72
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
+				if fflib.AsciiEqualFold(ffjKeyProfileImage48, kn) {
+					currentKey = ffjtProfileImage48
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
+				if fflib.AsciiEqualFold(ffjKeyProfileImage32, kn) {
+					currentKey = ffjtProfileImage32
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
+				if fflib.AsciiEqualFold(ffjKeyProfileImage24, kn) {
+					currentKey = ffjtProfileImage24
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
+				if fflib.SimpleLetterEqualFold(ffjKeyProfileEmail, kn) {
+					currentKey = ffjtProfileEmail
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
+				if fflib.EqualFoldRight(ffjKeyProfileLastName, kn) {
+					currentKey = ffjtProfileLastName
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
+				if fflib.EqualFoldRight(ffjKeyProfileFirstName, kn) {
+					currentKey = ffjtProfileFirstName
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
+				if fflib.EqualFoldRight(ffjKeyProfileDisplayNameNormalized, kn) {
+					currentKey = ffjtProfileDisplayNameNormalized
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
+				if fflib.EqualFoldRight(ffjKeyProfileDisplayName, kn) {
+					currentKey = ffjtProfileDisplayName
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
+				if fflib.AsciiEqualFold(ffjKeyProfileRealNameNormalized, kn) {
+					currentKey = ffjtProfileRealNameNormalized
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
+				if fflib.AsciiEqualFold(ffjKeyProfileRealName, kn) {
+					currentKey = ffjtProfileRealName
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
+				if fflib.SimpleLetterEqualFold(ffjKeyProfilePhone, kn) {
+					currentKey = ffjtProfilePhone
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
+				if fflib.SimpleLetterEqualFold(ffjKeyProfileTitle, kn) {
+					currentKey = ffjtProfileTitle
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
+				if fflib.EqualFoldRight(ffjKeyProfileStatusExpiration, kn) {
+					currentKey = ffjtProfileStatusExpiration
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
+				if fflib.EqualFoldRight(ffjKeyProfileStatusEmoji, kn) {
+					currentKey = ffjtProfileStatusEmoji
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
+				if fflib.EqualFoldRight(ffjKeyProfileStatusText, kn) {
+					currentKey = ffjtProfileStatusText
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
+				if fflib.EqualFoldRight(ffjKeyProfileAvatarHash, kn) {
+					currentKey = ffjtProfileAvatarHash
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
+				currentKey = ffjtProfilenosuchkey
+				state = fflib.FFParse_want_colon
+				goto mainparse
+			}
+
+		case fflib.FFParse_want_colon:
+			if tok != fflib.FFTok_colon {
+				wantedTok = fflib.FFTok_colon
+				goto wrongtokenerror
+			}
+			state = fflib.FFParse_want_value
+			continue
+		case fflib.FFParse_want_value:
+
+			if tok == fflib.FFTok_left_brace || tok == fflib.FFTok_left_bracket || tok == fflib.FFTok_integer || tok == fflib.FFTok_double || tok == fflib.FFTok_string || tok == fflib.FFTok_bool || tok == fflib.FFTok_null {
+				switch currentKey {
+
+				case ffjtProfileAvatarHash:
+					goto handle_AvatarHash
+
+				case ffjtProfileStatusText:
+					goto handle_StatusText
+
+				case ffjtProfileStatusEmoji:
+					goto handle_StatusEmoji
+
+				case ffjtProfileStatusExpiration:
+					goto handle_StatusExpiration
+
+				case ffjtProfileTitle:
+					goto handle_Title
+
+				case ffjtProfilePhone:
+					goto handle_Phone
+
+				case ffjtProfileRealName:
+					goto handle_RealName
+
+				case ffjtProfileRealNameNormalized:
+					goto handle_RealNameNormalized
+
+				case ffjtProfileDisplayName:
+					goto handle_DisplayName
+
+				case ffjtProfileDisplayNameNormalized:
+					goto handle_DisplayNameNormalized
+
+				case ffjtProfileFirstName:
+					goto handle_FirstName
+
+				case ffjtProfileLastName:
+					goto handle_LastName
+
+				case ffjtProfileEmail:
+					goto handle_Email
+
+				case ffjtProfileImage24:
+					goto handle_Image24
+
+				case ffjtProfileImage32:
+					goto handle_Image32
+
+				case ffjtProfileImage48:
+					goto handle_Image48
+
+				case ffjtProfileImage72:
+					goto handle_Image72
+
+				case ffjtProfileImage192:
+					goto handle_Image192
+
+				case ffjtProfileImage512:
+					goto handle_Image512
+
+				case ffjtProfileTeam:
+					goto handle_Team
+
+				case ffjtProfileBotID:
+					goto handle_BotID
+
+				case ffjtProfilenosuchkey:
+					err = fs.SkipField(tok)
+					if err != nil {
+						return fs.WrapErr(err)
+					}
+					state = fflib.FFParse_after_value
+					goto mainparse
+				}
+			} else {
+				goto wantedvalue
+			}
+		}
+	}
+
+handle_AvatarHash:
+
+	/* handler: j.AvatarHash type=string kind=string quoted=false*/
+
+	{
+
+		{
+			if tok != fflib.FFTok_string && tok != fflib.FFTok_null {
+				return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for string", tok))
+			}
+		}
+
+		if tok == fflib.FFTok_null {
+
+		} else {
+
+			outBuf := fs.Output.Bytes()
+
+			j.AvatarHash = string(string(outBuf))
+
+		}
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+handle_StatusText:
+
+	/* handler: j.StatusText type=string kind=string quoted=false*/
+
+	{
+
+		{
+			if tok != fflib.FFTok_string && tok != fflib.FFTok_null {
+				return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for string", tok))
+			}
+		}
+
+		if tok == fflib.FFTok_null {
+
+		} else {
+
+			outBuf := fs.Output.Bytes()
+
+			j.StatusText = string(string(outBuf))
+
+		}
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+handle_StatusEmoji:
+
+	/* handler: j.StatusEmoji type=string kind=string quoted=false*/
+
+	{
+
+		{
+			if tok != fflib.FFTok_string && tok != fflib.FFTok_null {
+				return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for string", tok))
+			}
+		}
+
+		if tok == fflib.FFTok_null {
+
+		} else {
+
+			outBuf := fs.Output.Bytes()
+
+			j.StatusEmoji = string(string(outBuf))
+
+		}
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+handle_StatusExpiration:
+
+	/* handler: j.StatusExpiration type=int kind=int quoted=false*/
+
+	{
+		if tok != fflib.FFTok_integer && tok != fflib.FFTok_null {
+			return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for int", tok))
+		}
+	}
+
+	{
+
+		if tok == fflib.FFTok_null {
+
+		} else {
+
+			tval, err := fflib.ParseInt(fs.Output.Bytes(), 10, 64)
+
+			if err != nil {
+				return fs.WrapErr(err)
+			}
+
+			j.StatusExpiration = int(tval)
+
+		}
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+handle_Title:
+
+	/* handler: j.Title type=string kind=string quoted=false*/
+
+	{
+
+		{
+			if tok != fflib.FFTok_string && tok != fflib.FFTok_null {
+				return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for string", tok))
+			}
+		}
+
+		if tok == fflib.FFTok_null {
+
+		} else {
+
+			outBuf := fs.Output.Bytes()
+
+			j.Title = string(string(outBuf))
+
+		}
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+handle_Phone:
+
+	/* handler: j.Phone type=string kind=string quoted=false*/
+
+	{
+
+		{
+			if tok != fflib.FFTok_string && tok != fflib.FFTok_null {
+				return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for string", tok))
+			}
+		}
+
+		if tok == fflib.FFTok_null {
+
+		} else {
+
+			outBuf := fs.Output.Bytes()
+
+			j.Phone = string(string(outBuf))
+
+		}
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+handle_RealName:
+
+	/* handler: j.RealName type=string kind=string quoted=false*/
+
+	{
+
+		{
+			if tok != fflib.FFTok_string && tok != fflib.FFTok_null {
+				return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for string", tok))
+			}
+		}
+
+		if tok == fflib.FFTok_null {
+
+		} else {
+
+			outBuf := fs.Output.Bytes()
+
+			j.RealName = string(string(outBuf))
+
+		}
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+handle_RealNameNormalized:
+
+	/* handler: j.RealNameNormalized type=string kind=string quoted=false*/
+
+	{
+
+		{
+			if tok != fflib.FFTok_string && tok != fflib.FFTok_null {
+				return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for string", tok))
+			}
+		}
+
+		if tok == fflib.FFTok_null {
+
+		} else {
+
+			outBuf := fs.Output.Bytes()
+
+			j.RealNameNormalized = string(string(outBuf))
+
+		}
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+handle_DisplayName:
+
+	/* handler: j.DisplayName type=string kind=string quoted=false*/
+
+	{
+
+		{
+			if tok != fflib.FFTok_string && tok != fflib.FFTok_null {
+				return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for string", tok))
+			}
+		}
+
+		if tok == fflib.FFTok_null {
+
+		} else {
+
+			outBuf := fs.Output.Bytes()
+
+			j.DisplayName = string(string(outBuf))
+
+		}
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+handle_DisplayNameNormalized:
+
+	/* handler: j.DisplayNameNormalized type=string kind=string quoted=false*/
+
+	{
+
+		{
+			if tok != fflib.FFTok_string && tok != fflib.FFTok_null {
+				return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for string", tok))
+			}
+		}
+
+		if tok == fflib.FFTok_null {
+
+		} else {
+
+			outBuf := fs.Output.Bytes()
+
+			j.DisplayNameNormalized = string(string(outBuf))
+
+		}
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+handle_FirstName:
+
+	/* handler: j.FirstName type=string kind=string quoted=false*/
+
+	{
+
+		{
+			if tok != fflib.FFTok_string && tok != fflib.FFTok_null {
+				return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for string", tok))
+			}
+		}
+
+		if tok == fflib.FFTok_null {
+
+		} else {
+
+			outBuf := fs.Output.Bytes()
+
+			j.FirstName = string(string(outBuf))
+
+		}
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+handle_LastName:
+
+	/* handler: j.LastName type=string kind=string quoted=false*/
+
+	{
+
+		{
+			if tok != fflib.FFTok_string && tok != fflib.FFTok_null {
+				return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for string", tok))
+			}
+		}
+
+		if tok == fflib.FFTok_null {
+
+		} else {
+
+			outBuf := fs.Output.Bytes()
+
+			j.LastName = string(string(outBuf))
+
+		}
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+handle_Email:
+
+	/* handler: j.Email type=string kind=string quoted=false*/
+
+	{
+
+		{
+			if tok != fflib.FFTok_string && tok != fflib.FFTok_null {
+				return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for string", tok))
+			}
+		}
+
+		if tok == fflib.FFTok_null {
+
+		} else {
+
+			outBuf := fs.Output.Bytes()
+
+			j.Email = string(string(outBuf))
+
+		}
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+handle_Image24:
+
+	/* handler: j.Image24 type=string kind=string quoted=false*/
+
+	{
+
+		{
+			if tok != fflib.FFTok_string && tok != fflib.FFTok_null {
+				return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for string", tok))
+			}
+		}
+
+		if tok == fflib.FFTok_null {
+
+		} else {
+
+			outBuf := fs.Output.Bytes()
+
+			j.Image24 = string(string(outBuf))
+
+		}
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+handle_Image32:
+
+	/* handler: j.Image32 type=string kind=string quoted=false*/
+
+	{
+
+		{
+			if tok != fflib.FFTok_string && tok != fflib.FFTok_null {
+				return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for string", tok))
+			}
+		}
+
+		if tok == fflib.FFTok_null {
+
+		} else {
+
+			outBuf := fs.Output.Bytes()
+
+			j.Image32 = string(string(outBuf))
+
+		}
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+handle_Image48:
+
+	/* handler: j.Image48 type=string kind=string quoted=false*/
+
+	{
+
+		{
+			if tok != fflib.FFTok_string && tok != fflib.FFTok_null {
+				return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for string", tok))
+			}
+		}
+
+		if tok == fflib.FFTok_null {
+
+		} else {
+
+			outBuf := fs.Output.Bytes()
+
+			j.Image48 = string(string(outBuf))
+
+		}
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+handle_Image72:
+
+	/* handler: j.Image72 type=string kind=string quoted=false*/
+
+	{
+
+		{
+			if tok != fflib.FFTok_string && tok != fflib.FFTok_null {
+				return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for string", tok))
+			}
+		}
+
+		if tok == fflib.FFTok_null {
+
+		} else {
+
+			outBuf := fs.Output.Bytes()
+
+			j.Image72 = string(string(outBuf))
+
+		}
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+handle_Image192:
+
+	/* handler: j.Image192 type=string kind=string quoted=false*/
+
+	{
+
+		{
+			if tok != fflib.FFTok_string && tok != fflib.FFTok_null {
+				return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for string", tok))
+			}
+		}
+
+		if tok == fflib.FFTok_null {
+
+		} else {
+
+			outBuf := fs.Output.Bytes()
+
+			j.Image192 = string(string(outBuf))
+
+		}
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+handle_Image512:
+
+	/* handler: j.Image512 type=string kind=string quoted=false*/
+
+	{
+
+		{
+			if tok != fflib.FFTok_string && tok != fflib.FFTok_null {
+				return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for string", tok))
+			}
+		}
+
+		if tok == fflib.FFTok_null {
+
+		} else {
+
+			outBuf := fs.Output.Bytes()
+
+			j.Image512 = string(string(outBuf))
+
+		}
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+handle_Team:
+
+	/* handler: j.Team type=string kind=string quoted=false*/
+
+	{
+
+		{
+			if tok != fflib.FFTok_string && tok != fflib.FFTok_null {
+				return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for string", tok))
+			}
+		}
+
+		if tok == fflib.FFTok_null {
+
+		} else {
+
+			outBuf := fs.Output.Bytes()
+
+			j.Team = string(string(outBuf))
+
+		}
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+handle_BotID:
+
+	/* handler: j.BotID type=string kind=string quoted=false*/
+
+	{
+
+		{
+			if tok != fflib.FFTok_string && tok != fflib.FFTok_null {
+				return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for string", tok))
+			}
+		}
+
+		if tok == fflib.FFTok_null {
+
+		} else {
+
+			outBuf := fs.Output.Bytes()
+
+			j.BotID = string(string(outBuf))
+
+		}
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+wantedvalue:
+	return fs.WrapErr(fmt.Errorf("wanted value token, but got token: %v", tok))
+wrongtokenerror:
+	return fs.WrapErr(fmt.Errorf("ffjson: wanted token: %v, but got token: %v output=%s", wantedTok, tok, fs.Output.String()))
+tokerror:
+	if fs.BigError != nil {
+		return fs.WrapErr(fs.BigError)
+	}
+	err = fs.Error.ToError()
+	if err != nil {
+		return fs.WrapErr(err)
+	}
+	panic("ffjson-generated: unreachable, please report bug.")
+done:
+
+	return nil
+}
+
+// MarshalJSON marshal bytes to json - template
+func (j *ResponseMetadata) MarshalJSON() ([]byte, error) {
+	var buf fflib.Buffer
+	if j == nil {
+		buf.WriteString("null")
+		return buf.Bytes(), nil
+	}
+	err := j.MarshalJSONBuf(&buf)
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// MarshalJSONBuf marshal buff to json - template
+func (j *ResponseMetadata) MarshalJSONBuf(buf fflib.EncodingBuffer) error {
+	if j == nil {
+		buf.WriteString("null")
+		return nil
+	}
+	var err error
+	var obj []byte
+	_ = obj
+	_ = err
+	buf.WriteString(`{"next_cursor":`)
+	fflib.WriteJsonString(buf, string(j.NextCursor))
+	buf.WriteByte('}')
+	return nil
+}
+
+const (
+	ffjtResponseMetadatabase = iota
+	ffjtResponseMetadatanosuchkey
+
+	ffjtResponseMetadataNextCursor
+)
+
+var ffjKeyResponseMetadataNextCursor = []byte("next_cursor")
+
+// UnmarshalJSON umarshall json - template of ffjson
+func (j *ResponseMetadata) UnmarshalJSON(input []byte) error {
+	fs := fflib.NewFFLexer(input)
+	return j.UnmarshalJSONFFLexer(fs, fflib.FFParse_map_start)
+}
+
+// UnmarshalJSONFFLexer fast json unmarshall - template ffjson
+func (j *ResponseMetadata) UnmarshalJSONFFLexer(fs *fflib.FFLexer, state fflib.FFParseState) error {
+	var err error
+	currentKey := ffjtResponseMetadatabase
+	_ = currentKey
+	tok := fflib.FFTok_init
+	wantedTok := fflib.FFTok_init
+
+mainparse:
+	for {
+		tok = fs.Scan()
+		//	println(fmt.Sprintf("debug: tok: %v  state: %v", tok, state))
+		if tok == fflib.FFTok_error {
+			goto tokerror
+		}
+
+		switch state {
+
+		case fflib.FFParse_map_start:
+			if tok != fflib.FFTok_left_bracket {
+				wantedTok = fflib.FFTok_left_bracket
+				goto wrongtokenerror
+			}
+			state = fflib.FFParse_want_key
+			continue
+
+		case fflib.FFParse_after_value:
+			if tok == fflib.FFTok_comma {
+				state = fflib.FFParse_want_key
+			} else if tok == fflib.FFTok_right_bracket {
+				goto done
+			} else {
+				wantedTok = fflib.FFTok_comma
+				goto wrongtokenerror
+			}
+
+		case fflib.FFParse_want_key:
+			// json {} ended. goto exit. woo.
+			if tok == fflib.FFTok_right_bracket {
+				goto done
+			}
+			if tok != fflib.FFTok_string {
+				wantedTok = fflib.FFTok_string
+				goto wrongtokenerror
+			}
+
+			kn := fs.Output.Bytes()
+			if len(kn) <= 0 {
+				// "" case. hrm.
+				currentKey = ffjtResponseMetadatanosuchkey
+				state = fflib.FFParse_want_colon
+				goto mainparse
+			} else {
+				switch kn[0] {
+
+				case 'n':
+
+					if bytes.Equal(ffjKeyResponseMetadataNextCursor, kn) {
+						currentKey = ffjtResponseMetadataNextCursor
+						state = fflib.FFParse_want_colon
+						goto mainparse
+					}
+
+				}
+
+				if fflib.EqualFoldRight(ffjKeyResponseMetadataNextCursor, kn) {
+					currentKey = ffjtResponseMetadataNextCursor
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
+				currentKey = ffjtResponseMetadatanosuchkey
+				state = fflib.FFParse_want_colon
+				goto mainparse
+			}
+
+		case fflib.FFParse_want_colon:
+			if tok != fflib.FFTok_colon {
+				wantedTok = fflib.FFTok_colon
+				goto wrongtokenerror
+			}
+			state = fflib.FFParse_want_value
+			continue
+		case fflib.FFParse_want_value:
+
+			if tok == fflib.FFTok_left_brace || tok == fflib.FFTok_left_bracket || tok == fflib.FFTok_integer || tok == fflib.FFTok_double || tok == fflib.FFTok_string || tok == fflib.FFTok_bool || tok == fflib.FFTok_null {
+				switch currentKey {
+
+				case ffjtResponseMetadataNextCursor:
+					goto handle_NextCursor
+
+				case ffjtResponseMetadatanosuchkey:
+					err = fs.SkipField(tok)
+					if err != nil {
+						return fs.WrapErr(err)
+					}
+					state = fflib.FFParse_after_value
+					goto mainparse
+				}
+			} else {
+				goto wantedvalue
+			}
+		}
+	}
+
+handle_NextCursor:
+
+	/* handler: j.NextCursor type=string kind=string quoted=false*/
+
+	{
+
+		{
+			if tok != fflib.FFTok_string && tok != fflib.FFTok_null {
+				return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for string", tok))
+			}
+		}
+
+		if tok == fflib.FFTok_null {
+
+		} else {
+
+			outBuf := fs.Output.Bytes()
+
+			j.NextCursor = string(string(outBuf))
+
+		}
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+wantedvalue:
+	return fs.WrapErr(fmt.Errorf("wanted value token, but got token: %v", tok))
+wrongtokenerror:
+	return fs.WrapErr(fmt.Errorf("ffjson: wanted token: %v, but got token: %v output=%s", wantedTok, tok, fs.Output.String()))
+tokerror:
+	if fs.BigError != nil {
+		return fs.WrapErr(fs.BigError)
+	}
+	err = fs.Error.ToError()
+	if err != nil {
+		return fs.WrapErr(err)
+	}
+	panic("ffjson-generated: unreachable, please report bug.")
+done:
+
+	return nil
+}
+
+// MarshalJSON marshal bytes to json - template
+func (j *User) MarshalJSON() ([]byte, error) {
+	var buf fflib.Buffer
+	if j == nil {
+		buf.WriteString("null")
+		return buf.Bytes(), nil
+	}
+	err := j.MarshalJSONBuf(&buf)
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// MarshalJSONBuf marshal buff to json - template
+func (j *User) MarshalJSONBuf(buf fflib.EncodingBuffer) error {
+	if j == nil {
+		buf.WriteString("null")
+		return nil
+	}
+	var err error
+	var obj []byte
+	_ = obj
+	_ = err
+	buf.WriteString(`{ "id":`)
+	fflib.WriteJsonString(buf, string(j.ID))
+	buf.WriteString(`,"team_id":`)
+	fflib.WriteJsonString(buf, string(j.TeamID))
+	buf.WriteString(`,"name":`)
+	fflib.WriteJsonString(buf, string(j.Name))
+	if j.Deleted {
+		buf.WriteString(`,"deleted":true`)
+	} else {
+		buf.WriteString(`,"deleted":false`)
+	}
+	buf.WriteString(`,"color":`)
+	fflib.WriteJsonString(buf, string(j.Color))
+	buf.WriteString(`,"real_name":`)
+	fflib.WriteJsonString(buf, string(j.RealName))
+	buf.WriteString(`,"tz":`)
+	fflib.WriteJsonString(buf, string(j.Tz))
+	buf.WriteString(`,"tz_label":`)
+	fflib.WriteJsonString(buf, string(j.TzLabel))
+	buf.WriteString(`,"tz_offset":`)
+	fflib.FormatBits2(buf, uint64(j.TzOffset), 10, j.TzOffset < 0)
+	if j.Profile != nil {
+		buf.WriteString(`,"profile":`)
+
+		{
+
+			err = j.Profile.MarshalJSONBuf(buf)
+			if err != nil {
+				return err
+			}
+
+		}
+	} else {
+		buf.WriteString(`,"profile":null`)
+	}
+	if j.IsAdmin {
+		buf.WriteString(`,"is_admin":true`)
+	} else {
+		buf.WriteString(`,"is_admin":false`)
+	}
+	if j.IsOwner {
+		buf.WriteString(`,"is_owner":true`)
+	} else {
+		buf.WriteString(`,"is_owner":false`)
+	}
+	if j.IsPrimaryOwner {
+		buf.WriteString(`,"is_primary_owner":true`)
+	} else {
+		buf.WriteString(`,"is_primary_owner":false`)
+	}
+	if j.IsRestricted {
+		buf.WriteString(`,"is_restricted":true`)
+	} else {
+		buf.WriteString(`,"is_restricted":false`)
+	}
+	if j.IsUltraRestricted {
+		buf.WriteString(`,"is_ultra_restricted":true`)
+	} else {
+		buf.WriteString(`,"is_ultra_restricted":false`)
+	}
+	if j.IsBot {
+		buf.WriteString(`,"is_bot":true`)
+	} else {
+		buf.WriteString(`,"is_bot":false`)
+	}
+	if j.IsAppUser {
+		buf.WriteString(`,"is_app_user":true`)
+	} else {
+		buf.WriteString(`,"is_app_user":false`)
+	}
+	buf.WriteString(`,"updated":`)
+	fflib.FormatBits2(buf, uint64(j.Updated), 10, j.Updated < 0)
+	buf.WriteByte(',')
+	if len(j.Locale) != 0 {
+		buf.WriteString(`"locale":`)
+		fflib.WriteJsonString(buf, string(j.Locale))
+		buf.WriteByte(',')
+	}
+	buf.Rewind(1)
+	buf.WriteByte('}')
+	return nil
+}
+
+const (
+	ffjtUserbase = iota
+	ffjtUsernosuchkey
+
+	ffjtUserID
+
+	ffjtUserTeamID
+
+	ffjtUserName
+
+	ffjtUserDeleted
+
+	ffjtUserColor
+
+	ffjtUserRealName
+
+	ffjtUserTz
+
+	ffjtUserTzLabel
+
+	ffjtUserTzOffset
+
+	ffjtUserProfile
+
+	ffjtUserIsAdmin
+
+	ffjtUserIsOwner
+
+	ffjtUserIsPrimaryOwner
+
+	ffjtUserIsRestricted
+
+	ffjtUserIsUltraRestricted
+
+	ffjtUserIsBot
+
+	ffjtUserIsAppUser
+
+	ffjtUserUpdated
+
+	ffjtUserLocale
+)
+
+var ffjKeyUserID = []byte("id")
+
+var ffjKeyUserTeamID = []byte("team_id")
+
+var ffjKeyUserName = []byte("name")
+
+var ffjKeyUserDeleted = []byte("deleted")
+
+var ffjKeyUserColor = []byte("color")
+
+var ffjKeyUserRealName = []byte("real_name")
+
+var ffjKeyUserTz = []byte("tz")
+
+var ffjKeyUserTzLabel = []byte("tz_label")
+
+var ffjKeyUserTzOffset = []byte("tz_offset")
+
+var ffjKeyUserProfile = []byte("profile")
+
+var ffjKeyUserIsAdmin = []byte("is_admin")
+
+var ffjKeyUserIsOwner = []byte("is_owner")
+
+var ffjKeyUserIsPrimaryOwner = []byte("is_primary_owner")
+
+var ffjKeyUserIsRestricted = []byte("is_restricted")
+
+var ffjKeyUserIsUltraRestricted = []byte("is_ultra_restricted")
+
+var ffjKeyUserIsBot = []byte("is_bot")
+
+var ffjKeyUserIsAppUser = []byte("is_app_user")
+
+var ffjKeyUserUpdated = []byte("updated")
+
+var ffjKeyUserLocale = []byte("locale")
+
+// UnmarshalJSON umarshall json - template of ffjson
+func (j *User) UnmarshalJSON(input []byte) error {
+	fs := fflib.NewFFLexer(input)
+	return j.UnmarshalJSONFFLexer(fs, fflib.FFParse_map_start)
+}
+
+// UnmarshalJSONFFLexer fast json unmarshall - template ffjson
+func (j *User) UnmarshalJSONFFLexer(fs *fflib.FFLexer, state fflib.FFParseState) error {
+	var err error
+	currentKey := ffjtUserbase
+	_ = currentKey
+	tok := fflib.FFTok_init
+	wantedTok := fflib.FFTok_init
+
+mainparse:
+	for {
+		tok = fs.Scan()
+		//	println(fmt.Sprintf("debug: tok: %v  state: %v", tok, state))
+		if tok == fflib.FFTok_error {
+			goto tokerror
+		}
+
+		switch state {
+
+		case fflib.FFParse_map_start:
+			if tok != fflib.FFTok_left_bracket {
+				wantedTok = fflib.FFTok_left_bracket
+				goto wrongtokenerror
+			}
+			state = fflib.FFParse_want_key
+			continue
+
+		case fflib.FFParse_after_value:
+			if tok == fflib.FFTok_comma {
+				state = fflib.FFParse_want_key
+			} else if tok == fflib.FFTok_right_bracket {
+				goto done
+			} else {
+				wantedTok = fflib.FFTok_comma
+				goto wrongtokenerror
+			}
+
+		case fflib.FFParse_want_key:
+			// json {} ended. goto exit. woo.
+			if tok == fflib.FFTok_right_bracket {
+				goto done
+			}
+			if tok != fflib.FFTok_string {
+				wantedTok = fflib.FFTok_string
+				goto wrongtokenerror
+			}
+
+			kn := fs.Output.Bytes()
+			if len(kn) <= 0 {
+				// "" case. hrm.
+				currentKey = ffjtUsernosuchkey
+				state = fflib.FFParse_want_colon
+				goto mainparse
+			} else {
+				switch kn[0] {
+
+				case 'c':
+
+					if bytes.Equal(ffjKeyUserColor, kn) {
+						currentKey = ffjtUserColor
+						state = fflib.FFParse_want_colon
+						goto mainparse
+					}
+
+				case 'd':
+
+					if bytes.Equal(ffjKeyUserDeleted, kn) {
+						currentKey = ffjtUserDeleted
+						state = fflib.FFParse_want_colon
+						goto mainparse
+					}
+
+				case 'i':
+
+					if bytes.Equal(ffjKeyUserID, kn) {
+						currentKey = ffjtUserID
+						state = fflib.FFParse_want_colon
+						goto mainparse
+
+					} else if bytes.Equal(ffjKeyUserIsAdmin, kn) {
+						currentKey = ffjtUserIsAdmin
+						state = fflib.FFParse_want_colon
+						goto mainparse
+
+					} else if bytes.Equal(ffjKeyUserIsOwner, kn) {
+						currentKey = ffjtUserIsOwner
+						state = fflib.FFParse_want_colon
+						goto mainparse
+
+					} else if bytes.Equal(ffjKeyUserIsPrimaryOwner, kn) {
+						currentKey = ffjtUserIsPrimaryOwner
+						state = fflib.FFParse_want_colon
+						goto mainparse
+
+					} else if bytes.Equal(ffjKeyUserIsRestricted, kn) {
+						currentKey = ffjtUserIsRestricted
+						state = fflib.FFParse_want_colon
+						goto mainparse
+
+					} else if bytes.Equal(ffjKeyUserIsUltraRestricted, kn) {
+						currentKey = ffjtUserIsUltraRestricted
+						state = fflib.FFParse_want_colon
+						goto mainparse
+
+					} else if bytes.Equal(ffjKeyUserIsBot, kn) {
+						currentKey = ffjtUserIsBot
+						state = fflib.FFParse_want_colon
+						goto mainparse
+
+					} else if bytes.Equal(ffjKeyUserIsAppUser, kn) {
+						currentKey = ffjtUserIsAppUser
+						state = fflib.FFParse_want_colon
+						goto mainparse
+					}
+
+				case 'l':
+
+					if bytes.Equal(ffjKeyUserLocale, kn) {
+						currentKey = ffjtUserLocale
+						state = fflib.FFParse_want_colon
+						goto mainparse
+					}
+
+				case 'n':
+
+					if bytes.Equal(ffjKeyUserName, kn) {
+						currentKey = ffjtUserName
+						state = fflib.FFParse_want_colon
+						goto mainparse
+					}
+
+				case 'p':
+
+					if bytes.Equal(ffjKeyUserProfile, kn) {
+						currentKey = ffjtUserProfile
+						state = fflib.FFParse_want_colon
+						goto mainparse
+					}
+
+				case 'r':
+
+					if bytes.Equal(ffjKeyUserRealName, kn) {
+						currentKey = ffjtUserRealName
+						state = fflib.FFParse_want_colon
+						goto mainparse
+					}
+
+				case 't':
+
+					if bytes.Equal(ffjKeyUserTeamID, kn) {
+						currentKey = ffjtUserTeamID
+						state = fflib.FFParse_want_colon
+						goto mainparse
+
+					} else if bytes.Equal(ffjKeyUserTz, kn) {
+						currentKey = ffjtUserTz
+						state = fflib.FFParse_want_colon
+						goto mainparse
+
+					} else if bytes.Equal(ffjKeyUserTzLabel, kn) {
+						currentKey = ffjtUserTzLabel
+						state = fflib.FFParse_want_colon
+						goto mainparse
+
+					} else if bytes.Equal(ffjKeyUserTzOffset, kn) {
+						currentKey = ffjtUserTzOffset
+						state = fflib.FFParse_want_colon
+						goto mainparse
+					}
+
+				case 'u':
+
+					if bytes.Equal(ffjKeyUserUpdated, kn) {
+						currentKey = ffjtUserUpdated
+						state = fflib.FFParse_want_colon
+						goto mainparse
+					}
+
+				}
+
+				if fflib.SimpleLetterEqualFold(ffjKeyUserLocale, kn) {
+					currentKey = ffjtUserLocale
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
+				if fflib.SimpleLetterEqualFold(ffjKeyUserUpdated, kn) {
+					currentKey = ffjtUserUpdated
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
+				if fflib.EqualFoldRight(ffjKeyUserIsAppUser, kn) {
+					currentKey = ffjtUserIsAppUser
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
+				if fflib.EqualFoldRight(ffjKeyUserIsBot, kn) {
+					currentKey = ffjtUserIsBot
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
+				if fflib.EqualFoldRight(ffjKeyUserIsUltraRestricted, kn) {
+					currentKey = ffjtUserIsUltraRestricted
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
+				if fflib.EqualFoldRight(ffjKeyUserIsRestricted, kn) {
+					currentKey = ffjtUserIsRestricted
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
+				if fflib.EqualFoldRight(ffjKeyUserIsPrimaryOwner, kn) {
+					currentKey = ffjtUserIsPrimaryOwner
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
+				if fflib.EqualFoldRight(ffjKeyUserIsOwner, kn) {
+					currentKey = ffjtUserIsOwner
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
+				if fflib.EqualFoldRight(ffjKeyUserIsAdmin, kn) {
+					currentKey = ffjtUserIsAdmin
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
+				if fflib.SimpleLetterEqualFold(ffjKeyUserProfile, kn) {
+					currentKey = ffjtUserProfile
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
+				if fflib.EqualFoldRight(ffjKeyUserTzOffset, kn) {
+					currentKey = ffjtUserTzOffset
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
+				if fflib.AsciiEqualFold(ffjKeyUserTzLabel, kn) {
+					currentKey = ffjtUserTzLabel
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
+				if fflib.SimpleLetterEqualFold(ffjKeyUserTz, kn) {
+					currentKey = ffjtUserTz
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
+				if fflib.AsciiEqualFold(ffjKeyUserRealName, kn) {
+					currentKey = ffjtUserRealName
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
+				if fflib.SimpleLetterEqualFold(ffjKeyUserColor, kn) {
+					currentKey = ffjtUserColor
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
+				if fflib.SimpleLetterEqualFold(ffjKeyUserDeleted, kn) {
+					currentKey = ffjtUserDeleted
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
+				if fflib.SimpleLetterEqualFold(ffjKeyUserName, kn) {
+					currentKey = ffjtUserName
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
+				if fflib.AsciiEqualFold(ffjKeyUserTeamID, kn) {
+					currentKey = ffjtUserTeamID
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
+				if fflib.SimpleLetterEqualFold(ffjKeyUserID, kn) {
+					currentKey = ffjtUserID
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
+				currentKey = ffjtUsernosuchkey
+				state = fflib.FFParse_want_colon
+				goto mainparse
+			}
+
+		case fflib.FFParse_want_colon:
+			if tok != fflib.FFTok_colon {
+				wantedTok = fflib.FFTok_colon
+				goto wrongtokenerror
+			}
+			state = fflib.FFParse_want_value
+			continue
+		case fflib.FFParse_want_value:
+
+			if tok == fflib.FFTok_left_brace || tok == fflib.FFTok_left_bracket || tok == fflib.FFTok_integer || tok == fflib.FFTok_double || tok == fflib.FFTok_string || tok == fflib.FFTok_bool || tok == fflib.FFTok_null {
+				switch currentKey {
+
+				case ffjtUserID:
+					goto handle_ID
+
+				case ffjtUserTeamID:
+					goto handle_TeamID
+
+				case ffjtUserName:
+					goto handle_Name
+
+				case ffjtUserDeleted:
+					goto handle_Deleted
+
+				case ffjtUserColor:
+					goto handle_Color
+
+				case ffjtUserRealName:
+					goto handle_RealName
+
+				case ffjtUserTz:
+					goto handle_Tz
+
+				case ffjtUserTzLabel:
+					goto handle_TzLabel
+
+				case ffjtUserTzOffset:
+					goto handle_TzOffset
+
+				case ffjtUserProfile:
+					goto handle_Profile
+
+				case ffjtUserIsAdmin:
+					goto handle_IsAdmin
+
+				case ffjtUserIsOwner:
+					goto handle_IsOwner
+
+				case ffjtUserIsPrimaryOwner:
+					goto handle_IsPrimaryOwner
+
+				case ffjtUserIsRestricted:
+					goto handle_IsRestricted
+
+				case ffjtUserIsUltraRestricted:
+					goto handle_IsUltraRestricted
+
+				case ffjtUserIsBot:
+					goto handle_IsBot
+
+				case ffjtUserIsAppUser:
+					goto handle_IsAppUser
+
+				case ffjtUserUpdated:
+					goto handle_Updated
+
+				case ffjtUserLocale:
+					goto handle_Locale
+
+				case ffjtUsernosuchkey:
+					err = fs.SkipField(tok)
+					if err != nil {
+						return fs.WrapErr(err)
+					}
+					state = fflib.FFParse_after_value
+					goto mainparse
+				}
+			} else {
+				goto wantedvalue
+			}
+		}
+	}
+
+handle_ID:
+
+	/* handler: j.ID type=string kind=string quoted=false*/
+
+	{
+
+		{
+			if tok != fflib.FFTok_string && tok != fflib.FFTok_null {
+				return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for string", tok))
+			}
+		}
+
+		if tok == fflib.FFTok_null {
+
+		} else {
+
+			outBuf := fs.Output.Bytes()
+
+			j.ID = string(string(outBuf))
+
+		}
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+handle_TeamID:
+
+	/* handler: j.TeamID type=string kind=string quoted=false*/
+
+	{
+
+		{
+			if tok != fflib.FFTok_string && tok != fflib.FFTok_null {
+				return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for string", tok))
+			}
+		}
+
+		if tok == fflib.FFTok_null {
+
+		} else {
+
+			outBuf := fs.Output.Bytes()
+
+			j.TeamID = string(string(outBuf))
+
+		}
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+handle_Name:
+
+	/* handler: j.Name type=string kind=string quoted=false*/
+
+	{
+
+		{
+			if tok != fflib.FFTok_string && tok != fflib.FFTok_null {
+				return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for string", tok))
+			}
+		}
+
+		if tok == fflib.FFTok_null {
+
+		} else {
+
+			outBuf := fs.Output.Bytes()
+
+			j.Name = string(string(outBuf))
+
+		}
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+handle_Deleted:
+
+	/* handler: j.Deleted type=bool kind=bool quoted=false*/
+
+	{
+		if tok != fflib.FFTok_bool && tok != fflib.FFTok_null {
+			return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for bool", tok))
+		}
+	}
+
+	{
+		if tok == fflib.FFTok_null {
+
+		} else {
+			tmpb := fs.Output.Bytes()
+
+			if bytes.Compare([]byte{'t', 'r', 'u', 'e'}, tmpb) == 0 {
+
+				j.Deleted = true
+
+			} else if bytes.Compare([]byte{'f', 'a', 'l', 's', 'e'}, tmpb) == 0 {
+
+				j.Deleted = false
+
+			} else {
+				err = errors.New("unexpected bytes for true/false value")
+				return fs.WrapErr(err)
+			}
+
+		}
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+handle_Color:
+
+	/* handler: j.Color type=string kind=string quoted=false*/
+
+	{
+
+		{
+			if tok != fflib.FFTok_string && tok != fflib.FFTok_null {
+				return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for string", tok))
+			}
+		}
+
+		if tok == fflib.FFTok_null {
+
+		} else {
+
+			outBuf := fs.Output.Bytes()
+
+			j.Color = string(string(outBuf))
+
+		}
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+handle_RealName:
+
+	/* handler: j.RealName type=string kind=string quoted=false*/
+
+	{
+
+		{
+			if tok != fflib.FFTok_string && tok != fflib.FFTok_null {
+				return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for string", tok))
+			}
+		}
+
+		if tok == fflib.FFTok_null {
+
+		} else {
+
+			outBuf := fs.Output.Bytes()
+
+			j.RealName = string(string(outBuf))
+
+		}
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+handle_Tz:
+
+	/* handler: j.Tz type=string kind=string quoted=false*/
+
+	{
+
+		{
+			if tok != fflib.FFTok_string && tok != fflib.FFTok_null {
+				return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for string", tok))
+			}
+		}
+
+		if tok == fflib.FFTok_null {
+
+		} else {
+
+			outBuf := fs.Output.Bytes()
+
+			j.Tz = string(string(outBuf))
+
+		}
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+handle_TzLabel:
+
+	/* handler: j.TzLabel type=string kind=string quoted=false*/
+
+	{
+
+		{
+			if tok != fflib.FFTok_string && tok != fflib.FFTok_null {
+				return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for string", tok))
+			}
+		}
+
+		if tok == fflib.FFTok_null {
+
+		} else {
+
+			outBuf := fs.Output.Bytes()
+
+			j.TzLabel = string(string(outBuf))
+
+		}
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+handle_TzOffset:
+
+	/* handler: j.TzOffset type=int kind=int quoted=false*/
+
+	{
+		if tok != fflib.FFTok_integer && tok != fflib.FFTok_null {
+			return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for int", tok))
+		}
+	}
+
+	{
+
+		if tok == fflib.FFTok_null {
+
+		} else {
+
+			tval, err := fflib.ParseInt(fs.Output.Bytes(), 10, 64)
+
+			if err != nil {
+				return fs.WrapErr(err)
+			}
+
+			j.TzOffset = int(tval)
+
+		}
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+handle_Profile:
+
+	/* handler: j.Profile type=users.Profile kind=struct quoted=false*/
+
+	{
+		if tok == fflib.FFTok_null {
+
+			j.Profile = nil
+
+		} else {
+
+			if j.Profile == nil {
+				j.Profile = new(Profile)
+			}
+
+			err = j.Profile.UnmarshalJSONFFLexer(fs, fflib.FFParse_want_key)
+			if err != nil {
+				return err
+			}
+		}
+		state = fflib.FFParse_after_value
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+handle_IsAdmin:
+
+	/* handler: j.IsAdmin type=bool kind=bool quoted=false*/
+
+	{
+		if tok != fflib.FFTok_bool && tok != fflib.FFTok_null {
+			return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for bool", tok))
+		}
+	}
+
+	{
+		if tok == fflib.FFTok_null {
+
+		} else {
+			tmpb := fs.Output.Bytes()
+
+			if bytes.Compare([]byte{'t', 'r', 'u', 'e'}, tmpb) == 0 {
+
+				j.IsAdmin = true
+
+			} else if bytes.Compare([]byte{'f', 'a', 'l', 's', 'e'}, tmpb) == 0 {
+
+				j.IsAdmin = false
+
+			} else {
+				err = errors.New("unexpected bytes for true/false value")
+				return fs.WrapErr(err)
+			}
+
+		}
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+handle_IsOwner:
+
+	/* handler: j.IsOwner type=bool kind=bool quoted=false*/
+
+	{
+		if tok != fflib.FFTok_bool && tok != fflib.FFTok_null {
+			return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for bool", tok))
+		}
+	}
+
+	{
+		if tok == fflib.FFTok_null {
+
+		} else {
+			tmpb := fs.Output.Bytes()
+
+			if bytes.Compare([]byte{'t', 'r', 'u', 'e'}, tmpb) == 0 {
+
+				j.IsOwner = true
+
+			} else if bytes.Compare([]byte{'f', 'a', 'l', 's', 'e'}, tmpb) == 0 {
+
+				j.IsOwner = false
+
+			} else {
+				err = errors.New("unexpected bytes for true/false value")
+				return fs.WrapErr(err)
+			}
+
+		}
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+handle_IsPrimaryOwner:
+
+	/* handler: j.IsPrimaryOwner type=bool kind=bool quoted=false*/
+
+	{
+		if tok != fflib.FFTok_bool && tok != fflib.FFTok_null {
+			return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for bool", tok))
+		}
+	}
+
+	{
+		if tok == fflib.FFTok_null {
+
+		} else {
+			tmpb := fs.Output.Bytes()
+
+			if bytes.Compare([]byte{'t', 'r', 'u', 'e'}, tmpb) == 0 {
+
+				j.IsPrimaryOwner = true
+
+			} else if bytes.Compare([]byte{'f', 'a', 'l', 's', 'e'}, tmpb) == 0 {
+
+				j.IsPrimaryOwner = false
+
+			} else {
+				err = errors.New("unexpected bytes for true/false value")
+				return fs.WrapErr(err)
+			}
+
+		}
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+handle_IsRestricted:
+
+	/* handler: j.IsRestricted type=bool kind=bool quoted=false*/
+
+	{
+		if tok != fflib.FFTok_bool && tok != fflib.FFTok_null {
+			return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for bool", tok))
+		}
+	}
+
+	{
+		if tok == fflib.FFTok_null {
+
+		} else {
+			tmpb := fs.Output.Bytes()
+
+			if bytes.Compare([]byte{'t', 'r', 'u', 'e'}, tmpb) == 0 {
+
+				j.IsRestricted = true
+
+			} else if bytes.Compare([]byte{'f', 'a', 'l', 's', 'e'}, tmpb) == 0 {
+
+				j.IsRestricted = false
+
+			} else {
+				err = errors.New("unexpected bytes for true/false value")
+				return fs.WrapErr(err)
+			}
+
+		}
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+handle_IsUltraRestricted:
+
+	/* handler: j.IsUltraRestricted type=bool kind=bool quoted=false*/
+
+	{
+		if tok != fflib.FFTok_bool && tok != fflib.FFTok_null {
+			return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for bool", tok))
+		}
+	}
+
+	{
+		if tok == fflib.FFTok_null {
+
+		} else {
+			tmpb := fs.Output.Bytes()
+
+			if bytes.Compare([]byte{'t', 'r', 'u', 'e'}, tmpb) == 0 {
+
+				j.IsUltraRestricted = true
+
+			} else if bytes.Compare([]byte{'f', 'a', 'l', 's', 'e'}, tmpb) == 0 {
+
+				j.IsUltraRestricted = false
+
+			} else {
+				err = errors.New("unexpected bytes for true/false value")
+				return fs.WrapErr(err)
+			}
+
+		}
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+handle_IsBot:
+
+	/* handler: j.IsBot type=bool kind=bool quoted=false*/
+
+	{
+		if tok != fflib.FFTok_bool && tok != fflib.FFTok_null {
+			return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for bool", tok))
+		}
+	}
+
+	{
+		if tok == fflib.FFTok_null {
+
+		} else {
+			tmpb := fs.Output.Bytes()
+
+			if bytes.Compare([]byte{'t', 'r', 'u', 'e'}, tmpb) == 0 {
+
+				j.IsBot = true
+
+			} else if bytes.Compare([]byte{'f', 'a', 'l', 's', 'e'}, tmpb) == 0 {
+
+				j.IsBot = false
+
+			} else {
+				err = errors.New("unexpected bytes for true/false value")
+				return fs.WrapErr(err)
+			}
+
+		}
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+handle_IsAppUser:
+
+	/* handler: j.IsAppUser type=bool kind=bool quoted=false*/
+
+	{
+		if tok != fflib.FFTok_bool && tok != fflib.FFTok_null {
+			return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for bool", tok))
+		}
+	}
+
+	{
+		if tok == fflib.FFTok_null {
+
+		} else {
+			tmpb := fs.Output.Bytes()
+
+			if bytes.Compare([]byte{'t', 'r', 'u', 'e'}, tmpb) == 0 {
+
+				j.IsAppUser = true
+
+			} else if bytes.Compare([]byte{'f', 'a', 'l', 's', 'e'}, tmpb) == 0 {
+
+				j.IsAppUser = false
+
+			} else {
+				err = errors.New("unexpected bytes for true/false value")
+				return fs.WrapErr(err)
+			}
+
+		}
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+handle_Updated:
+
+	/* handler: j.Updated type=int kind=int quoted=false*/
+
+	{
+		if tok != fflib.FFTok_integer && tok != fflib.FFTok_null {
+			return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for int", tok))
+		}
+	}
+
+	{
+
+		if tok == fflib.FFTok_null {
+
+		} else {
+
+			tval, err := fflib.ParseInt(fs.Output.Bytes(), 10, 64)
+
+			if err != nil {
+				return fs.WrapErr(err)
+			}
+
+			j.Updated = int(tval)
+
+		}
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+handle_Locale:
+
+	/* handler: j.Locale type=string kind=string quoted=false*/
+
+	{
+
+		{
+			if tok != fflib.FFTok_string && tok != fflib.FFTok_null {
+				return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for string", tok))
+			}
+		}
+
+		if tok == fflib.FFTok_null {
+
+		} else {
+
+			outBuf := fs.Output.Bytes()
+
+			j.Locale = string(string(outBuf))
+
+		}
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+wantedvalue:
+	return fs.WrapErr(fmt.Errorf("wanted value token, but got token: %v", tok))
+wrongtokenerror:
+	return fs.WrapErr(fmt.Errorf("ffjson: wanted token: %v, but got token: %v output=%s", wantedTok, tok, fs.Output.String()))
+tokerror:
+	if fs.BigError != nil {
+		return fs.WrapErr(fs.BigError)
+	}
+	err = fs.Error.ToError()
+	if err != nil {
+		return fs.WrapErr(err)
+	}
+	panic("ffjson-generated: unreachable, please report bug.")
+done:
+
+	return nil
+}
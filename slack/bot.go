@@ -1,33 +1,61 @@
 package slack
 
 import (
+	"io"
+	"strings"
+	"sync"
+
 	"github.com/pkg/errors"
 
 	"suy.io/bots/slack/api/chat"
+	"suy.io/bots/slack/api/conversations"
+	"suy.io/bots/slack/api/files"
 	"suy.io/bots/slack/api/oauth"
+	"suy.io/bots/slack/api/pins"
+	"suy.io/bots/slack/api/reactions"
 	"suy.io/bots/slack/api/rtm"
+	"suy.io/bots/slack/api/users"
+	"suy.io/bots/slack/connector"
 )
 
 // Bot represents a single slack bot unique to a slack team.
 type Bot struct {
-	id     string
-	teamID string
-	token  string
+	id           string
+	botID        string
+	key          string
+	teamID       string
+	enterpriseID string
+
+	mu            sync.Mutex
+	token         string
+	expiresAt     int64
+	userToken     string
+	userExpiresAt int64
+	tr            *tokenRefresher
 
 	c     Connector
 	convs map[string]*Conversation
 	cs    ConversationStore
+	us    UserStore
 }
 
 // newBot creates a new Bot from a given slack OAuth Response
-func newBot(p *oauth.AccessResponse, c Connector, convs map[string]*Conversation, cs ConversationStore) *Bot {
+func newBot(p *oauth.AccessResponse, c Connector, convs map[string]*Conversation, cs ConversationStore, us UserStore, tr *tokenRefresher) *Bot {
 	return &Bot{
-		teamID: p.TeamID,
-		token:  p.Bot.BotAccessToken,
-		id:     p.Bot.BotUserID,
-		c:      c,
-		convs:  convs,
-		cs:     cs,
+		key:           BotKey(p),
+		teamID:        p.TeamID,
+		enterpriseID:  p.EnterpriseID,
+		token:         p.Bot.BotAccessToken,
+		expiresAt:     p.Bot.ExpiresAt,
+		userToken:     p.AccessToken,
+		userExpiresAt: p.ExpiresAt,
+		id:            p.Bot.BotUserID,
+		botID:         p.Bot.BotID,
+		c:             c,
+		convs:         convs,
+		cs:            cs,
+		us:            us,
+		tr:            tr,
 	}
 }
 
@@ -41,19 +69,79 @@ func (bot *Bot) Team() string {
 	return bot.teamID
 }
 
+// Enterprise gets the ID of the Enterprise Grid org the bot is in, if any.
+func (bot *Bot) Enterprise() string {
+	return bot.enterpriseID
+}
+
 // Token gets the access token used to create the bot.
 func (bot *Bot) Token() string {
+	bot.mu.Lock()
+	defer bot.mu.Unlock()
+
 	return bot.token
 }
 
+// accessToken gets the token to make API calls with,
+// rotating it first if it is about to expire.
+//
+// If the rotation fails, the current token is returned
+// and the API call surfaces the error from slack.
+func (bot *Bot) accessToken() string {
+	bot.mu.Lock()
+	token, expiresAt := bot.token, bot.expiresAt
+	bot.mu.Unlock()
+
+	if bot.tr == nil || !expiring(expiresAt) {
+		return token
+	}
+
+	p, err := bot.tr.refresh(bot.key)
+	if err != nil {
+		return token
+	}
+
+	bot.setTokens(p)
+	return p.Bot.BotAccessToken
+}
+
+// UserToken gets the token of the user that installed the bot, if any,
+// rotating it first if it is about to expire.
+func (bot *Bot) UserToken() (string, error) {
+	bot.mu.Lock()
+	token, expiresAt := bot.userToken, bot.userExpiresAt
+	bot.mu.Unlock()
+
+	if bot.tr == nil || !expiring(expiresAt) {
+		return token, nil
+	}
+
+	p, err := bot.tr.refreshUser(bot.key)
+	if err != nil {
+		return "", errors.Wrap(err, "UserToken Failed")
+	}
+
+	bot.setTokens(p)
+	return p.AccessToken, nil
+}
+
+// setTokens updates the tokens of the bot after a rotation.
+func (bot *Bot) setTokens(p *oauth.AccessResponse) {
+	bot.mu.Lock()
+	defer bot.mu.Unlock()
+
+	bot.token, bot.expiresAt = p.Bot.BotAccessToken, p.Bot.ExpiresAt
+	bot.userToken, bot.userExpiresAt = p.AccessToken, p.ExpiresAt
+}
+
 // Start opens a WebSocket connection to slack to send and receive messages through this bot.
 func (bot *Bot) Start() error {
-	res, err := rtm.Connect(&rtm.ConnectRequest{Token: bot.token})
+	res, err := rtm.Connect(&rtm.ConnectRequest{Token: bot.accessToken()})
 	if err != nil {
 		return errors.Wrap(err, "Start Failed")
 	}
 
-	if err := bot.c.Add(bot.teamID, res.URL); err != nil {
+	if err := bot.c.Add(bot.key, res.URL); err != nil {
 		return errors.Wrap(err, "Start Failed")
 	}
 
@@ -62,7 +150,19 @@ func (bot *Bot) Start() error {
 
 // Typing sends a typing indicator to this bot's connector instance.
 func (bot *Bot) Typing(channel string) error {
-	return bot.c.Typing(bot.teamID, channel)
+	return bot.c.Typing(bot.key, channel)
+}
+
+// Send sends an RTM payload over this bot's socket, like a message or a presence subscription.
+//
+// The reply is set for payloads slack acknowledges, see connector.Connector.Send.
+func (bot *Bot) Send(payload []byte) (*connector.Reply, error) {
+	r, err := bot.c.Send(bot.key, payload)
+	if err != nil {
+		return r, errors.Wrap(err, "Send Failed")
+	}
+
+	return r, nil
 }
 
 // Say sends a message in a channel.
@@ -71,7 +171,7 @@ func (bot *Bot) Say(msg *chat.Message) (*chat.Message, error) {
 		return nil, ErrChannelUnset
 	}
 
-	res, err := chat.PostMessage(&chat.PostMessageRequest{Message: msg, Token: bot.token})
+	res, err := chat.PostMessage(&chat.PostMessageRequest{Message: msg, Token: bot.accessToken()})
 	if err != nil {
 		return nil, errors.Wrap(err, "Say Failed")
 	}
@@ -105,7 +205,7 @@ func (bot *Bot) SayEphemeral(msg *chat.EphemeralMessage) (string, error) {
 		return "", ErrChannelUnset
 	}
 
-	res, err := chat.PostEphemeral(&chat.PostEphemeralRequest{EphemeralMessage: msg, Token: bot.token})
+	res, err := chat.PostEphemeral(&chat.PostEphemeralRequest{EphemeralMessage: msg, Token: bot.accessToken()})
 	if err != nil {
 		return "", errors.Wrap(err, "SayEphemeral Failed")
 	}
@@ -152,7 +252,7 @@ func (bot *Bot) ReplyInThread(msg *rtm.Message, response *chat.Message) (*chat.M
 func (bot *Bot) Update(ts string, msg *chat.Message) (string, error) {
 	msg.Ts = ts
 
-	res, err := chat.Update(&chat.UpdateRequest{Message: msg, Token: bot.token})
+	res, err := chat.Update(&chat.UpdateRequest{Message: msg, Token: bot.accessToken()})
 	if err != nil {
 		return "", errors.Wrap(err, "Update Failed")
 	}
@@ -160,6 +260,218 @@ func (bot *Bot) Update(ts string, msg *chat.Message) (string, error) {
 	return res.Ts, nil
 }
 
+// Delete deletes a message the bot sent, bot tokens cannot delete the messages of users.
+func (bot *Bot) Delete(ts, channel string) error {
+	if channel == "" {
+		return ErrChannelUnset
+	}
+
+	if _, err := chat.Delete(&chat.DeleteRequest{Token: bot.accessToken(), Channel: channel, Ts: ts}); err != nil {
+		return errors.Wrap(err, "Delete Failed")
+	}
+
+	return nil
+}
+
+// Unfurl attaches previews for the links in a message, unfurls is a mapping
+// of the URLs to their previews.
+func (bot *Bot) Unfurl(channel, ts string, unfurls map[string]*chat.Attachment) error {
+	if channel == "" {
+		return ErrChannelUnset
+	}
+
+	if err := chat.Unfurl(&chat.UnfurlRequest{Token: bot.accessToken(), Channel: channel, Ts: ts, Unfurls: unfurls}); err != nil {
+		return errors.Wrap(err, "Unfurl Failed")
+	}
+
+	return nil
+}
+
+// React adds an emoji reaction to a message.
+func (bot *Bot) React(msg *rtm.Message, emoji string) error {
+	if msg.Channel == "" {
+		return ErrChannelUnset
+	}
+
+	if err := reactions.Add(&reactions.AddRequest{Token: bot.accessToken(), Name: emoji, Channel: msg.Channel, Timestamp: msg.Ts}); err != nil {
+		return errors.Wrap(err, "React Failed")
+	}
+
+	return nil
+}
+
+// RemoveReaction removes an emoji reaction added by the bot to a message.
+func (bot *Bot) RemoveReaction(msg *rtm.Message, emoji string) error {
+	if msg.Channel == "" {
+		return ErrChannelUnset
+	}
+
+	if err := reactions.Remove(&reactions.RemoveRequest{Token: bot.accessToken(), Name: emoji, Channel: msg.Channel, Timestamp: msg.Ts}); err != nil {
+		return errors.Wrap(err, "RemoveReaction Failed")
+	}
+
+	return nil
+}
+
+// Reactions gets all the reactions on a message.
+func (bot *Bot) Reactions(msg *rtm.Message) ([]*reactions.Reaction, error) {
+	if msg.Channel == "" {
+		return nil, ErrChannelUnset
+	}
+
+	res, err := reactions.Get(&reactions.GetRequest{Token: bot.accessToken(), Channel: msg.Channel, Timestamp: msg.Ts, Full: true})
+	if err != nil {
+		return nil, errors.Wrap(err, "Reactions Failed")
+	}
+
+	return res.Message.Reactions, nil
+}
+
+// Pin pins a message to its channel.
+func (bot *Bot) Pin(msg *rtm.Message) error {
+	if msg.Channel == "" {
+		return ErrChannelUnset
+	}
+
+	if err := pins.Add(&pins.AddRequest{Token: bot.accessToken(), Channel: msg.Channel, Timestamp: msg.Ts}); err != nil {
+		return errors.Wrap(err, "Pin Failed")
+	}
+
+	return nil
+}
+
+// Unpin removes a pinned message from its channel.
+func (bot *Bot) Unpin(msg *rtm.Message) error {
+	if msg.Channel == "" {
+		return ErrChannelUnset
+	}
+
+	if err := pins.Remove(&pins.RemoveRequest{Token: bot.accessToken(), Channel: msg.Channel, Timestamp: msg.Ts}); err != nil {
+		return errors.Wrap(err, "Unpin Failed")
+	}
+
+	return nil
+}
+
+// UploadFile uploads the contents of r as a file and shares it in the given channels.
+// opts can be used to set the filename, title, comment etc. and can be nil.
+func (bot *Bot) UploadFile(channels []string, r io.Reader, opts *files.UploadRequest) (*files.File, error) {
+	req := &files.UploadRequest{}
+	if opts != nil {
+		*req = *opts
+	}
+
+	req.Token = bot.accessToken()
+	req.Channels = strings.Join(channels, ",")
+	req.File = r
+
+	res, err := files.Upload(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "UploadFile Failed")
+	}
+
+	return res.File, nil
+}
+
+// FileInfo gets information about a file.
+func (bot *Bot) FileInfo(id string) (*files.File, error) {
+	res, err := files.Info(&files.InfoRequest{Token: bot.accessToken(), File: id})
+	if err != nil {
+		return nil, errors.Wrap(err, "FileInfo Failed")
+	}
+
+	return res.File, nil
+}
+
+// DownloadFile writes the contents of a file shared in the bot's team to w.
+func (bot *Bot) DownloadFile(f *files.File, w io.Writer) error {
+	url := f.URLPrivateDownload
+	if url == "" {
+		url = f.URLPrivate
+	}
+
+	if err := files.Download(url, bot.accessToken(), w); err != nil {
+		return errors.Wrap(err, "DownloadFile Failed")
+	}
+
+	return nil
+}
+
+// ThreadHistory gets all the messages in the thread of the passed message,
+// starting with the parent message.
+func (bot *Bot) ThreadHistory(msg *rtm.Message) ([]*conversations.Message, error) {
+	if msg.Channel == "" {
+		return nil, ErrChannelUnset
+	}
+
+	ts := msg.ThreadTs
+	if ts == "" {
+		ts = msg.Ts
+	}
+
+	var msgs []*conversations.Message
+	err := conversations.RepliesPages(&conversations.RepliesRequest{Token: bot.accessToken(), Channel: msg.Channel, Ts: ts}, func(res *conversations.RepliesResponse) bool {
+		msgs = append(msgs, res.Messages...)
+		return true
+	})
+
+	if err != nil {
+		return nil, errors.Wrap(err, "ThreadHistory Failed")
+	}
+
+	return msgs, nil
+}
+
+// ChannelInfo gets information about a channel.
+func (bot *Bot) ChannelInfo(channel string) (*conversations.Channel, error) {
+	res, err := conversations.Info(&conversations.InfoRequest{Token: bot.accessToken(), Channel: channel})
+	if err != nil {
+		return nil, errors.Wrap(err, "ChannelInfo Failed")
+	}
+
+	return res.Channel, nil
+}
+
+// ChannelMembers gets the IDs of all the users in a channel.
+func (bot *Bot) ChannelMembers(channel string) ([]string, error) {
+	var members []string
+	err := conversations.MembersPages(&conversations.MembersRequest{Token: bot.accessToken(), Channel: channel}, func(res *conversations.MembersResponse) bool {
+		members = append(members, res.Members...)
+		return true
+	})
+
+	if err != nil {
+		return nil, errors.Wrap(err, "ChannelMembers Failed")
+	}
+
+	return members, nil
+}
+
+// UserInfo gets the information for a user in the bot's team, using the cached
+// value if one exists.
+//
+// It is not named User, as the pairs sent by the Controller embed a Bot next to payloads with a User field.
+func (bot *Bot) UserInfo(id string) (*users.User, error) {
+	if bot.us != nil {
+		if u, err := bot.us.GetUser(bot.key, id); err == nil {
+			return u, nil
+		}
+	}
+
+	res, err := users.Info(&users.InfoRequest{Token: bot.accessToken(), User: id, IncludeLocale: true})
+	if err != nil {
+		return nil, errors.Wrap(err, "UserInfo Failed")
+	}
+
+	if bot.us != nil {
+		if err := bot.us.SetUser(bot.key, res.User); err != nil {
+			return nil, errors.Wrap(err, "UserInfo Failed")
+		}
+	}
+
+	return res.User, nil
+}
+
 // StartConversation starts the conversation with the given name for the given user
 // in the given channel.
 func (bot *Bot) StartConversation(user, channel, name string) error {
@@ -168,14 +480,16 @@ func (bot *Bot) StartConversation(user, channel, name string) error {
 		return ErrConversationNotFound
 	}
 
-	if bot.cs.IsActive(user, channel, bot.teamID) {
-		return ErrConversationAlreadyActive
+	// checking and starting in one step, so two messages can't both start a conversation
+	started, err := StartIfNotActive(bot.cs, user, channel, bot.teamID, name)
+	if err != nil {
+		return errors.Wrap(err, "Could not start")
 	}
 
-	if err := bot.cs.Start(user, channel, bot.teamID, name); err != nil {
-		errors.Wrap(err, "Could not start")
+	if !started {
+		return ErrConversationAlreadyActive
 	}
 
-	c.mp["start"](&chat.Message{Channel: channel}, &Controls{bot, user, channel})
+	c.mp["start"](&chat.Message{Channel: channel}, &Controls{bot, user, channel, 1})
 	return nil
 }
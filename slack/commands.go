@@ -23,6 +23,7 @@ const CommandImmediateResponseTimeout = 2000 * time.Millisecond
 type Command struct {
 	immediateResponse chan []byte
 	responded         byte
+	bot               *Bot
 
 	TeamID         string `json:"team_id"`
 	TeamDomain     string `json:"team_domain"`
@@ -59,6 +60,12 @@ func newCommand(vals url.Values) *Command {
 	return ans
 }
 
+// Bot gets the bot installed in the team the command was invoked in,
+// nil if the app has no bot there.
+func (command *Command) Bot() *Bot {
+	return command.bot
+}
+
 // commandResponse represents the payload sent as a response to a command.
 // ffjson: nodecoder
 type commandResponse struct {
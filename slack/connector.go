@@ -1,6 +1,8 @@
 package slack
 
 import (
+	"sync"
+
 	"suy.io/bots/slack/connector"
 )
 
@@ -10,12 +12,18 @@ type Connector interface {
 	// add a new team and socket url to manage
 	Add(team, url string) error
 
+	// stop managing a team, closing its socket
+	Remove(team string) error
+
 	// get incoming messages
 	Messages() <-chan *connector.MessagePayload
 
 	// send typing indicator for a team
 	Typing(team, channel string) error
 
+	// send an RTM payload for a team, returning the reply of slack for payloads it acknowledges
+	Send(team string, payload []byte) (*connector.Reply, error)
+
 	// close the connector
 	Close()
 }
@@ -25,6 +33,11 @@ type Connector interface {
 type internalConnector struct {
 	conn *connector.Connector
 	msgs chan *connector.MessagePayload
+
+	mu      sync.RWMutex
+	closed  bool
+	done    chan struct{}
+	pending sync.WaitGroup
 }
 
 // newInternalConnector creates a new internalConnector
@@ -32,6 +45,7 @@ func newInternalConnector() *internalConnector {
 	c := &internalConnector{
 		conn: connector.NewConnector(),
 		msgs: make(chan *connector.MessagePayload),
+		done: make(chan struct{}),
 	}
 
 	c.conn.SetMessageHandler(c.handleMessage)
@@ -43,20 +57,64 @@ func (c *internalConnector) Add(team, url string) error {
 	return c.conn.Open(team, url)
 }
 
+// Remove closes the connection for a team
+func (c *internalConnector) Remove(team string) error {
+	return c.conn.Close(team)
+}
+
 // handleMessage handles a message from a connection
 func (c *internalConnector) handleMessage(msg []byte, team string) {
-	go func() { c.msgs <- &connector.MessagePayload{Team: team, Message: msg} }()
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.closed {
+		return
+	}
+
+	msgs := c.msgs
+
+	c.pending.Add(1)
+	go func() {
+		defer c.pending.Done()
+
+		select {
+		case msgs <- &connector.MessagePayload{Team: team, Message: msg}:
+		case <-c.done:
+		}
+	}()
 }
 
 // Messages returns a receive only channel that gets messages.
 func (c *internalConnector) Messages() <-chan *connector.MessagePayload {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
 	return c.msgs
 }
 
-// Close closes the connector, and stops listening to messages.
+// Close closes the sockets of all teams, and stops listening to messages.
+// Messages that were not received yet are dropped.
 func (c *internalConnector) Close() {
+	c.mu.Lock()
+	if c.closed {
+		c.mu.Unlock()
+		return
+	}
+
+	c.closed = true
+	c.mu.Unlock()
+
+	for _, team := range c.conn.Teams() {
+		c.conn.Close(team)
+	}
+
+	close(c.done)
+	c.pending.Wait()
+
+	c.mu.Lock()
 	close(c.msgs)
 	c.msgs = nil
+	c.mu.Unlock()
 }
 
 // Typing sends a typing indicatior.
@@ -64,4 +122,9 @@ func (c *internalConnector) Typing(team, channel string) error {
 	return c.conn.Typing(team, channel)
 }
 
+// Send sends an RTM payload.
+func (c *internalConnector) Send(team string, payload []byte) (*connector.Reply, error) {
+	return c.conn.Send(team, payload)
+}
+
 var _ Connector = &internalConnector{}
@@ -0,0 +1,363 @@
+// Package cluster shards the teams of a connector over multiple instances.
+//
+// Every instance holds a lease in a LeaseStore, and teams are assigned to
+// instances with a consistent hash Ring over the instances with a live lease.
+// When an instance joins or leaves, the others pick up the change on their next
+// heartbeat, taking over the teams they gained and closing the ones they lost.
+//
+// Socket urls from rtm.connect can only be used once, so a node takes over a team
+// by asking the app for a new socket with the function set by WithTakeover.
+//
+// While teams move, an instance may keep a connection open for up to one heartbeat
+// after its new owner opened it, so the same message can be delivered twice.
+package cluster // import "suy.io/bots/slack/connector/cluster"
+
+import (
+	"log"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"suy.io/bots/slack/connector"
+)
+
+var (
+	ErrNotOwner        = errors.New("Team is owned by another instance")
+	ErrInvalidID       = errors.New("Invalid Instance ID")
+	ErrInvalidLeaseTTL = errors.New("Invalid Lease TTL")
+	ErrInvalidTakeover = errors.New("Invalid Takeover Function")
+)
+
+// DefaultLeaseTTL is the lease TTL of a Node, unless set with WithLeaseTTL.
+const DefaultLeaseTTL = 15 * time.Second
+
+// Node is an instance of a connector cluster, managing the connections
+// of the teams it owns.
+type Node struct {
+	id, addr string
+	ls       LeaseStore
+	conn     *connector.Connector
+	ttl      time.Duration
+	takeover func(team string) error
+
+	// mu serializes changes to the teams of the node
+	mu   sync.Mutex
+	ring *Ring
+
+	// pending holds the teams being taken over, with the time the takeover started
+	pending map[string]time.Time
+
+	stop, done chan struct{}
+}
+
+// NewNode creates a new Node, with an id unique in the cluster and the address
+// clients can reach it at.
+func NewNode(id, addr string, conn *connector.Connector, ls LeaseStore, options ...func(*Node) error) (*Node, error) {
+	if id == "" {
+		return nil, errors.Wrap(ErrInvalidID, "NewNode Failed")
+	}
+
+	n := &Node{
+		id:   id,
+		addr: addr,
+		ls:   ls,
+		conn: conn,
+		ttl:  DefaultLeaseTTL,
+		ring: NewRing(),
+
+		pending: make(map[string]time.Time),
+	}
+
+	for _, option := range options {
+		if err := option(n); err != nil {
+			return nil, errors.Wrap(err, "NewNode Failed")
+		}
+	}
+
+	return n, nil
+}
+
+// WithLeaseTTL sets the TTL of the lease of a Node, which is renewed every third of it.
+func WithLeaseTTL(ttl time.Duration) func(*Node) error {
+	return func(n *Node) error {
+		if ttl <= 0 {
+			return ErrInvalidLeaseTTL
+		}
+
+		n.ttl = ttl
+		return nil
+	}
+}
+
+// WithTakeover sets the function called for every team a Node gains from another instance,
+// which should get the app to add the team again with a fresh socket url, as the url stored for it
+// has already been used.
+//
+// It is called outside of the lock of the node, so it can add the team to the node from another goroutine.
+// A team is taken over again only if the function failed, or the team was not added within a lease TTL.
+// Without it, the node dials the stored url, which only works for urls slack sent with reconnect_url.
+func WithTakeover(f func(team string) error) func(*Node) error {
+	return func(n *Node) error {
+		if f == nil {
+			return ErrInvalidTakeover
+		}
+
+		n.takeover = f
+		return nil
+	}
+}
+
+// ID returns the id of the node.
+func (n *Node) ID() string {
+	return n.id
+}
+
+// Owner returns the id of the instance owning a team, as of the last rebalance.
+func (n *Node) Owner(team string) string {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	return n.ring.Owner(team)
+}
+
+// Start joins the cluster, takes over the teams the node owns,
+// and keeps its lease and teams up to date until Stop is called.
+func (n *Node) Start() error {
+	if err := n.ls.Join(n.id, n.addr, n.ttl); err != nil {
+		return errors.Wrap(err, "Start Failed")
+	}
+
+	if err := n.Rebalance(); err != nil {
+		return errors.Wrap(err, "Start Failed")
+	}
+
+	n.stop, n.done = make(chan struct{}), make(chan struct{})
+	go n.heartbeat(n.stop, n.done)
+
+	return nil
+}
+
+func (n *Node) heartbeat(stop, done chan struct{}) {
+	defer close(done)
+
+	t := time.NewTicker(n.ttl / 3)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-t.C:
+		}
+
+		if err := n.ls.Join(n.id, n.addr, n.ttl); err != nil {
+			log.Println(errors.Wrap(err, "Could not renew lease"))
+			continue
+		}
+
+		if err := n.Rebalance(); err != nil {
+			log.Println(err)
+		}
+	}
+}
+
+// Stop leaves the cluster, closing the connections of all its teams
+// so the remaining instances can take them over.
+func (n *Node) Stop() error {
+	if n.stop != nil {
+		close(n.stop)
+		<-n.done
+		n.stop = nil
+	}
+
+	n.mu.Lock()
+	for _, team := range n.conn.Teams() {
+		n.release(team)
+	}
+
+	n.ring = NewRing()
+	n.pending = make(map[string]time.Time)
+	n.mu.Unlock()
+
+	if err := n.ls.Leave(n.id); err != nil {
+		return errors.Wrap(err, "Stop Failed")
+	}
+
+	return nil
+}
+
+// Rebalance reads the members of the cluster, taking over the teams the node
+// now owns and closing the ones it no longer does.
+func (n *Node) Rebalance() error {
+	gained, err := n.rebalance()
+	if err != nil {
+		return errors.Wrap(err, "Rebalance Failed")
+	}
+
+	for _, team := range gained {
+		if err := n.takeover(team); err != nil {
+			log.Println(errors.Wrapf(err, "Could not take over team %s", team))
+
+			n.mu.Lock()
+			delete(n.pending, team)
+			n.mu.Unlock()
+		}
+	}
+
+	return nil
+}
+
+// rebalance updates the teams of the node, returning the teams gained that have to be taken over.
+func (n *Node) rebalance() ([]string, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if err := n.updateRing(); err != nil {
+		return nil, err
+	}
+
+	teams, err := n.ls.Teams()
+	if err != nil {
+		return nil, err
+	}
+
+	open := make(map[string]bool)
+	for _, team := range n.conn.Teams() {
+		open[team] = true
+
+		// removed by another instance, while this one thought it owned it
+		if _, ok := teams[team]; !ok {
+			n.conn.Close(team)
+		}
+	}
+
+	var gained []string
+	for team, url := range teams {
+		owned := n.ring.Owner(team) == n.id
+
+		if !owned || open[team] {
+			delete(n.pending, team)
+		}
+
+		switch {
+		case owned && !open[team] && n.takeover != nil:
+			// the previous takeover may still be adding the team
+			if start, ok := n.pending[team]; ok && time.Since(start) < n.ttl {
+				continue
+			}
+
+			n.pending[team] = time.Now()
+			gained = append(gained, team)
+		case owned && !open[team]:
+			if err := n.conn.Open(team, url); err != nil {
+				log.Println(errors.Wrapf(err, "Could not take over team %s", team))
+			}
+		case owned:
+			// keep the url slack last sent, for whoever owns the team next
+			if u, err := n.conn.URL(team); err == nil && u != url {
+				n.ls.SetTeam(team, u)
+			}
+		case open[team]:
+			n.release(team)
+		}
+	}
+
+	for team := range n.pending {
+		if _, ok := teams[team]; !ok {
+			delete(n.pending, team)
+		}
+	}
+
+	return gained, nil
+}
+
+// updateRing rebuilds the ring from the current members of the cluster.
+func (n *Node) updateRing() error {
+	members, err := n.ls.Members()
+	if err != nil {
+		return err
+	}
+
+	ids := make([]string, 0, len(members))
+	for id := range members {
+		ids = append(ids, id)
+	}
+
+	n.ring = NewRing(ids...)
+	return nil
+}
+
+// owns checks if the node owns a team. Clients can see an instance join or leave
+// before the node does, so the ring is updated before giving up on the team.
+func (n *Node) owns(team string) (bool, error) {
+	if n.ring.Owner(team) == n.id {
+		return true, nil
+	}
+
+	if err := n.updateRing(); err != nil {
+		return false, err
+	}
+
+	return n.ring.Owner(team) == n.id, nil
+}
+
+// release closes the connection of a team, saving its url for the next owner.
+func (n *Node) release(team string) {
+	if url, err := n.conn.URL(team); err == nil {
+		if err := n.ls.SetTeam(team, url); err != nil {
+			log.Println(errors.Wrapf(err, "Could not save url of team %s", team))
+		}
+	}
+
+	n.conn.Close(team)
+}
+
+// Add opens a connection for a team, if the node owns it.
+func (n *Node) Add(team, url string) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	ok, err := n.owns(team)
+	if err != nil {
+		return errors.Wrap(err, "Add Failed")
+	}
+
+	if !ok {
+		return ErrNotOwner
+	}
+
+	// a team added again keeps its record when the new url fails
+	if err := n.conn.Open(team, url); err != nil {
+		return errors.Wrap(err, "Add Failed")
+	}
+
+	// rebalancing closes teams missing from the store
+	if err := n.ls.SetTeam(team, url); err != nil {
+		n.conn.Close(team)
+		return errors.Wrap(err, "Add Failed")
+	}
+
+	return nil
+}
+
+// Remove closes the connection for a team, if the node owns it.
+func (n *Node) Remove(team string) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	ok, err := n.owns(team)
+	if err != nil {
+		return errors.Wrap(err, "Remove Failed")
+	}
+
+	if !ok {
+		return ErrNotOwner
+	}
+
+	if err := n.ls.RemoveTeam(team); err != nil {
+		return errors.Wrap(err, "Remove Failed")
+	}
+
+	return n.conn.Close(team)
+}
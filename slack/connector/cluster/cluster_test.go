@@ -0,0 +1,237 @@
+package cluster
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/pkg/errors"
+
+	"suy.io/bots/slack/connector"
+)
+
+func TestNewNode(t *testing.T) {
+	ls := NewMemoryLeaseStore()
+
+	tests := []struct {
+		name    string
+		id      string
+		options []func(*Node) error
+		wantErr error
+	}{
+		{"", "", nil, ErrInvalidID},
+		{"", "a", []func(*Node) error{WithLeaseTTL(0)}, ErrInvalidLeaseTTL},
+		{"", "a", []func(*Node) error{WithLeaseTTL(time.Second)}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewNode(tt.id, "", connector.NewConnector(), ls, tt.options...)
+			if errors.Cause(err) != tt.wantErr {
+				t.Errorf("NewNode() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func sortedTeams(c *connector.Connector) []string {
+	teams := c.Teams()
+	sort.Strings(teams)
+	return teams
+}
+
+func TestNode(t *testing.T) {
+	u := websocket.Upgrader{}
+	s := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		if _, err := u.Upgrade(res, req, nil); err != nil {
+			t.Fatal(err)
+		}
+	}))
+
+	defer s.Close()
+	url := strings.Replace(s.URL, "http", "ws", 1)
+
+	ls := NewMemoryLeaseStore()
+	ca, cb := connector.NewConnector(), connector.NewConnector()
+
+	a, _ := NewNode("a", "http://a", ca, ls)
+	b, _ := NewNode("b", "http://b", cb, ls)
+
+	if err := a.Start(); err != nil {
+		t.Fatal(err)
+	}
+
+	defer a.Stop()
+
+	if err := b.Start(); err != nil {
+		t.Fatal(err)
+	}
+
+	// what the heartbeat of a would do
+	a.Rebalance()
+
+	var teams []string
+	for i := 0; i < 20; i++ {
+		team := "T" + strconv.Itoa(i)
+		teams = append(teams, team)
+
+		owner, other := a, b
+		if a.Owner(team) != "a" {
+			owner, other = b, a
+		}
+
+		if err := other.Add(team, url); err != ErrNotOwner {
+			t.Errorf("Node.Add() of a team owned by another node error = %v, want %v", err, ErrNotOwner)
+		}
+
+		if err := owner.Add(team, url); err != nil {
+			t.Fatalf("Node.Add() error = %v", err)
+		}
+	}
+
+	if n := len(ca.Teams()) + len(cb.Teams()); n != len(teams) || len(ca.Teams()) == 0 || len(cb.Teams()) == 0 {
+		t.Errorf("teams split as %v and %v", ca.Teams(), cb.Teams())
+	}
+
+	// a leaving node gives all its teams to the others
+	if err := b.Stop(); err != nil {
+		t.Fatal(err)
+	}
+
+	if n := len(cb.Teams()); n != 0 {
+		t.Errorf("stopped node kept %v teams", n)
+	}
+
+	a.Rebalance()
+
+	sort.Strings(teams)
+	if got := sortedTeams(ca); strings.Join(got, ",") != strings.Join(teams, ",") {
+		t.Errorf("teams after rebalance = %v, want %v", got, teams)
+	}
+
+	if err := a.Remove("T0"); err != nil {
+		t.Errorf("Node.Remove() error = %v", err)
+	}
+
+	if got, _ := ls.Teams(); len(got) != len(teams)-1 {
+		t.Errorf("Node.Remove() left %v teams in the store, want %v", len(got), len(teams)-1)
+	}
+}
+
+func TestNode_takeover(t *testing.T) {
+	u := websocket.Upgrader{}
+	s := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		conn, err := u.Upgrade(res, req, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}))
+
+	defer s.Close()
+	url := strings.Replace(s.URL, "http", "ws", 1)
+
+	ls := NewMemoryLeaseStore()
+	ca, cb := connector.NewConnector(), connector.NewConnector()
+
+	var taken []string
+	var takeoverErr error
+	a, _ := NewNode("a", "http://a", ca, ls, WithTakeover(func(team string) error {
+		taken = append(taken, team)
+		return takeoverErr
+	}))
+
+	b, _ := NewNode("b", "http://b", cb, ls)
+
+	if err := WithTakeover(nil)(a); err != ErrInvalidTakeover {
+		t.Errorf("WithTakeover(nil) error = %v, want %v", err, ErrInvalidTakeover)
+	}
+
+	a.Start()
+	defer a.Stop()
+
+	b.Start()
+	a.Rebalance()
+
+	team := ""
+	for i := 0; team == ""; i++ {
+		if t := "T" + strconv.Itoa(i); b.Owner(t) == "b" {
+			team = t
+		}
+	}
+
+	if err := b.Add(team, url); err != nil {
+		t.Fatalf("Node.Add() error = %v", err)
+	}
+
+	// adding again with a url that fails keeps the team and its connection
+	if err := b.Add(team, "ws://127.0.0.1:1"); err == nil {
+		t.Errorf("Node.Add() with a bad url error = nil, want error")
+	}
+
+	if got, _ := ls.Teams(); got[team] != url {
+		t.Errorf("Node.Add() with a bad url stored %v, want %v", got[team], url)
+	}
+
+	if got := cb.Teams(); len(got) != 1 {
+		t.Errorf("Node.Add() with a bad url left teams %v, want %v", got, team)
+	}
+
+	b.Stop()
+
+	// the used url is not dialed, the app is asked for a new one
+	if err := a.Rebalance(); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(taken) != 1 || taken[0] != team {
+		t.Errorf("Node.Rebalance() took over %v, want %v", taken, []string{team})
+	}
+
+	if got := ca.Teams(); len(got) != 0 {
+		t.Errorf("Node.Rebalance() opened %v, want none", got)
+	}
+
+	// a pending takeover is not started again
+	a.Rebalance()
+	if len(taken) != 1 {
+		t.Errorf("Node.Rebalance() with a pending takeover took over %v, want %v", taken, []string{team})
+	}
+
+	// a timed out takeover is retried
+	a.mu.Lock()
+	a.pending[team] = time.Now().Add(-a.ttl)
+	a.mu.Unlock()
+
+	takeoverErr = errors.New("takeover failed")
+	a.Rebalance()
+	if len(taken) != 2 {
+		t.Errorf("Node.Rebalance() with a timed out takeover took over %v times, want 2", len(taken))
+	}
+
+	// a failed takeover is retried
+	takeoverErr = nil
+	a.Rebalance()
+	if len(taken) != 3 {
+		t.Errorf("Node.Rebalance() with a failed takeover took over %v times, want 3", len(taken))
+	}
+
+	if err := a.Add(team, url); err != nil {
+		t.Errorf("Node.Add() of a team taken over error = %v", err)
+	}
+
+	a.Rebalance()
+	if len(taken) != 3 || len(a.pending) != 0 {
+		t.Errorf("Node.Rebalance() of a team taken over took over %v times with %v pending, want 3 and none", len(taken), a.pending)
+	}
+}
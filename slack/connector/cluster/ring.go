@@ -0,0 +1,57 @@
+package cluster
+
+import (
+	"hash/crc32"
+	"sort"
+	"strconv"
+)
+
+// replicas is the number of points every member has on a Ring,
+// spreading teams evenly even with a few members.
+const replicas = 64
+
+// Ring is a consistent hash ring, assigning teams to members so that
+// a member joining or leaving only moves the teams it gains or loses.
+type Ring struct {
+	hashes []uint32
+	owners map[uint32]string
+}
+
+// NewRing creates a Ring of members.
+func NewRing(members ...string) *Ring {
+	r := &Ring{owners: make(map[uint32]string)}
+
+	// sorted, so that every instance resolves collisions the same way
+	members = append([]string(nil), members...)
+	sort.Strings(members)
+
+	for _, m := range members {
+		for i := 0; i < replicas; i++ {
+			h := crc32.ChecksumIEEE([]byte(m + "#" + strconv.Itoa(i)))
+			if _, ok := r.owners[h]; ok {
+				continue
+			}
+
+			r.owners[h] = m
+			r.hashes = append(r.hashes, h)
+		}
+	}
+
+	sort.Slice(r.hashes, func(i, j int) bool { return r.hashes[i] < r.hashes[j] })
+	return r
+}
+
+// Owner returns the member owning a team, or an empty string for an empty Ring.
+func (r *Ring) Owner(team string) string {
+	if len(r.hashes) == 0 {
+		return ""
+	}
+
+	h := crc32.ChecksumIEEE([]byte(team))
+	i := sort.Search(len(r.hashes), func(i int) bool { return r.hashes[i] >= h })
+	if i == len(r.hashes) {
+		i = 0
+	}
+
+	return r.owners[r.hashes[i]]
+}
@@ -0,0 +1,42 @@
+package cluster
+
+import (
+	"strconv"
+	"testing"
+)
+
+func TestRing_Owner(t *testing.T) {
+	if got := NewRing().Owner("T1"); got != "" {
+		t.Errorf("Ring.Owner() of an empty ring = %v, want none", got)
+	}
+
+	teams := make([]string, 1000)
+	for i := range teams {
+		teams[i] = "T" + strconv.Itoa(i)
+	}
+
+	r := NewRing("a", "b", "c")
+	counts := make(map[string]int)
+	for _, team := range teams {
+		counts[r.Owner(team)]++
+	}
+
+	for _, m := range []string{"a", "b", "c"} {
+		if counts[m] < len(teams)/6 {
+			t.Errorf("Ring.Owner() gave %v %v of %v teams", m, counts[m], len(teams))
+		}
+	}
+
+	// the order of members does not matter
+	if r2 := NewRing("c", "a", "b"); r2.Owner(teams[0]) != r.Owner(teams[0]) {
+		t.Errorf("Ring.Owner() depends on the order of members")
+	}
+
+	// a new member only takes teams, it never moves them between the others
+	r2 := NewRing("a", "b", "c", "d")
+	for _, team := range teams {
+		if got, was := r2.Owner(team), r.Owner(team); got != was && got != "d" {
+			t.Errorf("Ring.Owner(%v) moved from %v to %v", team, was, got)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package cluster
+
+import (
+	"sync"
+	"time"
+)
+
+// LeaseStore keeps the members of a connector cluster and the teams they share.
+//
+// Members hold a lease that expires unless renewed, so instances that die
+// without leaving are dropped from the cluster.
+type LeaseStore interface {
+	// Join adds an instance reachable at addr, or renews its lease, for ttl.
+	Join(id, addr string, ttl time.Duration) error
+
+	// Leave removes an instance.
+	Leave(id string) error
+
+	// Members gets the addresses of all instances with a live lease, by id.
+	Members() (map[string]string, error)
+
+	// SetTeam adds a team or updates its socket url.
+	SetTeam(team, url string) error
+
+	// RemoveTeam removes a team.
+	RemoveTeam(team string) error
+
+	// Teams gets the socket urls of all teams, by team.
+	Teams() (map[string]string, error)
+}
+
+type lease struct {
+	addr    string
+	expires time.Time
+}
+
+// MemoryLeaseStore is an in-memory LeaseStore, for instances sharing a process.
+type MemoryLeaseStore struct {
+	mu     sync.RWMutex
+	leases map[string]*lease
+	teams  map[string]string
+}
+
+// NewMemoryLeaseStore creates a new MemoryLeaseStore.
+func NewMemoryLeaseStore() *MemoryLeaseStore {
+	return &MemoryLeaseStore{
+		leases: make(map[string]*lease),
+		teams:  make(map[string]string),
+	}
+}
+
+func (ls *MemoryLeaseStore) Join(id, addr string, ttl time.Duration) error {
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+
+	ls.leases[id] = &lease{addr, time.Now().Add(ttl)}
+	return nil
+}
+
+func (ls *MemoryLeaseStore) Leave(id string) error {
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+
+	delete(ls.leases, id)
+	return nil
+}
+
+func (ls *MemoryLeaseStore) Members() (map[string]string, error) {
+	ls.mu.RLock()
+	defer ls.mu.RUnlock()
+
+	now := time.Now()
+	members := make(map[string]string, len(ls.leases))
+	for id, l := range ls.leases {
+		if l.expires.After(now) {
+			members[id] = l.addr
+		}
+	}
+
+	return members, nil
+}
+
+func (ls *MemoryLeaseStore) SetTeam(team, url string) error {
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+
+	ls.teams[team] = url
+	return nil
+}
+
+func (ls *MemoryLeaseStore) RemoveTeam(team string) error {
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+
+	delete(ls.teams, team)
+	return nil
+}
+
+func (ls *MemoryLeaseStore) Teams() (map[string]string, error) {
+	ls.mu.RLock()
+	defer ls.mu.RUnlock()
+
+	teams := make(map[string]string, len(ls.teams))
+	for team, url := range ls.teams {
+		teams[team] = url
+	}
+
+	return teams, nil
+}
+
+var _ LeaseStore = &MemoryLeaseStore{}
@@ -0,0 +1,36 @@
+package cluster
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestMemoryLeaseStore(t *testing.T) {
+	ls := NewMemoryLeaseStore()
+
+	ls.Join("a", "http://a", time.Minute)
+	ls.Join("b", "http://b", time.Minute)
+	ls.Join("c", "http://c", -time.Second)
+
+	if got, _ := ls.Members(); !reflect.DeepEqual(got, map[string]string{"a": "http://a", "b": "http://b"}) {
+		t.Errorf("MemoryLeaseStore.Members() = %v, want a and b", got)
+	}
+
+	// renewing an expired lease brings an instance back
+	ls.Join("c", "http://c", time.Minute)
+	ls.Leave("a")
+
+	if got, _ := ls.Members(); !reflect.DeepEqual(got, map[string]string{"b": "http://b", "c": "http://c"}) {
+		t.Errorf("MemoryLeaseStore.Members() = %v, want b and c", got)
+	}
+
+	ls.SetTeam("T1", "wss://1")
+	ls.SetTeam("T2", "wss://2")
+	ls.SetTeam("T1", "wss://3")
+	ls.RemoveTeam("T2")
+
+	if got, _ := ls.Teams(); !reflect.DeepEqual(got, map[string]string{"T1": "wss://3"}) {
+		t.Errorf("MemoryLeaseStore.Teams() = %v, want T1", got)
+	}
+}
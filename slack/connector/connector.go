@@ -1,14 +1,30 @@
 package connector // import "suy.io/bots/slack/connector"
 
 import (
+	"bytes"
 	"encoding/json"
 	"log"
+	"strconv"
+	"sync"
+	"time"
 
 	"github.com/gorilla/websocket"
 	"github.com/pkg/errors"
 )
 
 var ErrBotNotFound = errors.New("No Such registered team")
+var ErrInvalidPayload = errors.New("Invalid Payload, it needs to be an object with a type")
+var ErrReplyTimeout = errors.New("No reply from slack")
+var ErrConnectionClosed = errors.New("Connection closed before slack replied")
+
+// ReplyTimeout is how long Send waits for slack to acknowledge a payload.
+const ReplyTimeout = 10 * time.Second
+
+// repliedTypes are the types of payloads slack acknowledges with a reply_to.
+var repliedTypes = map[string]bool{
+	"message": true,
+	"ping":    true,
+}
 
 // MessageHandler is a callback to attach that is invoked whenever a new message is received
 type MessageHandler func(msg []byte, team string)
@@ -17,12 +33,80 @@ type MessageHandler func(msg []byte, team string)
 type connection struct {
 	conn *websocket.Conn
 	url  string
+
+	// wmu serializes writes, a websocket only allows one writer at a time
+	wmu sync.Mutex
+
+	mu      sync.Mutex
+	id      int
+	pending map[int]chan *Reply
+}
+
+func newConnection(conn *websocket.Conn, url string) *connection {
+	return &connection{conn: conn, url: url, pending: make(map[int]chan *Reply)}
+}
+
+// next gets the id for the next payload, and a channel for its reply if wait is true.
+func (co *connection) next(wait bool) (int, chan *Reply) {
+	co.mu.Lock()
+	defer co.mu.Unlock()
+
+	co.id++
+	if !wait {
+		return co.id, nil
+	}
+
+	ch := make(chan *Reply, 1)
+	co.pending[co.id] = ch
+
+	return co.id, ch
+}
+
+// resolve passes a reply to the sender waiting for it, returning false if there is none.
+func (co *connection) resolve(r *Reply) bool {
+	co.mu.Lock()
+	defer co.mu.Unlock()
+
+	ch, ok := co.pending[r.ReplyTo]
+	if !ok {
+		return false
+	}
+
+	delete(co.pending, r.ReplyTo)
+	ch <- r
+
+	return true
+}
+
+func (co *connection) cancel(id int) {
+	co.mu.Lock()
+	delete(co.pending, id)
+	co.mu.Unlock()
+}
+
+// cancelAll stops waiting for replies, when the connection is closed.
+func (co *connection) cancelAll() {
+	co.mu.Lock()
+	defer co.mu.Unlock()
+
+	for id, ch := range co.pending {
+		close(ch)
+		delete(co.pending, id)
+	}
+}
+
+func (co *connection) write(data []byte) error {
+	co.wmu.Lock()
+	defer co.wmu.Unlock()
+
+	return co.conn.WriteMessage(websocket.TextMessage, data)
 }
 
 // Connector internally manages connections to slack teams.
 //
 // ffjson: skip
 type Connector struct {
+	mu            sync.RWMutex
 	bots          map[string]*connection
 	handleMessage MessageHandler
 }
@@ -34,18 +118,96 @@ func NewConnector() *Connector {
 	}
 }
 
-// Open opens a websocket connection for the passed URL and assigns it to the given team.
+// Open opens a websocket connection for the passed URL and assigns it to the given team,
+// replacing the connection the team had, if any.
 func (c *Connector) Open(team, url string) error {
 	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
 	if err != nil {
 		return errors.Wrap(err, "Start Failed")
 	}
 
-	c.bots[team] = &connection{conn, url}
+	c.mu.Lock()
+	prev, ok := c.bots[team]
+	c.bots[team] = newConnection(conn, url)
+	c.mu.Unlock()
+
+	if ok {
+		prev.cancelAll()
+		prev.conn.Close()
+	}
+
 	go c.readConn(conn, team)
 	return nil
 }
 
+// Close closes the websocket connection for a team and stops managing it.
+func (c *Connector) Close(team string) error {
+	c.mu.Lock()
+	co, ok := c.bots[team]
+	delete(c.bots, team)
+	c.mu.Unlock()
+
+	if !ok {
+		return ErrBotNotFound
+	}
+
+	co.cancelAll()
+
+	if err := co.conn.Close(); err != nil {
+		return errors.Wrap(err, "Close Failed")
+	}
+
+	return nil
+}
+
+// URL returns the socket URL of a team, following the reconnect urls sent by slack.
+func (c *Connector) URL(team string) (string, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	co, ok := c.bots[team]
+	if !ok {
+		return "", ErrBotNotFound
+	}
+
+	return co.url, nil
+}
+
+// Teams returns the teams with an open connection.
+func (c *Connector) Teams() []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	teams := make([]string, 0, len(c.bots))
+	for team := range c.bots {
+		teams = append(teams, team)
+	}
+
+	return teams
+}
+
+// connection gets the open connection of a team.
+func (c *Connector) connection(team string) (*connection, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	co, ok := c.bots[team]
+	if !ok {
+		return nil, ErrBotNotFound
+	}
+
+	return co, nil
+}
+
+// closed checks if a connection has been closed using Close.
+func (c *Connector) closed(conn *websocket.Conn, team string) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	co, ok := c.bots[team]
+	return !ok || co.conn != conn
+}
+
 // SetMessageHandler sets the message handler for the connector.
 func (c *Connector) SetMessageHandler(messageHandler MessageHandler) {
 	c.handleMessage = messageHandler
@@ -60,18 +222,105 @@ type typingPayload struct {
 
 // Typing sends a typing payload.
 func (c *Connector) Typing(team, channel string) error {
-	co, ok := c.bots[team]
-	if !ok {
-		return ErrBotNotFound
+	co, err := c.connection(team)
+	if err != nil {
+		return err
+	}
+
+	id, _ := co.next(false)
+
+	data, err := json.Marshal(&typingPayload{id, channel, "typing"})
+	if err != nil {
+		return errors.Wrap(err, "Typing Failed")
 	}
 
-	if err := co.conn.WriteJSON(&typingPayload{1, channel, "typing"}); err != nil {
+	if err := co.write(data); err != nil {
 		return errors.Wrap(err, "Typing Failed")
 	}
 
 	return nil
 }
 
+// ReplyError is the error slack replies with when it could not handle a payload.
+//
+// ffjson: skip
+type ReplyError struct {
+	Code int    `json:"code"`
+	Msg  string `json:"msg"`
+}
+
+func (e *ReplyError) Error() string {
+	return "Slack Error " + strconv.Itoa(e.Code) + ": " + e.Msg
+}
+
+// Reply is the acknowledgement slack sends for a payload sent over a connection.
+//
+// ffjson: skip
+type Reply struct {
+	Type    string      `json:"type,omitempty"`
+	OK      bool        `json:"ok"`
+	ReplyTo int         `json:"reply_to"`
+	TS      string      `json:"ts,omitempty"`
+	Text    string      `json:"text,omitempty"`
+	Error   *ReplyError `json:"error,omitempty"`
+}
+
+// Send sends a payload to the socket of a team, setting its id.
+//
+// For payloads slack acknowledges, like messages and pings, it waits up to ReplyTimeout for the reply,
+// returning it along with its Error if slack could not handle the payload. For other payloads,
+// like typing indicators and presence subscriptions, the reply is nil.
+func (c *Connector) Send(team string, payload []byte) (*Reply, error) {
+	co, err := c.connection(team)
+	if err != nil {
+		return nil, err
+	}
+
+	p := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(payload, &p); err != nil {
+		return nil, ErrInvalidPayload
+	}
+
+	var typ string
+	if err := json.Unmarshal(p["type"], &typ); err != nil || typ == "" {
+		return nil, ErrInvalidPayload
+	}
+
+	id, replies := co.next(repliedTypes[typ])
+	p["id"] = json.RawMessage(strconv.Itoa(id))
+
+	data, err := json.Marshal(p)
+	if err != nil {
+		co.cancel(id)
+		return nil, errors.Wrap(err, "Send Failed")
+	}
+
+	if err := co.write(data); err != nil {
+		co.cancel(id)
+		return nil, errors.Wrap(err, "Send Failed")
+	}
+
+	if replies == nil {
+		return nil, nil
+	}
+
+	select {
+	case r, ok := <-replies:
+		if !ok {
+			return nil, ErrConnectionClosed
+		}
+
+		if r.Error != nil {
+			return r, r.Error
+		}
+
+		return r, nil
+	case <-time.After(ReplyTimeout):
+		co.cancel(id)
+		return nil, ErrReplyTimeout
+	}
+}
+
 // ffjson: noencoder
 type reconnect struct {
 	Type string `json:"type"`
@@ -83,6 +332,10 @@ func (c *Connector) readConn(conn *websocket.Conn, team string) {
 	for {
 		_, msg, err := conn.ReadMessage()
 		if err != nil {
+			if c.closed(conn, team) {
+				return
+			}
+
 			log.Fatal(errors.Wrap(err, "Unexpected error while reading message"))
 		}
 
@@ -92,7 +345,16 @@ func (c *Connector) readConn(conn *websocket.Conn, team string) {
 		}
 
 		if r.Type == "reconnect_url" {
-			c.bots[team].url = r.URL
+			c.mu.Lock()
+			if co, ok := c.bots[team]; ok {
+				co.url = r.URL
+			}
+			c.mu.Unlock()
+
+			continue
+		}
+
+		if bytes.Contains(msg, replyTo) && c.resolve(conn, team, msg) {
 			continue
 		}
 
@@ -100,10 +362,43 @@ func (c *Connector) readConn(conn *websocket.Conn, team string) {
 	}
 }
 
+var replyTo = []byte(`"reply_to"`)
+
+// resolve passes a reply to the Send call waiting for it, returning false if it is not a reply to one.
+func (c *Connector) resolve(conn *websocket.Conn, team string, msg []byte) bool {
+	r := &Reply{}
+	if err := json.Unmarshal(msg, r); err != nil {
+		return false
+	}
+
+	// pongs have no ok field
+	if r.Type == "pong" {
+		r.OK = true
+	}
+
+	c.mu.RLock()
+	co, ok := c.bots[team]
+	c.mu.RUnlock()
+
+	if !ok || co.conn != conn {
+		return false
+	}
+
+	return co.resolve(r)
+}
+
 // MessagePayload is the payload received from slack over a connection.
 type MessagePayload struct {
 	Message json.RawMessage `json:"message"`
 	Team    string          `json:"team"`
+
+	// ID is set by connectors that can deliver a payload more than once,
+	// so the receiver can discard the duplicates.
+	ID string `json:"id,omitempty"`
+
+	// Ack is set by connectors that deliver a payload again until it was handled,
+	// the receiver calls it once it handled the payload.
+	Ack func() `json:"-"`
 }
 
 //go:generate ffjson $GOFILE
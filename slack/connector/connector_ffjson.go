@@ -33,7 +33,7 @@ func (j *MessagePayload) MarshalJSONBuf(buf fflib.EncodingBuffer) error {
 	var obj []byte
 	_ = obj
 	_ = err
-	buf.WriteString(`{"message":`)
+	buf.WriteString(`{ "message":`)
 
 	{
 
@@ -46,6 +46,13 @@ func (j *MessagePayload) MarshalJSONBuf(buf fflib.EncodingBuffer) error {
 	}
 	buf.WriteString(`,"team":`)
 	fflib.WriteJsonString(buf, string(j.Team))
+	buf.WriteByte(',')
+	if len(j.ID) != 0 {
+		buf.WriteString(`"id":`)
+		fflib.WriteJsonString(buf, string(j.ID))
+		buf.WriteByte(',')
+	}
+	buf.Rewind(1)
 	buf.WriteByte('}')
 	return nil
 }
@@ -57,12 +64,16 @@ const (
 	ffjtMessagePayloadMessage
 
 	ffjtMessagePayloadTeam
+
+	ffjtMessagePayloadID
 )
 
 var ffjKeyMessagePayloadMessage = []byte("message")
 
 var ffjKeyMessagePayloadTeam = []byte("team")
 
+var ffjKeyMessagePayloadID = []byte("id")
+
 // UnmarshalJSON umarshall json - template of ffjson
 func (j *MessagePayload) UnmarshalJSON(input []byte) error {
 	fs := fflib.NewFFLexer(input)
@@ -124,6 +135,14 @@ mainparse:
 			} else {
 				switch kn[0] {
 
+				case 'i':
+
+					if bytes.Equal(ffjKeyMessagePayloadID, kn) {
+						currentKey = ffjtMessagePayloadID
+						state = fflib.FFParse_want_colon
+						goto mainparse
+					}
+
 				case 'm':
 
 					if bytes.Equal(ffjKeyMessagePayloadMessage, kn) {
@@ -142,6 +161,12 @@ mainparse:
 
 				}
 
+				if fflib.SimpleLetterEqualFold(ffjKeyMessagePayloadID, kn) {
+					currentKey = ffjtMessagePayloadID
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
 				if fflib.SimpleLetterEqualFold(ffjKeyMessagePayloadTeam, kn) {
 					currentKey = ffjtMessagePayloadTeam
 					state = fflib.FFParse_want_colon
@@ -177,6 +202,9 @@ mainparse:
 				case ffjtMessagePayloadTeam:
 					goto handle_Team
 
+				case ffjtMessagePayloadID:
+					goto handle_ID
+
 				case ffjtMessagePayloadnosuchkey:
 					err = fs.SkipField(tok)
 					if err != nil {
@@ -242,6 +270,32 @@ handle_Team:
 	state = fflib.FFParse_after_value
 	goto mainparse
 
+handle_ID:
+
+	/* handler: j.ID type=string kind=string quoted=false*/
+
+	{
+
+		{
+			if tok != fflib.FFTok_string && tok != fflib.FFTok_null {
+				return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for string", tok))
+			}
+		}
+
+		if tok == fflib.FFTok_null {
+
+		} else {
+
+			outBuf := fs.Output.Bytes()
+
+			j.ID = string(string(outBuf))
+
+		}
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
 wantedvalue:
 	return fs.WrapErr(fmt.Errorf("wanted value token, but got token: %v", tok))
 wrongtokenerror:
@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"reflect"
+	"strconv"
 	"strings"
 	"testing"
 
@@ -15,7 +16,7 @@ func TestNewConnector(t *testing.T) {
 		name string
 		want *Connector
 	}{
-		{"", &Connector{make(map[string]*connection), nil}},
+		{"", &Connector{bots: make(map[string]*connection)}},
 	}
 
 	for _, tt := range tests {
@@ -104,6 +105,131 @@ func TestConnector_Typing(t *testing.T) {
 	}
 }
 
+func TestConnector_Close(t *testing.T) {
+	u := websocket.Upgrader{
+		ReadBufferSize: 1024,
+	}
+
+	s := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		conn, err := u.Upgrade(res, req, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// keep the connection open until the client closes it
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}))
+
+	c := NewConnector()
+	if err := c.Open("T12345678", strings.Replace(s.URL, "http", "ws", 1)); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		c       *Connector
+		team    string
+		wantErr bool
+	}{
+		{"", c, "T12345678", false},
+		{"", c, "T12345678", true},
+		{"", c, "T87654321", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.c.Close(tt.team); (err != nil) != tt.wantErr {
+				t.Errorf("Connector.Close() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	if err := c.Typing("T12345678", "C12345678"); err != ErrBotNotFound {
+		t.Errorf("Connector.Typing() after Close error = %v, want %v", err, ErrBotNotFound)
+	}
+}
+
+func TestConnector_Send(t *testing.T) {
+	u := websocket.Upgrader{
+		ReadBufferSize: 1024,
+	}
+
+	s := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		conn, err := u.Upgrade(res, req, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// reply like slack, messages with text "fail" are rejected
+		for {
+			var p struct {
+				ID   int    `json:"id"`
+				Type string `json:"type"`
+				Text string `json:"text"`
+			}
+
+			if err := conn.ReadJSON(&p); err != nil {
+				return
+			}
+
+			switch {
+			case p.Type == "ping":
+				conn.WriteJSON(map[string]interface{}{"type": "pong", "reply_to": p.ID})
+			case p.Type == "message" && p.Text == "fail":
+				conn.WriteJSON(map[string]interface{}{"ok": false, "reply_to": p.ID, "error": map[string]interface{}{"code": 2, "msg": "message text is missing"}})
+			case p.Type == "message":
+				conn.WriteJSON(map[string]interface{}{"ok": true, "reply_to": p.ID, "ts": strconv.Itoa(p.ID), "text": p.Text})
+			}
+		}
+	}))
+
+	c := NewConnector()
+	c.SetMessageHandler(func(msg []byte, team string) {
+		t.Errorf("reply was passed to the message handler: %s", msg)
+	})
+
+	if err := c.Open("T12345678", strings.Replace(s.URL, "http", "ws", 1)); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		team      string
+		payload   string
+		wantReply bool
+		wantErr   bool
+	}{
+		{"message", "T12345678", `{"type":"message","channel":"C1","text":"hi"}`, true, false},
+		{"ping", "T12345678", `{"type":"ping"}`, true, false},
+		{"typing", "T12345678", `{"type":"typing","channel":"C1"}`, false, false},
+		{"rejected", "T12345678", `{"type":"message","channel":"C1","text":"fail"}`, true, true},
+		{"no type", "T12345678", `{"channel":"C1"}`, false, true},
+		{"not an object", "T12345678", `[]`, false, true},
+		{"unknown team", "T87654321", `{"type":"ping"}`, false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := c.Send(tt.team, []byte(tt.payload))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Connector.Send() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if (got != nil) != tt.wantReply {
+				t.Fatalf("Connector.Send() = %v, wantReply %v", got, tt.wantReply)
+			}
+
+			if got != nil && got.OK == tt.wantErr {
+				t.Errorf("Connector.Send() ok = %v, wantErr %v", got.OK, tt.wantErr)
+			}
+		})
+	}
+}
+
 // TODO: figure this out
 //
 // func TestConnector_readConn(t *testing.T) {
@@ -0,0 +1,78 @@
+package httpserver
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// SignatureHeader holds the signature of a request body.
+	SignatureHeader = "X-Connector-Signature"
+
+	// TimestampHeader holds the unix time a request was signed at.
+	TimestampHeader = "X-Connector-Request-Timestamp"
+
+	// MaxSignatureAge is how old a signed request can be, to limit replays.
+	MaxSignatureAge = 5 * time.Minute
+
+	signatureVersion = "v0"
+)
+
+var (
+	ErrInvalidSecret    = errors.New("Invalid Secret")
+	ErrInvalidSignature = errors.New("Invalid Signature")
+	ErrExpiredSignature = errors.New("Expired Signature")
+)
+
+func signature(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(signatureVersion + ":" + timestamp + ":"))
+	mac.Write(body)
+
+	return signatureVersion + "=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Sign sets the signature headers of a request with body, using a shared secret.
+//
+// Does nothing if secret is empty.
+func Sign(req *http.Request, body []byte, secret string) {
+	if secret == "" {
+		return
+	}
+
+	ts := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set(TimestampHeader, ts)
+	req.Header.Set(SignatureHeader, signature(secret, ts, body))
+}
+
+// Verify checks that a request with body was signed with secret in the last MaxSignatureAge.
+//
+// Any request is valid if secret is empty.
+func Verify(req *http.Request, body []byte, secret string) error {
+	if secret == "" {
+		return nil
+	}
+
+	ts := req.Header.Get(TimestampHeader)
+
+	t, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+
+	if d := time.Since(time.Unix(t, 0)); d > MaxSignatureAge || d < -MaxSignatureAge {
+		return ErrExpiredSignature
+	}
+
+	if !hmac.Equal([]byte(req.Header.Get(SignatureHeader)), []byte(signature(secret, ts, body))) {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
@@ -0,0 +1,76 @@
+package httpserver
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestVerify(t *testing.T) {
+	body := []byte(`{"team":"T12345678"}`)
+
+	signed := func(secret string) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/", nil)
+		Sign(req, body, secret)
+		return req
+	}
+
+	expired := signed("secret")
+	ts := strconv.FormatInt(time.Now().Add(-2*MaxSignatureAge).Unix(), 10)
+	expired.Header.Set(TimestampHeader, ts)
+	expired.Header.Set(SignatureHeader, signature("secret", ts, body))
+
+	tests := []struct {
+		name    string
+		req     *http.Request
+		secret  string
+		wantErr error
+	}{
+		{"unsigned, no secret", httptest.NewRequest(http.MethodPost, "/", nil), "", nil},
+		{"unsigned", httptest.NewRequest(http.MethodPost, "/", nil), "secret", ErrInvalidSignature},
+		{"signed", signed("secret"), "secret", nil},
+		{"wrong secret", signed("other"), "secret", ErrInvalidSignature},
+		{"expired", expired, "secret", ErrExpiredSignature},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := Verify(tt.req, body, tt.secret); err != tt.wantErr {
+				t.Errorf("Verify() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestConnector_RemoveHandlerSecret(t *testing.T) {
+	if _, err := NewConnector("", WithSecret("")); err == nil {
+		t.Error("NewConnector() with empty secret did not fail")
+	}
+
+	c, err := NewConnector("", WithSecret("secret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	body := `{"team":"T12345678"}`
+
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	res := httptest.NewRecorder()
+	c.RemoveHandler()(res, req)
+
+	if res.Code != http.StatusUnauthorized {
+		t.Errorf("Connector.RemoveHandler() unsigned status = %v, want %v", res.Code, http.StatusUnauthorized)
+	}
+
+	req = httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	Sign(req, []byte(body), "secret")
+	res = httptest.NewRecorder()
+	c.RemoveHandler()(res, req)
+
+	if res.Code != http.StatusNotFound {
+		t.Errorf("Connector.RemoveHandler() signed status = %v, want %v", res.Code, http.StatusNotFound)
+	}
+}
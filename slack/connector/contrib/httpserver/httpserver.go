@@ -2,43 +2,325 @@ package httpserver // import "suy.io/bots/slack/connector/contrib/httpserver"
 
 import (
 	"bytes"
+	"crypto/rand"
+	"encoding/hex"
 	"encoding/json"
 	"io/ioutil"
 	"log"
 	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/pkg/errors"
 
 	"suy.io/bots/slack/connector"
+	"suy.io/bots/slack/connector/cluster"
 )
 
+// DefaultSpoolSize is the number of messages held by the spool of a new Connector.
+const DefaultSpoolSize = 10000
+
+// DefaultPostTimeout is how long posting a message to the app can take, before it is retried.
+const DefaultPostTimeout = 30 * time.Second
+
+const (
+	minRetryBackoff = 100 * time.Millisecond
+	maxRetryBackoff = 30 * time.Second
+)
+
+// errRejected is returned when the app refuses a message, retrying it will not help.
+var errRejected = errors.New("Message was rejected")
+
+var ErrInvalidClient = errors.New("Invalid Client")
+
+// Connector manages websocket connections, and posts their messages to an app.
+//
+// Messages are held in a Spool until the app accepts them, retrying with backoff
+// while it is unreachable or failing, so every message is delivered at least once.
+// Every message gets an ID, so the app can discard the ones it already received.
 type Connector struct {
 	*connector.Connector
+
+	// node is set for connectors that are part of a cluster
+	node *cluster.Node
+
+	messageURL string
+	client     *http.Client
+
+	mu     sync.Mutex
+	spool  Spool
+	pushed chan struct{}
+
+	prefix string
+	seq    uint64
+
+	// secret signs and verifies requests, when set
+	secret string
+
+	nodeOptions []func(*cluster.Node) error
 }
 
-func NewConnector(messageURL string) *Connector {
-	c := connector.NewConnector()
+func NewConnector(messageURL string, options ...func(*Connector) error) (*Connector, error) {
+	c, err := newConnector(messageURL, options...)
+	if err != nil {
+		return nil, errors.Wrap(err, "NewConnector Failed")
+	}
 
-	c.SetMessageHandler(func(msg []byte, team string) {
-		c.Typing(team, "")
+	return c, nil
+}
 
-		p := &connector.MessagePayload{Message: msg, Team: team}
-		data, err := json.Marshal(p)
-		if err != nil {
-			log.Fatal(errors.Wrap(err, "Could not create Message Payload"))
+// WithSecret can be passed to NewConnector or NewClusterConnector to sign the messages posted to the app,
+// and only accept requests signed with the same secret, like the ones sent by httpclient.WithSecret.
+func WithSecret(secret string) func(*Connector) error {
+	return func(c *Connector) error {
+		if secret == "" {
+			return ErrInvalidSecret
+		}
+
+		c.secret = secret
+		return nil
+	}
+}
+
+// WithClient sets the client messages are posted to the app with,
+// which is a client with a timeout of DefaultPostTimeout by default.
+func WithClient(client *http.Client) func(*Connector) error {
+	return func(c *Connector) error {
+		if client == nil {
+			return ErrInvalidClient
+		}
+
+		c.client = client
+		return nil
+	}
+}
+
+// WithNodeOptions can be passed to NewClusterConnector to configure the cluster node of the connector.
+func WithNodeOptions(options ...func(*cluster.Node) error) func(*Connector) error {
+	return func(c *Connector) error {
+		c.nodeOptions = append(c.nodeOptions, options...)
+		return nil
+	}
+}
+
+// NewClusterConnector creates a Connector that is an instance of a cluster, reachable at addr,
+// managing only the teams it owns. Adding or removing a team it does not own responds with
+// http.StatusMisdirectedRequest.
+//
+// The instance joins the cluster right away, call Node().Stop() to leave it.
+func NewClusterConnector(messageURL, id, addr string, ls cluster.LeaseStore, options ...func(*Connector) error) (*Connector, error) {
+	c, err := newConnector(messageURL, options...)
+	if err != nil {
+		return nil, errors.Wrap(err, "NewClusterConnector Failed")
+	}
+
+	// options passed with WithNodeOptions come after, so they can replace the takeover
+	nodeOptions := append([]func(*cluster.Node) error{cluster.WithTakeover(c.takeover)}, c.nodeOptions...)
+
+	node, err := cluster.NewNode(id, addr, c.Connector, ls, nodeOptions...)
+	if err != nil {
+		return nil, errors.Wrap(err, "NewClusterConnector Failed")
+	}
+
+	if err := node.Start(); err != nil {
+		return nil, errors.Wrap(err, "NewClusterConnector Failed")
+	}
+
+	c.node = node
+	return c, nil
+}
+
+// Node returns the cluster node of the connector, nil if it is not part of a cluster.
+func (c *Connector) Node() *cluster.Node {
+	return c.node
+}
+
+// SetSpool replaces the spool of the connector, which is a MemorySpool of DefaultSpoolSize
+// by default. Messages in the previous spool are not moved, so it should be set before adding any team.
+func (c *Connector) SetSpool(s Spool) {
+	c.mu.Lock()
+	c.spool = s
+	c.mu.Unlock()
+
+	c.notify()
+}
+
+func (c *Connector) add(team, url string) error {
+	if c.node != nil {
+		return c.node.Add(team, url)
+	}
+
+	return c.Open(team, url)
+}
+
+func (c *Connector) remove(team string) error {
+	if c.node != nil {
+		return c.node.Remove(team)
+	}
+
+	return c.Connector.Close(team)
+}
+
+func newConnector(messageURL string, options ...func(*Connector) error) (*Connector, error) {
+	// a random prefix keeps ids unique across restarts and instances
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return nil, err
+	}
+
+	c := &Connector{
+		Connector:  connector.NewConnector(),
+		messageURL: messageURL,
+		client:     &http.Client{Timeout: DefaultPostTimeout},
+		spool:      NewMemorySpool(DefaultSpoolSize),
+		pushed:     make(chan struct{}, 1),
+		prefix:     hex.EncodeToString(b),
+	}
+
+	for _, option := range options {
+		if err := option(c); err != nil {
+			return nil, err
 		}
+	}
+
+	c.SetMessageHandler(c.handleMessage)
+	go c.deliver()
 
-		res, err := http.Post(messageURL, "application/json", bytes.NewReader(data))
+	return c, nil
+}
+
+func (c *Connector) currentSpool() Spool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.spool
+}
+
+// notify wakes up deliver, if it is waiting for messages.
+func (c *Connector) notify() {
+	select {
+	case c.pushed <- struct{}{}:
+	default:
+	}
+}
+
+func (c *Connector) handleMessage(msg []byte, team string) {
+	c.Typing(team, "")
+
+	if err := c.push(msg, team); err != nil {
+		log.Println(errors.Wrapf(err, "Dropped message for team %s", team))
+	}
+}
+
+// goodbye is sent to the app for a team taken over from another instance, like slack does
+// before closing a socket, so the app adds the team again with a new socket url.
+var goodbye = []byte(`{"type":"goodbye"}`)
+
+func (c *Connector) takeover(team string) error {
+	return c.push(goodbye, team)
+}
+
+// push queues a message for the app.
+func (c *Connector) push(msg []byte, team string) error {
+	p := &connector.MessagePayload{
+		Message: msg,
+		Team:    team,
+		ID:      c.prefix + "-" + strconv.FormatUint(atomic.AddUint64(&c.seq, 1), 10),
+	}
+
+	if err := c.currentSpool().Push(p); err != nil {
+		return err
+	}
+
+	c.notify()
+	return nil
+}
+
+// deliver posts the messages in the spool to the app in order, retrying every message until it is accepted.
+func (c *Connector) deliver() {
+	backoff := minRetryBackoff
+	wait := func() {
+		time.Sleep(backoff)
+
+		if backoff *= 2; backoff > maxRetryBackoff {
+			backoff = maxRetryBackoff
+		}
+	}
+
+	for {
+		s := c.currentSpool()
+
+		p, err := s.Peek()
 		if err != nil {
-			log.Fatal(errors.Wrap(err, "Could not Post Message"))
+			// a message that cannot be read can never be delivered
+			log.Println(errors.Wrap(err, "Dropped unreadable message"))
+			if err := s.Pop(); err != nil {
+				log.Println(err)
+				wait()
+			}
+
+			continue
+		}
+
+		if p == nil {
+			<-c.pushed
+			continue
 		}
 
-		if res.StatusCode != http.StatusOK {
-			log.Fatal(errors.Wrap(err, "Post Message was not successful"))
+		if err := c.postMessage(p); err != nil {
+			if errors.Cause(err) == errRejected {
+				log.Println(errors.Wrapf(err, "Dropped message for team %s", p.Team))
+				s.Pop()
+				continue
+			}
+
+			log.Println(errors.Wrapf(err, "Could not deliver message, retrying in %v", backoff))
+			wait()
+			continue
 		}
-	})
 
-	return &Connector{c}
+		backoff = minRetryBackoff
+
+		// if this fails the message is sent again, and the app discards it by its id
+		if err := s.Pop(); err != nil {
+			log.Println(err)
+		}
+	}
+}
+
+func (c *Connector) postMessage(p *connector.MessagePayload) error {
+	data, err := json.Marshal(p)
+	if err != nil {
+		return errors.Wrap(errRejected, "Could not create Message Payload")
+	}
+
+	req, err := http.NewRequest(http.MethodPost, c.messageURL, bytes.NewReader(data))
+	if err != nil {
+		return errors.Wrap(errRejected, "Could not create Message Request")
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	Sign(req, data, c.secret)
+
+	res, err := c.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "Could not Post Message")
+	}
+
+	res.Body.Close()
+
+	// only a message the app cannot take is dropped, anything else,
+	// like a misconfigured route or proxy, is retried until it is fixed
+	switch res.StatusCode {
+	case http.StatusOK:
+		return nil
+	case http.StatusBadRequest, http.StatusRequestEntityTooLarge:
+		return errors.Wrap(errRejected, res.Status)
+	default:
+		return errors.New("Post Message was not successful, " + res.Status)
+	}
 }
 
 type AddPayload struct {
@@ -61,14 +343,24 @@ func (c *Connector) AddHandler() http.HandlerFunc {
 			return
 		}
 
+		if err := Verify(req, data, c.secret); err != nil {
+			http.Error(res, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+			return
+		}
+
 		p := &AddPayload{}
 		if err := json.Unmarshal(data, p); err != nil {
 			http.Error(res, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
 			return
 		}
 
-		if err := c.Open(p.Team, p.URL); err != nil {
-			http.Error(res, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		if err := c.add(p.Team, p.URL); err != nil {
+			if err == cluster.ErrNotOwner {
+				http.Error(res, http.StatusText(http.StatusMisdirectedRequest), http.StatusMisdirectedRequest)
+			} else {
+				http.Error(res, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			}
+
 			return
 		}
 
@@ -96,6 +388,11 @@ func (c *Connector) TypingHandler() http.HandlerFunc {
 			return
 		}
 
+		if err := Verify(req, data, c.secret); err != nil {
+			http.Error(res, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+			return
+		}
+
 		p := &TypingPayload{}
 		if err := json.Unmarshal(data, p); err != nil {
 			http.Error(res, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
@@ -115,3 +412,116 @@ func (c *Connector) TypingHandler() http.HandlerFunc {
 		res.WriteHeader(http.StatusOK)
 	}
 }
+
+type RemovePayload struct {
+	Team string `json:"team"`
+}
+
+func (c *Connector) RemoveHandler() http.HandlerFunc {
+	return func(res http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost {
+			http.Error(res, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+			return
+		}
+
+		data, err := ioutil.ReadAll(req.Body)
+		defer req.Body.Close()
+
+		if err != nil {
+			http.Error(res, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+
+		if err := Verify(req, data, c.secret); err != nil {
+			http.Error(res, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+			return
+		}
+
+		p := &RemovePayload{}
+		if err := json.Unmarshal(data, p); err != nil {
+			http.Error(res, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+
+		if err := c.remove(p.Team); err != nil {
+			if err == connector.ErrBotNotFound {
+				http.Error(res, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+			} else if err == cluster.ErrNotOwner {
+				http.Error(res, http.StatusText(http.StatusMisdirectedRequest), http.StatusMisdirectedRequest)
+			} else {
+				http.Error(res, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			}
+
+			return
+		}
+
+		res.WriteHeader(http.StatusOK)
+	}
+}
+
+type SendPayload struct {
+	Team    string          `json:"team"`
+	Payload json.RawMessage `json:"payload"`
+}
+
+// SendHandler sends RTM payloads, responding with the reply of slack as JSON,
+// or with no body for payloads slack does not acknowledge.
+func (c *Connector) SendHandler() http.HandlerFunc {
+	return func(res http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost {
+			http.Error(res, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+			return
+		}
+
+		data, err := ioutil.ReadAll(req.Body)
+		defer req.Body.Close()
+
+		if err != nil {
+			http.Error(res, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+
+		if err := Verify(req, data, c.secret); err != nil {
+			http.Error(res, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+			return
+		}
+
+		p := &SendPayload{}
+		if err := json.Unmarshal(data, p); err != nil {
+			http.Error(res, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+
+		r, err := c.Send(p.Team, p.Payload)
+		if err != nil {
+			if _, ok := err.(*connector.ReplyError); !ok {
+				switch err {
+				case connector.ErrBotNotFound:
+					http.Error(res, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+				case connector.ErrInvalidPayload:
+					http.Error(res, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+				case connector.ErrReplyTimeout, connector.ErrConnectionClosed:
+					http.Error(res, http.StatusText(http.StatusGatewayTimeout), http.StatusGatewayTimeout)
+				default:
+					http.Error(res, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+				}
+
+				return
+			}
+		}
+
+		if r == nil {
+			res.WriteHeader(http.StatusOK)
+			return
+		}
+
+		d, err := json.Marshal(r)
+		if err != nil {
+			http.Error(res, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+
+		res.Header().Set("Content-Type", "application/json")
+		res.Write(d)
+	}
+}
@@ -1,12 +1,17 @@
 package httpserver
 
 import (
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/gorilla/websocket"
+
+	"suy.io/bots/slack/connector/cluster"
 )
 
 func TestConnector_AddHandler(t *testing.T) {
@@ -15,13 +20,23 @@ func TestConnector_AddHandler(t *testing.T) {
 	}
 
 	s := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
-		_, err := u.Upgrade(res, req, nil)
+		conn, err := u.Upgrade(res, req, nil)
 		if err != nil {
 			t.Fatal(err)
 		}
+
+		// keep the connection open until the client closes it
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
 	}))
 
-	c := NewConnector("")
+	c, err := NewConnector("")
+	if err != nil {
+		t.Fatal(err)
+	}
 
 	tests := []struct {
 		name       string
@@ -53,13 +68,23 @@ func TestConnector_TypingHandler(t *testing.T) {
 	}
 
 	s := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
-		_, err := u.Upgrade(res, req, nil)
+		conn, err := u.Upgrade(res, req, nil)
 		if err != nil {
 			t.Fatal(err)
 		}
+
+		// keep the connection open until the client closes it
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
 	}))
 
-	c := NewConnector("")
+	c, err := NewConnector("")
+	if err != nil {
+		t.Fatal(err)
+	}
 
 	tests := []struct {
 		name       string
@@ -84,3 +109,224 @@ func TestConnector_TypingHandler(t *testing.T) {
 		})
 	}
 }
+
+func TestConnector_RemoveHandler(t *testing.T) {
+	u := websocket.Upgrader{
+		ReadBufferSize: 1024,
+	}
+
+	s := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		conn, err := u.Upgrade(res, req, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}))
+
+	c, err := NewConnector("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Open("T12345678", strings.Replace(s.URL, "http", "ws", 1)); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		c          *Connector
+		req        *http.Request
+		wantStatus int
+	}{
+		{"", c, httptest.NewRequest(http.MethodGet, "/", nil), http.StatusUnauthorized},
+		{"", c, httptest.NewRequest(http.MethodPost, "/", nil), http.StatusInternalServerError},
+		{"", c, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"team":"T12345678"}`)), http.StatusOK},
+		{"", c, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"team":"T12345678"}`)), http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := httptest.NewRecorder()
+
+			tt.c.RemoveHandler()(res, tt.req)
+
+			if res.Code != tt.wantStatus {
+				t.Errorf("Connector.RemoveHandler() status = %v, want %v", res.Code, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestConnector_SendHandler(t *testing.T) {
+	u := websocket.Upgrader{
+		ReadBufferSize: 1024,
+	}
+
+	s := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		conn, err := u.Upgrade(res, req, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for {
+			var p struct {
+				ID   int    `json:"id"`
+				Type string `json:"type"`
+			}
+
+			if err := conn.ReadJSON(&p); err != nil {
+				return
+			}
+
+			if p.Type == "ping" {
+				conn.WriteJSON(map[string]interface{}{"type": "pong", "reply_to": p.ID})
+			}
+		}
+	}))
+
+	c, err := NewConnector("")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := c.Open("T12345678", strings.Replace(s.URL, "http", "ws", 1)); err != nil {
+		t.Fatal(err)
+	}
+
+	send := func(team, payload string) *http.Request {
+		return httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"team":"`+team+`","payload":`+payload+`}`))
+	}
+
+	tests := []struct {
+		name       string
+		req        *http.Request
+		wantStatus int
+		wantBody   bool
+	}{
+		{"", httptest.NewRequest(http.MethodGet, "/", nil), http.StatusUnauthorized, true},
+		{"", send("T12345678", `{"type":"ping"}`), http.StatusOK, true},
+		{"", send("T12345678", `{"type":"typing","channel":"C1"}`), http.StatusOK, false},
+		{"", send("T12345678", `{"channel":"C1"}`), http.StatusBadRequest, true},
+		{"", send("T87654321", `{"type":"ping"}`), http.StatusNotFound, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := httptest.NewRecorder()
+
+			c.SendHandler()(res, tt.req)
+
+			if res.Code != tt.wantStatus {
+				t.Errorf("Connector.SendHandler() status = %v, want %v", res.Code, tt.wantStatus)
+			}
+
+			if (res.Body.Len() > 0) != tt.wantBody {
+				t.Errorf("Connector.SendHandler() body = %q, wantBody %v", res.Body.String(), tt.wantBody)
+			}
+		})
+	}
+}
+
+func TestNewClusterConnector(t *testing.T) {
+	u := websocket.Upgrader{
+		ReadBufferSize: 1024,
+	}
+
+	s := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		conn, err := u.Upgrade(res, req, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}))
+
+	ls := cluster.NewMemoryLeaseStore()
+	ls.Join("b", "http://b", time.Minute)
+
+	c, err := NewClusterConnector("", "a", "http://a", ls)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer c.Node().Stop()
+
+	// find a team for each instance
+	r := cluster.NewRing("a", "b")
+	teams := map[string]string{}
+	for i := 0; len(teams) < 2; i++ {
+		team := "T" + strconv.Itoa(i)
+		if _, ok := teams[r.Owner(team)]; !ok {
+			teams[r.Owner(team)] = team
+		}
+	}
+
+	add := func(team string) *http.Request {
+		return httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"team":"`+team+`","url":"`+strings.Replace(s.URL, "http", "ws", 1)+`"}`))
+	}
+
+	remove := func(team string) *http.Request {
+		return httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"team":"`+team+`"}`))
+	}
+
+	tests := []struct {
+		name       string
+		handler    http.HandlerFunc
+		req        *http.Request
+		wantStatus int
+	}{
+		{"", c.AddHandler(), add(teams["a"]), http.StatusOK},
+		{"", c.AddHandler(), add(teams["b"]), http.StatusMisdirectedRequest},
+		{"", c.RemoveHandler(), remove(teams["b"]), http.StatusMisdirectedRequest},
+		{"", c.RemoveHandler(), remove(teams["a"]), http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := httptest.NewRecorder()
+
+			tt.handler(res, tt.req)
+
+			if res.Code != tt.wantStatus {
+				t.Errorf("Connector handler status = %v, want %v", res.Code, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestConnector_takeover(t *testing.T) {
+	got := make(chan string, 1)
+
+	app := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		d, _ := ioutil.ReadAll(req.Body)
+		got <- string(d)
+	}))
+
+	defer app.Close()
+
+	c, err := NewConnector(app.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := c.takeover("T1"); err != nil {
+		t.Fatalf("Connector.takeover() error = %v", err)
+	}
+
+	select {
+	case d := <-got:
+		if !strings.Contains(d, `"message":{"type":"goodbye"}`) || !strings.Contains(d, `"team":"T1"`) {
+			t.Errorf("Connector.takeover() posted %v, want a goodbye for T1", d)
+		}
+	case <-time.After(time.Second):
+		t.Errorf("Connector.takeover() posted nothing to the app")
+	}
+}
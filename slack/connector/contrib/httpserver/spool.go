@@ -0,0 +1,193 @@
+package httpserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"suy.io/bots/internal/filekv"
+	"suy.io/bots/slack/connector"
+)
+
+var ErrSpoolFull = errors.New("Spool is full")
+
+// Spool holds the messages of a Connector until the app has received them.
+//
+// Messages are delivered in order, a message is only popped after
+// the app accepted it.
+type Spool interface {
+	// Push adds a message at the end, returning ErrSpoolFull if there is no space left.
+	Push(p *connector.MessagePayload) error
+
+	// Peek gets the first message without removing it, nil if the spool is empty.
+	Peek() (*connector.MessagePayload, error)
+
+	// Pop removes the first message.
+	Pop() error
+}
+
+// MemorySpool is a Spool holding messages in memory, they are lost if the connector stops.
+type MemorySpool struct {
+	mu   sync.Mutex
+	msgs []*connector.MessagePayload
+	size int
+}
+
+// NewMemorySpool creates a MemorySpool holding up to size messages.
+func NewMemorySpool(size int) *MemorySpool {
+	return &MemorySpool{size: size}
+}
+
+func (s *MemorySpool) Push(p *connector.MessagePayload) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.msgs) >= s.size {
+		return ErrSpoolFull
+	}
+
+	s.msgs = append(s.msgs, p)
+	return nil
+}
+
+func (s *MemorySpool) Peek() (*connector.MessagePayload, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.msgs) == 0 {
+		return nil, nil
+	}
+
+	return s.msgs[0], nil
+}
+
+func (s *MemorySpool) Pop() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.msgs) > 0 {
+		s.msgs[0] = nil
+		s.msgs = s.msgs[1:]
+	}
+
+	return nil
+}
+
+var _ Spool = &MemorySpool{}
+
+const spoolBucket = "spool"
+
+// FileSpool is a Spool persisted to a file, keeping messages across restarts of the connector.
+type FileSpool struct {
+	mu         sync.Mutex
+	db         *filekv.DB
+	head, tail uint64
+	size       int
+}
+
+// NewFileSpool opens or creates a FileSpool at path, holding up to size messages.
+func NewFileSpool(path string, size int) (*FileSpool, error) {
+	db, err := filekv.Open(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "NewFileSpool Failed")
+	}
+
+	s := &FileSpool{db: db, size: size}
+
+	if keys := db.Keys(spoolBucket); len(keys) > 0 {
+		if s.head, err = strconv.ParseUint(keys[0], 10, 64); err != nil {
+			return nil, errors.Wrap(err, "NewFileSpool Failed")
+		}
+
+		if s.tail, err = strconv.ParseUint(keys[len(keys)-1], 10, 64); err != nil {
+			return nil, errors.Wrap(err, "NewFileSpool Failed")
+		}
+
+		s.tail++
+	}
+
+	return s, nil
+}
+
+func spoolKey(n uint64) string {
+	// zero padded, so keys sort in order
+	return fmt.Sprintf("%020d", n)
+}
+
+func (s *FileSpool) Push(p *connector.MessagePayload) error {
+	d, err := json.Marshal(p)
+	if err != nil {
+		return errors.Wrap(err, "Push Failed")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.tail-s.head >= uint64(s.size) {
+		return ErrSpoolFull
+	}
+
+	err = s.db.Update(func(tx *filekv.Tx) error {
+		tx.Put(spoolBucket, spoolKey(s.tail), string(d))
+		return nil
+	})
+
+	if err != nil {
+		return errors.Wrap(err, "Push Failed")
+	}
+
+	s.tail++
+	return nil
+}
+
+func (s *FileSpool) Peek() (*connector.MessagePayload, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.head == s.tail {
+		return nil, nil
+	}
+
+	d, ok := s.db.Get(spoolBucket, spoolKey(s.head))
+	if !ok {
+		return nil, errors.Errorf("Peek Failed, message %d is missing", s.head)
+	}
+
+	p := &connector.MessagePayload{}
+	if err := json.Unmarshal([]byte(d), p); err != nil {
+		return nil, errors.Wrap(err, "Peek Failed")
+	}
+
+	return p, nil
+}
+
+func (s *FileSpool) Pop() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.head == s.tail {
+		return nil
+	}
+
+	err := s.db.Update(func(tx *filekv.Tx) error {
+		tx.Delete(spoolBucket, spoolKey(s.head))
+		return nil
+	})
+
+	if err != nil {
+		return errors.Wrap(err, "Pop Failed")
+	}
+
+	s.head++
+	return nil
+}
+
+// Close closes the file.
+func (s *FileSpool) Close() error {
+	return s.db.Close()
+}
+
+var _ Spool = &FileSpool{}
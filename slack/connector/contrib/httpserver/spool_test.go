@@ -0,0 +1,209 @@
+package httpserver
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"suy.io/bots/internal/filekv"
+	"suy.io/bots/slack/connector"
+)
+
+func testSpool(t *testing.T, s Spool) {
+	if p, err := s.Peek(); err != nil || p != nil {
+		t.Fatalf("Peek() on empty spool = %v, %v", p, err)
+	}
+
+	for _, id := range []string{"1", "2"} {
+		if err := s.Push(&connector.MessagePayload{ID: id, Team: "T12345678", Message: json.RawMessage(`{}`)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := s.Push(&connector.MessagePayload{ID: "3"}); err != ErrSpoolFull {
+		t.Errorf("Push() on full spool error = %v, want %v", err, ErrSpoolFull)
+	}
+
+	for _, id := range []string{"1", "2"} {
+		p, err := s.Peek()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if p == nil || p.ID != id {
+			t.Fatalf("Peek() = %v, want message %v", p, id)
+		}
+
+		if err := s.Pop(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if p, err := s.Peek(); err != nil || p != nil {
+		t.Errorf("Peek() on drained spool = %v, %v", p, err)
+	}
+}
+
+func TestMemorySpool(t *testing.T) {
+	testSpool(t, NewMemorySpool(2))
+}
+
+func TestFileSpool(t *testing.T) {
+	dir, err := ioutil.TempDir("", "spool")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "spool.db")
+
+	s, err := NewFileSpool(path, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testSpool(t, s)
+
+	if err := s.Push(&connector.MessagePayload{ID: "4"}); err != nil {
+		t.Fatal(err)
+	}
+
+	s.Close()
+
+	// messages survive a restart
+	s, err = NewFileSpool(path, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer s.Close()
+
+	p, err := s.Peek()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if p == nil || p.ID != "4" {
+		t.Errorf("Peek() after reopen = %v, want message 4", p)
+	}
+
+	s.db.Update(func(tx *filekv.Tx) error {
+		tx.Delete(spoolBucket, spoolKey(s.head))
+		return nil
+	})
+
+	if p, err := s.Peek(); err == nil {
+		t.Errorf("Peek() of a missing message = %v, want error", p)
+	}
+}
+
+func TestConnector_deliver(t *testing.T) {
+	got := make(chan *connector.MessagePayload, 10)
+	fails := 2
+
+	s := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		// the app is unavailable for the first few attempts
+		if fails > 0 {
+			fails--
+			res.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		p := &connector.MessagePayload{}
+		if err := json.NewDecoder(req.Body).Decode(p); err != nil {
+			t.Error(err)
+		}
+
+		got <- p
+		res.WriteHeader(http.StatusOK)
+	}))
+
+	defer s.Close()
+
+	c, err := NewConnector(s.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c.handleMessage([]byte(`{"type":"message"}`), "T12345678")
+	c.handleMessage([]byte(`{"type":"message"}`), "T12345678")
+
+	ids := map[string]bool{}
+	for i := 0; i < 2; i++ {
+		select {
+		case p := <-got:
+			if p.Team != "T12345678" || p.ID == "" {
+				t.Errorf("delivered message = %+v", p)
+			}
+
+			ids[p.ID] = true
+		case <-time.After(5 * time.Second):
+			t.Fatal("message was not delivered")
+		}
+	}
+
+	if len(ids) != 2 {
+		t.Errorf("delivered ids = %v, want 2 distinct ids", ids)
+	}
+}
+
+func TestConnector_postMessage(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		if req.URL.Query().Get("status") == "slow" {
+			time.Sleep(200 * time.Millisecond)
+			return
+		}
+
+		status, _ := strconv.Atoi(req.URL.Query().Get("status"))
+		res.WriteHeader(status)
+	}))
+
+	defer s.Close()
+
+	tests := []struct {
+		name         string
+		status       string
+		wantErr      bool
+		wantRejected bool
+	}{
+		{"", "200", false, false},
+		{"", "400", true, true},
+		{"", "413", true, true},
+		{"", "401", true, false},
+		{"", "404", true, false},
+		{"", "429", true, false},
+		{"", "503", true, false},
+		{"timeout", "slow", true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := NewConnector(s.URL+"?status="+tt.status, WithClient(&http.Client{Timeout: 50 * time.Millisecond}))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = c.postMessage(&connector.MessagePayload{Team: "T12345678", Message: json.RawMessage(`{}`)})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Connector.postMessage() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if rejected := errors.Cause(err) == errRejected; rejected != tt.wantRejected {
+				t.Errorf("Connector.postMessage() rejected = %v, want %v", rejected, tt.wantRejected)
+			}
+		})
+	}
+
+	if _, err := NewConnector(s.URL, WithClient(nil)); errors.Cause(err) != ErrInvalidClient {
+		t.Errorf("NewConnector() error = %v, want %v", err, ErrInvalidClient)
+	}
+}
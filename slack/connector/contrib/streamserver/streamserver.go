@@ -0,0 +1,305 @@
+// Package streamserver implements a connector service that talks to apps over Redis Streams.
+//
+// Messages from the managed sockets are appended to a message stream, and commands to
+// add, remove or send typing indicators for teams are read from a control stream.
+// The streamclient package implements the app side.
+package streamserver // import "suy.io/bots/slack/connector/contrib/streamserver"
+
+import (
+	"log"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/go-redis/redis"
+	"github.com/pkg/errors"
+
+	"suy.io/bots/internal/redisstream"
+	"suy.io/bots/slack/connector"
+)
+
+const (
+	// DefaultMessageStream is the stream messages are appended to.
+	DefaultMessageStream = "slack:messages"
+
+	// DefaultControlStream is the stream commands are read from.
+	DefaultControlStream = "slack:control"
+
+	// DefaultMaxLen is about the number of entries kept in a stream.
+	DefaultMaxLen = 100000
+
+	// ControlGroup is the consumer group connectors read the control stream as.
+	ControlGroup = "connector"
+
+	// sendQueueSize is the number of send commands queued for a team,
+	// before reading more commands waits for them.
+	sendQueueSize = 100
+)
+
+// Fields of a control stream entry.
+const (
+	CommandField = "command"
+	TeamField    = "team"
+	URLField     = "url"
+	ChannelField = "channel"
+	PayloadField = "payload"
+)
+
+// Fields of a message stream entry.
+const (
+	MessageField = "message"
+)
+
+// Commands of the control stream.
+const (
+	CommandAdd    = "add"
+	CommandRemove = "remove"
+	CommandTyping = "typing"
+	CommandSend   = "send"
+)
+
+var ErrInvalidStream = errors.New("Invalid Stream")
+var ErrInvalidMaxLen = errors.New("Invalid Max Length")
+var ErrInvalidConsumer = errors.New("Invalid Consumer")
+
+// Connector manages websocket connections, appending their messages to a stream
+// and running the commands read from a control stream.
+//
+// Commands are acknowledged once they ran, so the commands a connector read before it
+// stopped are run again when it restarts. Send commands run in order for every team,
+// without waiting for the replies of slack to other teams. Only one connector should read a control stream,
+// as it holds the sockets of every team added through it.
+type Connector struct {
+	*connector.Connector
+
+	client            *redis.Client
+	messages, control string
+	maxLen            int64
+	consumer          string
+
+	sendMu  sync.Mutex
+	sends   map[string]chan redisstream.Entry
+	sending sync.WaitGroup
+
+	stop, done chan struct{}
+}
+
+// NewConnector creates a Connector using the redis server at host.
+func NewConnector(host string, options ...func(*Connector) error) (*Connector, error) {
+	client := redis.NewClient(&redis.Options{
+		Addr:     host,
+		Password: "",
+		DB:       0,
+	})
+
+	if _, err := client.Ping().Result(); err != nil {
+		return nil, errors.Wrap(err, "NewConnector Failed")
+	}
+
+	// checked after the options, as WithConsumer can set it
+	consumer, _ := os.Hostname()
+
+	c := &Connector{
+		Connector: connector.NewConnector(),
+		client:    client,
+		messages:  DefaultMessageStream,
+		control:   DefaultControlStream,
+		maxLen:    DefaultMaxLen,
+		consumer:  consumer,
+		sends:     make(map[string]chan redisstream.Entry),
+		stop:      make(chan struct{}),
+		done:      make(chan struct{}),
+	}
+
+	for _, option := range options {
+		if err := option(c); err != nil {
+			return nil, errors.Wrap(err, "NewConnector Failed")
+		}
+	}
+
+	if c.consumer == "" {
+		return nil, errors.Wrap(ErrInvalidConsumer, "NewConnector Failed")
+	}
+
+	if err := redisstream.CreateGroup(client, c.control, ControlGroup); err != nil {
+		return nil, errors.Wrap(err, "NewConnector Failed")
+	}
+
+	c.SetMessageHandler(c.handleMessage)
+	go c.listen()
+
+	return c, nil
+}
+
+// WithStreams sets the names of the message and control streams.
+func WithStreams(messages, control string) func(*Connector) error {
+	return func(c *Connector) error {
+		if messages == "" || control == "" || messages == control {
+			return ErrInvalidStream
+		}
+
+		c.messages, c.control = messages, control
+		return nil
+	}
+}
+
+// WithMaxLen sets about the number of entries kept in the message stream,
+// older entries are dropped whether or not an app read them.
+func WithMaxLen(n int64) func(*Connector) error {
+	return func(c *Connector) error {
+		if n <= 0 {
+			return ErrInvalidMaxLen
+		}
+
+		c.maxLen = n
+		return nil
+	}
+}
+
+// WithConsumer sets the consumer name the control stream is read as, which is the hostname by default.
+// It should stay the same across restarts, so unacknowledged commands are run again.
+func WithConsumer(consumer string) func(*Connector) error {
+	return func(c *Connector) error {
+		if consumer == "" {
+			return ErrInvalidConsumer
+		}
+
+		c.consumer = consumer
+		return nil
+	}
+}
+
+func (c *Connector) handleMessage(msg []byte, team string) {
+	_, err := redisstream.Add(c.client, c.messages, c.maxLen, map[string]string{
+		TeamField:    team,
+		MessageField: string(msg),
+	})
+
+	if err != nil {
+		log.Println(errors.Wrapf(err, "Dropped message for team %s", team))
+	}
+}
+
+// listen runs commands from the control stream until Stop is called.
+func (c *Connector) listen() {
+	defer close(c.done)
+
+	// commands read before a restart, that were never acknowledged
+	id := "0"
+
+	for {
+		select {
+		case <-c.stop:
+			return
+		default:
+		}
+
+		entries, err := redisstream.ReadGroup(c.client, c.control, ControlGroup, c.consumer, id, 10, time.Second)
+		if err != nil {
+			log.Println(errors.Wrap(err, "Could not read commands"))
+
+			select {
+			case <-c.stop:
+				return
+			case <-time.After(time.Second):
+			}
+
+			continue
+		}
+
+		if id != ">" {
+			if len(entries) == 0 {
+				id = ">"
+				continue
+			}
+
+			// queued sends stay pending until they ran
+			id = entries[len(entries)-1].ID
+		}
+
+		for _, e := range entries {
+			if e.Values[CommandField] == CommandSend {
+				c.queueSend(e)
+				continue
+			}
+
+			c.runEntry(e)
+		}
+	}
+}
+
+// queueSend queues a send command for the sender of its team, starting it if needed.
+func (c *Connector) queueSend(e redisstream.Entry) {
+	team := e.Values[TeamField]
+
+	c.sendMu.Lock()
+	q, ok := c.sends[team]
+	if !ok {
+		q = make(chan redisstream.Entry, sendQueueSize)
+		c.sends[team] = q
+
+		c.sending.Add(1)
+		go c.send(q)
+	}
+	c.sendMu.Unlock()
+
+	q <- e
+}
+
+// send runs the send commands of a team until its queue is closed,
+// so waiting for a reply does not hold up the commands of other teams.
+func (c *Connector) send(q <-chan redisstream.Entry) {
+	defer c.sending.Done()
+
+	for e := range q {
+		c.runEntry(e)
+	}
+}
+
+// runEntry runs a command and acknowledges it.
+func (c *Connector) runEntry(e redisstream.Entry) {
+	if err := c.run(e.Values); err != nil {
+		log.Println(errors.Wrapf(err, "Command %s failed for team %s", e.Values[CommandField], e.Values[TeamField]))
+	}
+
+	if err := redisstream.Ack(c.client, c.control, ControlGroup, e.ID); err != nil {
+		log.Println(errors.Wrap(err, "Could not acknowledge command"))
+	}
+}
+
+func (c *Connector) run(cmd map[string]string) error {
+	team := cmd[TeamField]
+
+	switch cmd[CommandField] {
+	case CommandAdd:
+		return c.Open(team, cmd[URLField])
+	case CommandRemove:
+		return c.Connector.Close(team)
+	case CommandTyping:
+		return c.Typing(team, cmd[ChannelField])
+	case CommandSend:
+		// the reply has nowhere to go, but a rejected payload is returned as an error
+		_, err := c.Send(team, []byte(cmd[PayloadField]))
+		return err
+	}
+
+	// also entries that were deleted while pending
+	return errors.New("Unknown Command")
+}
+
+// Stop stops reading commands, waits for the queued sends and closes the redis client, the sockets stay open.
+func (c *Connector) Stop() error {
+	close(c.stop)
+	<-c.done
+
+	c.sendMu.Lock()
+	for team, q := range c.sends {
+		close(q)
+		delete(c.sends, team)
+	}
+	c.sendMu.Unlock()
+
+	c.sending.Wait()
+
+	return c.client.Close()
+}
@@ -0,0 +1,235 @@
+package streamserver
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis"
+	"github.com/gorilla/websocket"
+
+	"suy.io/bots/internal/redisstream"
+	"suy.io/bots/slack/connector"
+)
+
+func TestConnector_handleMessage(t *testing.T) {
+	s, err := miniredis.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer s.Close()
+
+	c, err := NewConnector(s.Addr(), WithMaxLen(10))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer c.Stop()
+
+	c.handleMessage([]byte(`{"type":"message"}`), "T12345678")
+
+	msgs, err := s.Stream(DefaultMessageStream)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(msgs) != 1 {
+		t.Fatalf("message stream = %v, want 1 entry", msgs)
+	}
+}
+
+func TestConnector_listen(t *testing.T) {
+	u := websocket.Upgrader{
+		ReadBufferSize: 1024,
+	}
+
+	ws := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		conn, err := u.Upgrade(res, req, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}))
+
+	defer ws.Close()
+
+	s, err := miniredis.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer s.Close()
+
+	rc := redis.NewClient(&redis.Options{Addr: s.Addr()})
+	defer rc.Close()
+
+	if err := redisstream.CreateGroup(rc, DefaultControlStream, ControlGroup); err != nil {
+		t.Fatal(err)
+	}
+
+	// sent while the connector was down
+	_, err = redisstream.Add(rc, DefaultControlStream, 0, map[string]string{
+		CommandField: CommandAdd,
+		TeamField:    "T12345678",
+		URLField:     strings.Replace(ws.URL, "http", "ws", 1),
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := NewConnector(s.Addr())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer c.Stop()
+
+	for i := 0; c.Typing("T12345678", "") == connector.ErrBotNotFound; i++ {
+		if i == 50 {
+			t.Fatal("add command was not run")
+		}
+
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	_, err = redisstream.Add(rc, DefaultControlStream, 0, map[string]string{
+		CommandField: CommandRemove,
+		TeamField:    "T12345678",
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; c.Typing("T12345678", "") != connector.ErrBotNotFound; i++ {
+		if i == 50 {
+			t.Fatal("remove command was not run")
+		}
+
+		time.Sleep(100 * time.Millisecond)
+	}
+}
+
+func TestConnector_send(t *testing.T) {
+	u := websocket.Upgrader{
+		ReadBufferSize: 1024,
+	}
+
+	received := make(chan struct{}, 1)
+
+	// never replies, so a send waits until the team is closed
+	ws := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		conn, err := u.Upgrade(res, req, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+
+			select {
+			case received <- struct{}{}:
+			default:
+			}
+		}
+	}))
+
+	defer ws.Close()
+
+	s, err := miniredis.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer s.Close()
+
+	rc := redis.NewClient(&redis.Options{Addr: s.Addr()})
+	defer rc.Close()
+
+	c, err := NewConnector(s.Addr())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer c.Stop()
+
+	if err := c.Open("T1", strings.Replace(ws.URL, "http", "ws", 1)); err != nil {
+		t.Fatal(err)
+	}
+
+	commands := []map[string]string{
+		{CommandField: CommandSend, TeamField: "T1", PayloadField: `{"type":"message","channel":"C1","text":"hi"}`},
+		{CommandField: CommandAdd, TeamField: "T2", URLField: strings.Replace(ws.URL, "http", "ws", 1)},
+	}
+
+	for _, cmd := range commands {
+		if _, err := redisstream.Add(rc, DefaultControlStream, 0, cmd); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	select {
+	case <-received:
+	case <-time.After(5 * time.Second):
+		t.Fatal("send command was not run")
+	}
+
+	// the add runs while the send waits for its reply
+	for i := 0; c.Typing("T2", "") == connector.ErrBotNotFound; i++ {
+		if i == 20 {
+			t.Fatal("add command waited for the send")
+		}
+
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	// ends the waiting send
+	c.Connector.Close("T1")
+}
+
+func TestNewConnector(t *testing.T) {
+	s, err := miniredis.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer s.Close()
+
+	tests := []struct {
+		name    string
+		options []func(*Connector) error
+		wantErr bool
+	}{
+		{"", nil, false},
+		{"", []func(*Connector) error{WithStreams("a", "b")}, false},
+		{"", []func(*Connector) error{WithStreams("a", "a")}, true},
+		{"", []func(*Connector) error{WithMaxLen(0)}, true},
+		{"", []func(*Connector) error{WithConsumer("connector-1")}, false},
+		{"", []func(*Connector) error{WithConsumer("")}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := NewConnector(s.Addr(), tt.options...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewConnector() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if c != nil {
+				c.Stop()
+			}
+		})
+	}
+}
@@ -17,7 +17,7 @@ func Test_newInternalConnector(t *testing.T) {
 		name string
 		want *internalConnector
 	}{
-		{"", &internalConnector{connector.NewConnector(), nil}},
+		{"", &internalConnector{conn: connector.NewConnector()}},
 	}
 
 	for _, tt := range tests {
@@ -39,10 +39,17 @@ func Test_internalConnector_Add(t *testing.T) {
 	}
 
 	s := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
-		_, err := u.Upgrade(res, req, nil)
+		conn, err := u.Upgrade(res, req, nil)
 		if err != nil {
 			t.Fatal(err)
 		}
+
+		// keep the connection open, the connector exits on abnormal closures
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
 	}))
 
 	type args struct {
@@ -6,47 +6,198 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/url"
+	"sync"
 
 	"github.com/pkg/errors"
 
 	"suy.io/bots/slack"
 	"suy.io/bots/slack/connector"
+	"suy.io/bots/slack/connector/cluster"
 	"suy.io/bots/slack/connector/contrib/httpserver"
 )
 
+var ErrNoInstances = errors.New("No connector instances are running")
+
+// recentIDs is the number of message ids remembered to discard duplicates.
+const recentIDs = 4096
+
 type Connector struct {
 	url  *url.URL
 	msgs chan *connector.MessagePayload
+
+	// ls is set for connectors talking to a cluster
+	ls cluster.LeaseStore
+
+	seen *seenIDs
+
+	// secret signs and verifies requests, when set
+	secret string
+
+	mu      sync.RWMutex
+	closed  bool
+	done    chan struct{}
+	pending sync.WaitGroup
+}
+
+// seenIDs remembers the last message ids received.
+type seenIDs struct {
+	mu   sync.Mutex
+	ids  map[string]bool
+	ring []string
+	next int
 }
 
-func NewConnector(host string) (*Connector, error) {
+func newSeenIDs(size int) *seenIDs {
+	return &seenIDs{
+		ids:  make(map[string]bool, size),
+		ring: make([]string, size),
+	}
+}
+
+// add adds an id, returning false if it was already seen.
+func (s *seenIDs) add(id string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.ids[id] {
+		return false
+	}
+
+	delete(s.ids, s.ring[s.next])
+	s.ring[s.next] = id
+	s.ids[id] = true
+	s.next = (s.next + 1) % len(s.ring)
+
+	return true
+}
+
+func NewConnector(host string, options ...func(*Connector) error) (*Connector, error) {
 	u, err := url.Parse(host)
 	if err != nil || u.Host == "" {
 		return nil, errors.New("NewConnector failed, invalid URL")
 	}
 
-	return &Connector{
+	c := &Connector{
 		url:  u,
 		msgs: make(chan *connector.MessagePayload),
-	}, nil
+		seen: newSeenIDs(recentIDs),
+		done: make(chan struct{}),
+	}
+
+	for _, option := range options {
+		if err := option(c); err != nil {
+			return nil, errors.Wrap(err, "NewConnector Failed")
+		}
+	}
+
+	return c, nil
+}
+
+// NewClusterConnector creates a Connector for a connector cluster sharing ls,
+// sending the requests for a team to the instance that owns it.
+func NewClusterConnector(ls cluster.LeaseStore, options ...func(*Connector) error) (*Connector, error) {
+	c := &Connector{
+		msgs: make(chan *connector.MessagePayload),
+		ls:   ls,
+		seen: newSeenIDs(recentIDs),
+		done: make(chan struct{}),
+	}
+
+	for _, option := range options {
+		if err := option(c); err != nil {
+			return nil, errors.Wrap(err, "NewClusterConnector Failed")
+		}
+	}
+
+	return c, nil
+}
+
+// WithSecret can be passed to NewConnector or NewClusterConnector to sign the requests sent to the connector,
+// and only accept messages signed with the same secret, like the ones sent by httpserver.WithSecret.
+func WithSecret(secret string) func(*Connector) error {
+	return func(c *Connector) error {
+		if secret == "" {
+			return httpserver.ErrInvalidSecret
+		}
+
+		c.secret = secret
+		return nil
+	}
+}
+
+// instance gets the url of the connector managing a team.
+func (c *Connector) instance(team string) (*url.URL, error) {
+	if c.ls == nil {
+		return c.url, nil
+	}
+
+	members, err := c.ls.Members()
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(members))
+	for id := range members {
+		ids = append(ids, id)
+	}
+
+	id := cluster.NewRing(ids...).Owner(team)
+	if id == "" {
+		return nil, ErrNoInstances
+	}
+
+	u, err := url.Parse(members[id])
+	if err != nil || u.Host == "" {
+		return nil, errors.New("invalid URL for connector " + id)
+	}
+
+	return u, nil
 }
 
-func sendExternalRequest(url *url.URL, data interface{}) error {
+// sendExternalRequest posts data to url, decoding the response body into reply if it is set and there is one.
+func sendExternalRequest(url *url.URL, data interface{}, secret string, reply interface{}) error {
 	// NOTE: cannot use anonymous struct here, as ffjson would not be able to optimize it.
 	body, err := json.Marshal(data)
 	if err != nil {
 		return errors.Wrap(err, "Post Failed")
 	}
 
-	res, err := http.Post(url.String(), "application/json", bytes.NewReader(body))
+	req, err := http.NewRequest(http.MethodPost, url.String(), bytes.NewReader(body))
 	if err != nil {
 		return errors.Wrap(err, "Post Failed")
 	}
 
+	req.Header.Set("Content-Type", "application/json")
+	httpserver.Sign(req, body, secret)
+
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "Post Failed")
+	}
+
+	defer res.Body.Close()
+
 	if res.StatusCode != http.StatusOK {
 		return errors.New("Post Failed" + res.Status)
 	}
 
+	if reply == nil {
+		return nil
+	}
+
+	d, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return errors.Wrap(err, "Post Failed")
+	}
+
+	if len(d) == 0 {
+		return nil
+	}
+
+	if err := json.Unmarshal(d, reply); err != nil {
+		return errors.Wrap(err, "Post Failed")
+	}
+
 	return nil
 }
 
@@ -61,13 +212,40 @@ func (c *Connector) Add(team, socketURL string) error {
 		URL:  socketURL,
 	}
 
-	if err := sendExternalRequest(c.url.ResolveReference(a), p); err != nil {
+	u, err := c.instance(team)
+	if err != nil {
+		return errors.Wrap(err, "Add Failed")
+	}
+
+	if err := sendExternalRequest(u.ResolveReference(a), p, c.secret, nil); err != nil {
 		return errors.Wrap(err, "Add Failed")
 	}
 
 	return nil
 }
 
+func (c *Connector) Remove(team string) error {
+	r, err := url.Parse("./slack/remove")
+	if err != nil {
+		return errors.Wrap(err, "Remove Failed")
+	}
+
+	p := &httpserver.RemovePayload{
+		Team: team,
+	}
+
+	u, err := c.instance(team)
+	if err != nil {
+		return errors.Wrap(err, "Remove Failed")
+	}
+
+	if err := sendExternalRequest(u.ResolveReference(r), p, c.secret, nil); err != nil {
+		return errors.Wrap(err, "Remove Failed")
+	}
+
+	return nil
+}
+
 // Typing Typing
 func (c *Connector) Typing(team, channel string) error {
 	t, err := url.Parse("./slack/typing")
@@ -80,20 +258,97 @@ func (c *Connector) Typing(team, channel string) error {
 		Channel: channel,
 	}
 
-	if err := sendExternalRequest(c.url.ResolveReference(t), p); err != nil {
+	u, err := c.instance(team)
+	if err != nil {
+		return errors.Wrap(err, "Typing Failed")
+	}
+
+	if err := sendExternalRequest(u.ResolveReference(t), p, c.secret, nil); err != nil {
 		return errors.Wrap(err, "Typing Failed")
 	}
 
 	return nil
 }
 
+// Send sends an RTM payload, returning the reply of slack if it acknowledges the payload.
+func (c *Connector) Send(team string, payload []byte) (*connector.Reply, error) {
+	s, err := url.Parse("./slack/send")
+	if err != nil {
+		return nil, errors.Wrap(err, "Send Failed")
+	}
+
+	p := &httpserver.SendPayload{
+		Team:    team,
+		Payload: payload,
+	}
+
+	u, err := c.instance(team)
+	if err != nil {
+		return nil, errors.Wrap(err, "Send Failed")
+	}
+
+	var r *connector.Reply
+	if err := sendExternalRequest(u.ResolveReference(s), p, c.secret, &r); err != nil {
+		return nil, errors.Wrap(err, "Send Failed")
+	}
+
+	if r != nil && r.Error != nil {
+		return r, r.Error
+	}
+
+	return r, nil
+}
+
 func (c *Connector) Messages() <-chan *connector.MessagePayload {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
 	return c.msgs
 }
 
+// Close stops taking messages, messages that were accepted but not received yet are dropped.
 func (c *Connector) Close() {
+	c.mu.Lock()
+	if c.closed {
+		c.mu.Unlock()
+		return
+	}
+
+	c.closed = true
+	c.mu.Unlock()
+
+	close(c.done)
+	c.pending.Wait()
+
+	c.mu.Lock()
 	close(c.msgs)
 	c.msgs = nil
+	c.mu.Unlock()
+}
+
+// deliver passes an accepted message on without waiting for it to be received,
+// returning false once the connector is closed.
+func (c *Connector) deliver(p *connector.MessagePayload) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.closed {
+		return false
+	}
+
+	msgs := c.msgs
+
+	c.pending.Add(1)
+	go func() {
+		defer c.pending.Done()
+
+		select {
+		case msgs <- p:
+		case <-c.done:
+		}
+	}()
+
+	return true
 }
 
 func (c *Connector) ServeHTTP(res http.ResponseWriter, req *http.Request) {
@@ -110,13 +365,29 @@ func (c *Connector) ServeHTTP(res http.ResponseWriter, req *http.Request) {
 		return
 	}
 
+	if err := httpserver.Verify(req, data, c.secret); err != nil {
+		http.Error(res, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+		return
+	}
+
 	p := &connector.MessagePayload{}
 	if err := json.Unmarshal(data, p); err != nil {
 		http.Error(res, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
 		return
 	}
 
-	go func() { c.msgs <- p }()
+	// connectors retry until a message is accepted, so it can arrive more than once
+	if p.ID != "" && !c.seen.add(p.ID) {
+		res.WriteHeader(http.StatusOK)
+		return
+	}
+
+	// the connector keeps retrying messages that are not accepted
+	if !c.deliver(p) {
+		http.Error(res, http.StatusText(http.StatusServiceUnavailable), http.StatusServiceUnavailable)
+		return
+	}
+
 	res.WriteHeader(http.StatusOK)
 }
 
@@ -1,12 +1,20 @@
 package httpclient
 
 import (
+	"encoding/json"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"reflect"
 	"strings"
 	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"suy.io/bots/slack/connector/cluster"
+	"suy.io/bots/slack/connector/contrib/httpserver"
 )
 
 func TestNewConnector(t *testing.T) {
@@ -31,6 +39,8 @@ func TestNewConnector(t *testing.T) {
 			if err == nil {
 				got.msgs = nil
 				got.url = nil
+				got.seen = nil
+				got.done = nil
 			}
 
 			if (err != nil) != tt.wantErr {
@@ -115,7 +125,7 @@ func Test_sendExternalRequest(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if err := sendExternalRequest(tt.args.url, tt.args.data); (err != nil) != tt.wantErr {
+			if err := sendExternalRequest(tt.args.url, tt.args.data, "", nil); (err != nil) != tt.wantErr {
 				t.Errorf("sendExternalRequest() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
@@ -208,6 +218,95 @@ func TestConnector_Typing(t *testing.T) {
 	}
 }
 
+func TestConnector_Remove(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost {
+			t.Errorf("Connector.Remove() Method = %v, wantMethod %v", req.Method, http.MethodPost)
+		}
+
+		if req.URL.Path != "/slack/remove" {
+			t.Errorf("Connector.Remove() Path = %v, want Path %v", req.URL.Path, "/slack/remove")
+		}
+
+		if req.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("Connector.Remove() Content-Type = %v, want Content-Type %v", req.Header.Get("Content-Type"), "application/json")
+		}
+	}))
+
+	c, err := NewConnector(s.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		c       *Connector
+		team    string
+		wantErr bool
+	}{
+		{"", c, "T12345678", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.c.Remove(tt.team); (err != nil) != tt.wantErr {
+				t.Errorf("Connector.Remove() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestConnector_Send(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		p := &httpserver.SendPayload{}
+		if err := json.NewDecoder(req.Body).Decode(p); err != nil {
+			t.Error(err)
+		}
+
+		switch p.Team {
+		case "T1":
+			res.Write([]byte(`{"ok":true,"reply_to":1,"ts":"1.1"}`))
+		case "T2":
+			res.Write([]byte(`{"ok":false,"reply_to":1,"error":{"code":2,"msg":"message text is missing"}}`))
+		case "T3":
+			res.WriteHeader(http.StatusOK)
+		default:
+			res.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	defer s.Close()
+
+	c, err := NewConnector(s.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		team      string
+		wantReply bool
+		wantErr   bool
+	}{
+		{"T1", true, false},
+		{"T2", true, true},
+		{"T3", false, false},
+		{"T4", false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.team, func(t *testing.T) {
+			got, err := c.Send(tt.team, []byte(`{"type":"message"}`))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Connector.Send() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if (got != nil) != tt.wantReply {
+				t.Errorf("Connector.Send() = %v, wantReply %v", got, tt.wantReply)
+			}
+		})
+	}
+}
+
 func TestConnector_ServeHTTP(t *testing.T) {
 	type args struct {
 		res *httptest.ResponseRecorder
@@ -249,3 +348,147 @@ func TestConnector_ServeHTTP(t *testing.T) {
 		})
 	}
 }
+
+func TestConnector_ServeHTTPDuplicates(t *testing.T) {
+	c, err := NewConnector("http://a.a")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	body := `{"team": "T123", "message": {}, "id": "a-1"}`
+	for i := 0; i < 3; i++ {
+		res := httptest.NewRecorder()
+		c.ServeHTTP(res, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body)))
+
+		if res.Code != http.StatusOK {
+			t.Errorf("Connector.ServeHTTP() status = %v, wantStatus %v", res.Code, http.StatusOK)
+		}
+	}
+
+	if p := <-c.msgs; p.ID != "a-1" {
+		t.Errorf("Connector.ServeHTTP() message id = %v, want a-1", p.ID)
+	}
+
+	select {
+	case p := <-c.msgs:
+		t.Errorf("Connector.ServeHTTP() delivered duplicate %v", p.ID)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestConnector_Secret(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		data, _ := ioutil.ReadAll(req.Body)
+		if err := httpserver.Verify(req, data, "secret"); err != nil {
+			res.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+
+	defer s.Close()
+
+	c, err := NewConnector(s.URL, WithSecret("secret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := c.Typing("T123", "C123"); err != nil {
+		t.Errorf("Connector.Typing() error = %v", err)
+	}
+
+	body := `{"team": "T123", "message": {}}`
+
+	res := httptest.NewRecorder()
+	c.ServeHTTP(res, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body)))
+
+	if res.Code != http.StatusUnauthorized {
+		t.Errorf("Connector.ServeHTTP() unsigned status = %v, wantStatus %v", res.Code, http.StatusUnauthorized)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	httpserver.Sign(req, []byte(body), "secret")
+
+	res = httptest.NewRecorder()
+	c.ServeHTTP(res, req)
+
+	if res.Code != http.StatusOK {
+		t.Errorf("Connector.ServeHTTP() signed status = %v, wantStatus %v", res.Code, http.StatusOK)
+	}
+}
+
+func TestNewClusterConnector(t *testing.T) {
+	got := map[string][]string{}
+	instance := func(id string) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+			got[id] = append(got[id], req.URL.Path)
+		}))
+	}
+
+	a, b := instance("a"), instance("b")
+	defer a.Close()
+	defer b.Close()
+
+	ls := cluster.NewMemoryLeaseStore()
+	c, err := NewClusterConnector(ls)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := c.Add("T1", ""); errors.Cause(err) != ErrNoInstances {
+		t.Errorf("Connector.Add() error = %v, want %v", err, ErrNoInstances)
+	}
+
+	ls.Join("a", a.URL, time.Minute)
+	ls.Join("b", b.URL, time.Minute)
+
+	r := cluster.NewRing("a", "b")
+	want := map[string][]string{}
+
+	for _, team := range []string{"T1", "T2", "T3", "T4", "T5", "T6"} {
+		if err := c.Add(team, ""); err != nil {
+			t.Fatalf("Connector.Add() error = %v", err)
+		}
+
+		if err := c.Typing(team, "C1"); err != nil {
+			t.Fatalf("Connector.Typing() error = %v", err)
+		}
+
+		owner := r.Owner(team)
+		want[owner] = append(want[owner], "/slack/add", "/slack/typing")
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Connector requests = %v, want %v", got, want)
+	}
+}
+
+func TestConnector_Close(t *testing.T) {
+	c, err := NewConnector("http://a.a")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	msgs := c.Messages()
+
+	res := httptest.NewRecorder()
+	c.ServeHTTP(res, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"team": "T123", "message": {}, "id": "a-1"}`)))
+
+	if res.Code != http.StatusOK {
+		t.Errorf("Connector.ServeHTTP() status = %v, wantStatus %v", res.Code, http.StatusOK)
+	}
+
+	// the message is still waiting for a receiver
+	c.Close()
+
+	if _, ok := <-msgs; ok {
+		t.Errorf("Connector.Close() did not close messages")
+	}
+
+	res = httptest.NewRecorder()
+	c.ServeHTTP(res, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"team": "T123", "message": {}, "id": "a-2"}`)))
+
+	if res.Code != http.StatusServiceUnavailable {
+		t.Errorf("Connector.ServeHTTP() after Close() status = %v, wantStatus %v", res.Code, http.StatusServiceUnavailable)
+	}
+
+	c.Close()
+}
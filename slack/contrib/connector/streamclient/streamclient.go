@@ -0,0 +1,297 @@
+// Package streamclient implements a slack.Connector talking to a streamserver connector over Redis Streams.
+package streamclient // import "suy.io/bots/slack/contrib/connector/streamclient"
+
+import (
+	"encoding/json"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/go-redis/redis"
+	"github.com/pkg/errors"
+
+	"suy.io/bots/internal/redisstream"
+	"suy.io/bots/slack"
+	"suy.io/bots/slack/connector"
+	"suy.io/bots/slack/connector/contrib/streamserver"
+)
+
+const (
+	// DefaultGroup is the consumer group apps read the message stream as.
+	DefaultGroup = "app"
+
+	// DefaultClaimIdle is how long a message can stay unacknowledged by a consumer,
+	// before another consumer takes it over.
+	DefaultClaimIdle = time.Minute
+)
+
+var ErrInvalidConsumer = errors.New("Invalid Consumer")
+var ErrInvalidGroup = errors.New("Invalid Group")
+var ErrInvalidClaimIdle = errors.New("Invalid Claim Idle Time")
+
+// Connector is a slack.Connector that reads messages from a stream as a consumer of a group,
+// and sends commands to the connector over a control stream.
+//
+// Replicas of an app reading as the same group with different consumer names share the messages.
+// A message is acknowledged once its Ack was called, which the controller does after handling it.
+// The messages a consumer read but did not acknowledge are read again when it restarts with the same name,
+// or are taken over by another consumer once they were idle for the claim idle time. So a message can be
+// received more than once, its ID is the stream entry ID.
+//
+// Commands are sent without waiting for the connector, so Add does not fail if the socket cannot be opened,
+// and Send does not return the reply of slack.
+type Connector struct {
+	client            *redis.Client
+	messages, control string
+	maxLen            int64
+	group, consumer   string
+	claimIdle         time.Duration
+
+	msgs       chan *connector.MessagePayload
+	stop, done chan struct{}
+	closeOnce  sync.Once
+}
+
+// NewConnector creates a Connector using the redis server at host, reading messages as consumer.
+func NewConnector(host, consumer string, options ...func(*Connector) error) (*Connector, error) {
+	if consumer == "" {
+		return nil, errors.Wrap(ErrInvalidConsumer, "NewConnector Failed")
+	}
+
+	client := redis.NewClient(&redis.Options{
+		Addr:     host,
+		Password: "",
+		DB:       0,
+	})
+
+	if _, err := client.Ping().Result(); err != nil {
+		return nil, errors.Wrap(err, "NewConnector Failed")
+	}
+
+	c := &Connector{
+		client:    client,
+		messages:  streamserver.DefaultMessageStream,
+		control:   streamserver.DefaultControlStream,
+		maxLen:    streamserver.DefaultMaxLen,
+		group:     DefaultGroup,
+		consumer:  consumer,
+		claimIdle: DefaultClaimIdle,
+		msgs:      make(chan *connector.MessagePayload),
+		stop:      make(chan struct{}),
+		done:      make(chan struct{}),
+	}
+
+	for _, option := range options {
+		if err := option(c); err != nil {
+			return nil, errors.Wrap(err, "NewConnector Failed")
+		}
+	}
+
+	if err := redisstream.CreateGroup(client, c.messages, c.group); err != nil {
+		return nil, errors.Wrap(err, "NewConnector Failed")
+	}
+
+	go c.listen()
+	return c, nil
+}
+
+// WithStreams sets the names of the message and control streams, they should match the ones of the connector.
+func WithStreams(messages, control string) func(*Connector) error {
+	return func(c *Connector) error {
+		if messages == "" || control == "" || messages == control {
+			return streamserver.ErrInvalidStream
+		}
+
+		c.messages, c.control = messages, control
+		return nil
+	}
+}
+
+// WithGroup sets the consumer group to read messages as, which is DefaultGroup by default.
+// Apps reading as different groups each get every message.
+func WithGroup(group string) func(*Connector) error {
+	return func(c *Connector) error {
+		if group == "" {
+			return ErrInvalidGroup
+		}
+
+		c.group = group
+		return nil
+	}
+}
+
+// WithClaimIdle sets how long a message can stay unacknowledged by a consumer, before it is taken over.
+func WithClaimIdle(d time.Duration) func(*Connector) error {
+	return func(c *Connector) error {
+		if d <= 0 {
+			return ErrInvalidClaimIdle
+		}
+
+		c.claimIdle = d
+		return nil
+	}
+}
+
+func (c *Connector) command(command string, values map[string]string) error {
+	values[streamserver.CommandField] = command
+
+	_, err := redisstream.Add(c.client, c.control, c.maxLen, values)
+	return err
+}
+
+func (c *Connector) Add(team, url string) error {
+	err := c.command(streamserver.CommandAdd, map[string]string{
+		streamserver.TeamField: team,
+		streamserver.URLField:  url,
+	})
+
+	if err != nil {
+		return errors.Wrap(err, "Add Failed")
+	}
+
+	return nil
+}
+
+func (c *Connector) Remove(team string) error {
+	err := c.command(streamserver.CommandRemove, map[string]string{
+		streamserver.TeamField: team,
+	})
+
+	if err != nil {
+		return errors.Wrap(err, "Remove Failed")
+	}
+
+	return nil
+}
+
+func (c *Connector) Typing(team, channel string) error {
+	err := c.command(streamserver.CommandTyping, map[string]string{
+		streamserver.TeamField:    team,
+		streamserver.ChannelField: channel,
+	})
+
+	if err != nil {
+		return errors.Wrap(err, "Typing Failed")
+	}
+
+	return nil
+}
+
+// Send sends an RTM payload. Commands are not answered, so the reply is always nil,
+// and failures are only logged by the connector.
+func (c *Connector) Send(team string, payload []byte) (*connector.Reply, error) {
+	err := c.command(streamserver.CommandSend, map[string]string{
+		streamserver.TeamField:    team,
+		streamserver.PayloadField: string(payload),
+	})
+
+	if err != nil {
+		return nil, errors.Wrap(err, "Send Failed")
+	}
+
+	return nil, nil
+}
+
+func (c *Connector) Messages() <-chan *connector.MessagePayload {
+	return c.msgs
+}
+
+// Close stops reading messages and closes the redis client, it can be called more than once.
+//
+// Messages keeps returning the closed channel, as listen is the only sender and has returned before it is closed.
+func (c *Connector) Close() {
+	c.closeOnce.Do(func() {
+		close(c.stop)
+		<-c.done
+
+		close(c.msgs)
+		c.client.Close()
+	})
+}
+
+// listen reads messages until Close is called.
+func (c *Connector) listen() {
+	defer close(c.done)
+
+	// messages read before a restart, that were never acknowledged
+	id := "0"
+	claimed := time.Now()
+
+	for {
+		select {
+		case <-c.stop:
+			return
+		default:
+		}
+
+		var entries []redisstream.Entry
+		var err error
+
+		if time.Since(claimed) >= c.claimIdle {
+			claimed = time.Now()
+			entries, err = redisstream.Claim(c.client, c.messages, c.group, c.consumer, c.claimIdle, 10)
+		} else {
+			entries, err = redisstream.ReadGroup(c.client, c.messages, c.group, c.consumer, id, 10, time.Second)
+
+			if id != ">" && err == nil {
+				if len(entries) == 0 {
+					id = ">"
+					continue
+				}
+
+				// pending messages stay pending until they were handled
+				id = entries[len(entries)-1].ID
+			}
+		}
+
+		if err != nil {
+			log.Println(errors.Wrap(err, "Could not read messages"))
+
+			select {
+			case <-c.stop:
+				return
+			case <-time.After(time.Second):
+			}
+
+			continue
+		}
+
+		for _, e := range entries {
+			// entries deleted while pending have no message
+			msg := e.Values[streamserver.MessageField]
+			if msg == "" {
+				c.ack(e.ID)
+				continue
+			}
+
+			p := &connector.MessagePayload{
+				Team:    e.Values[streamserver.TeamField],
+				Message: json.RawMessage(msg),
+				ID:      e.ID,
+				Ack:     c.acker(e.ID),
+			}
+
+			select {
+			case c.msgs <- p:
+			case <-c.stop:
+				return
+			}
+		}
+	}
+}
+
+// acker returns the Ack function of a message.
+func (c *Connector) acker(id string) func() {
+	var once sync.Once
+	return func() {
+		once.Do(func() { c.ack(id) })
+	}
+}
+
+func (c *Connector) ack(id string) {
+	if err := redisstream.Ack(c.client, c.messages, c.group, id); err != nil {
+		log.Println(errors.Wrap(err, "Could not acknowledge message"))
+	}
+}
+
+var _ slack.Connector = &Connector{}
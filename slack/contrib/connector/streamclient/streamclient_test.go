@@ -0,0 +1,179 @@
+package streamclient
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis"
+
+	"suy.io/bots/internal/redisstream"
+	"suy.io/bots/slack/connector"
+	"suy.io/bots/slack/connector/contrib/streamserver"
+)
+
+func TestConnector_Messages(t *testing.T) {
+	s, err := miniredis.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer s.Close()
+
+	rc := redis.NewClient(&redis.Options{Addr: s.Addr()})
+	defer rc.Close()
+
+	if _, err := NewConnector(s.Addr(), ""); err == nil {
+		t.Error("NewConnector() without consumer did not fail")
+	}
+
+	if err := redisstream.CreateGroup(rc, streamserver.DefaultMessageStream, DefaultGroup); err != nil {
+		t.Fatal(err)
+	}
+
+	add := func(team string) string {
+		id, err := redisstream.Add(rc, streamserver.DefaultMessageStream, 0, map[string]string{
+			streamserver.TeamField:    team,
+			streamserver.MessageField: `{"type":"message"}`,
+		})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return id
+	}
+
+	// read by a consumer that crashed before acknowledging it
+	pending := add("T1")
+	if _, err := redisstream.ReadGroup(rc, streamserver.DefaultMessageStream, DefaultGroup, "a", ">", 10, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := NewConnector(s.Addr(), "a")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer c.Close()
+
+	next := add("T2")
+
+	var received []*connector.MessagePayload
+	for _, want := range []string{pending, next} {
+		select {
+		case p := <-c.Messages():
+			if p.ID != want || string(p.Message) != `{"type":"message"}` {
+				t.Errorf("Connector.Messages() = %+v, want id %v", p, want)
+			}
+
+			received = append(received, p)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("Connector.Messages() did not receive %v", want)
+		}
+	}
+
+	// messages stay pending until they were handled
+	if n := pendingCount(t, rc); n != 2 {
+		t.Errorf("pending messages = %v, want 2", n)
+	}
+
+	for _, p := range received {
+		p.Ack()
+	}
+
+	if n := pendingCount(t, rc); n != 0 {
+		t.Errorf("pending messages after Ack = %v, want 0", n)
+	}
+}
+
+func pendingCount(t *testing.T, rc *redis.Client) int64 {
+	cmd := redis.NewCmd("XPENDING", streamserver.DefaultMessageStream, DefaultGroup)
+	rc.Process(cmd)
+
+	v, err := cmd.Result()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return v.([]interface{})[0].(int64)
+}
+
+func TestConnector_Commands(t *testing.T) {
+	s, err := miniredis.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer s.Close()
+
+	c, err := NewConnector(s.Addr(), "a")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer c.Close()
+
+	if err := c.Add("T1", "wss://a"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := c.Typing("T1", "C1"); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := c.Send("T1", []byte(`{"type":"ping"}`)); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := c.Remove("T1"); err != nil {
+		t.Fatal(err)
+	}
+
+	msgs, err := s.Stream(streamserver.DefaultControlStream)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	for _, m := range msgs {
+		for i := 0; i+1 < len(m.Values); i += 2 {
+			if m.Values[i] == streamserver.CommandField {
+				got = append(got, m.Values[i+1])
+			}
+		}
+	}
+
+	want := []string{streamserver.CommandAdd, streamserver.CommandTyping, streamserver.CommandSend, streamserver.CommandRemove}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("control stream commands = %v, want %v", got, want)
+	}
+}
+
+func TestConnector_Close(t *testing.T) {
+	s, err := miniredis.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer s.Close()
+
+	c, err := NewConnector(s.Addr(), "app-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	msgs := c.Messages()
+
+	c.Close()
+	c.Close()
+
+	if _, ok := <-msgs; ok {
+		t.Errorf("Connector.Close() did not close messages")
+	}
+
+	if c.Messages() != msgs {
+		t.Errorf("Connector.Messages() changed after Close()")
+	}
+}
@@ -0,0 +1,327 @@
+// Package file implements slack stores persisted to a local file,
+// for single node deployments that need to keep their data across restarts.
+//
+// Every change is synced to disk before it returns, and the file is compacted
+// in the background of writes once it grows too large.
+package file // import "suy.io/bots/slack/contrib/file"
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+
+	"suy.io/bots/internal/filekv"
+	"suy.io/bots/slack"
+	"suy.io/bots/slack/api/oauth"
+)
+
+const (
+	botsBucket          = "bots"
+	conversationsBucket = "conversations"
+	dataBucketPrefix    = "data:"
+)
+
+// FileBotStore is a slack.BotStore storing bots as JSON in a file.
+type FileBotStore struct {
+	db *filekv.DB
+}
+
+// NewFileBotStore opens or creates a FileBotStore at path.
+func NewFileBotStore(path string) (*FileBotStore, error) {
+	db, err := filekv.Open(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "NewFileBotStore Failed")
+	}
+
+	return &FileBotStore{db}, nil
+}
+
+func (bs *FileBotStore) AddBot(p *oauth.AccessResponse) error {
+	d, err := json.Marshal(p)
+	if err != nil {
+		return errors.Wrap(err, "AddBot Failed")
+	}
+
+	k := slack.BotKey(p)
+
+	return bs.db.Update(func(tx *filekv.Tx) error {
+		if _, ok := tx.Get(botsBucket, k); ok {
+			return slack.ErrBotAlreadyAdded
+		}
+
+		tx.Put(botsBucket, k, string(d))
+		return nil
+	})
+}
+
+func (bs *FileBotStore) GetBot(key string) (*oauth.AccessResponse, error) {
+	d, ok := bs.db.Get(botsBucket, key)
+	if !ok {
+		return nil, slack.ErrBotNotFound
+	}
+
+	return decodeBot(d)
+}
+
+func (bs *FileBotStore) UpdateBot(p *oauth.AccessResponse) error {
+	d, err := json.Marshal(p)
+	if err != nil {
+		return errors.Wrap(err, "UpdateBot Failed")
+	}
+
+	k := slack.BotKey(p)
+
+	return bs.db.Update(func(tx *filekv.Tx) error {
+		if _, ok := tx.Get(botsBucket, k); !ok {
+			return slack.ErrBotNotFound
+		}
+
+		tx.Put(botsBucket, k, string(d))
+		return nil
+	})
+}
+
+func (bs *FileBotStore) RemoveBot(key string) error {
+	return bs.db.Update(func(tx *filekv.Tx) error {
+		if _, ok := tx.Get(botsBucket, key); !ok {
+			return slack.ErrBotNotFound
+		}
+
+		tx.Delete(botsBucket, key)
+		return nil
+	})
+}
+
+func (bs *FileBotStore) AllBots() ([]*oauth.AccessResponse, error) {
+	keys := bs.db.Keys(botsBucket)
+
+	bots := make([]*oauth.AccessResponse, 0, len(keys))
+	for _, k := range keys {
+		d, ok := bs.db.Get(botsBucket, k)
+		if !ok {
+			// removed since the keys were read
+			continue
+		}
+
+		p, err := decodeBot(d)
+		if err != nil {
+			return nil, errors.Wrap(err, "AllBots Failed")
+		}
+
+		bots = append(bots, p)
+	}
+
+	return bots, nil
+}
+
+// Compact rewrites the file with only the bots currently stored.
+func (bs *FileBotStore) Compact() error {
+	return bs.db.Compact()
+}
+
+// Close closes the file.
+func (bs *FileBotStore) Close() error {
+	return bs.db.Close()
+}
+
+func decodeBot(d string) (*oauth.AccessResponse, error) {
+	p := &oauth.AccessResponse{
+		Bot: &oauth.Bot{},
+	}
+
+	if err := json.Unmarshal([]byte(d), p); err != nil {
+		return nil, errors.Wrap(err, "Could not decode bot")
+	}
+
+	return p, nil
+}
+
+var _ slack.UpdateBotStore = &FileBotStore{}
+
+type conversation struct {
+	ID      string `json:"id"`
+	State   string `json:"state"`
+	Version int64  `json:"version"`
+}
+
+// FileConversationStore is a slack.ConversationStore storing conversations in a file.
+type FileConversationStore struct {
+	db *filekv.DB
+}
+
+// NewFileConversationStore opens or creates a FileConversationStore at path.
+func NewFileConversationStore(path string) (*FileConversationStore, error) {
+	db, err := filekv.Open(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "NewFileConversationStore Failed")
+	}
+
+	return &FileConversationStore{db}, nil
+}
+
+func conversationKey(user, channel, team string) string {
+	return team + ":" + channel + ":" + user
+}
+
+func getConversation(get func(string, string) (string, bool), k string) (*conversation, error) {
+	d, ok := get(conversationsBucket, k)
+	if !ok {
+		return nil, slack.ErrConversationNotFound
+	}
+
+	c := &conversation{}
+	if err := json.Unmarshal([]byte(d), c); err != nil {
+		return nil, errors.Wrap(err, "Could not decode conversation")
+	}
+
+	return c, nil
+}
+
+func putConversation(tx *filekv.Tx, k string, c *conversation) error {
+	d, err := json.Marshal(c)
+	if err != nil {
+		return err
+	}
+
+	tx.Put(conversationsBucket, k, string(d))
+	return nil
+}
+
+func (cs *FileConversationStore) Start(user, channel, team, id string) error {
+	k := conversationKey(user, channel, team)
+
+	return cs.db.Update(func(tx *filekv.Tx) error {
+		if _, ok := tx.Get(conversationsBucket, k); ok {
+			return slack.ErrConversationExists
+		}
+
+		tx.DeleteBucket(dataBucketPrefix + k)
+
+		if err := putConversation(tx, k, &conversation{id, "start", 1}); err != nil {
+			return errors.Wrap(err, "Start Failed")
+		}
+
+		return nil
+	})
+}
+
+func (cs *FileConversationStore) IsActive(user, channel, team string) bool {
+	_, ok := cs.db.Get(conversationsBucket, conversationKey(user, channel, team))
+	return ok
+}
+
+func (cs *FileConversationStore) Active(user, channel, team string) (id, state string, err error) {
+	c, err := getConversation(cs.db.Get, conversationKey(user, channel, team))
+	if err != nil {
+		return "", "", err
+	}
+
+	return c.ID, c.State, nil
+}
+
+func (cs *FileConversationStore) SetState(user, channel, team, state string) error {
+	k := conversationKey(user, channel, team)
+
+	return cs.db.Update(func(tx *filekv.Tx) error {
+		c, err := getConversation(tx.Get, k)
+		if err != nil {
+			return err
+		}
+
+		c.State, c.Version = state, c.Version+1
+		if err := putConversation(tx, k, c); err != nil {
+			return errors.Wrap(err, "SetState Failed")
+		}
+
+		return nil
+	})
+}
+
+func (cs *FileConversationStore) ActiveVersion(user, channel, team string) (id, state string, version int64, err error) {
+	c, err := getConversation(cs.db.Get, conversationKey(user, channel, team))
+	if err != nil {
+		return "", "", 0, err
+	}
+
+	return c.ID, c.State, c.Version, nil
+}
+
+func (cs *FileConversationStore) CompareAndSetState(user, channel, team string, version int64, state string) (int64, error) {
+	k := conversationKey(user, channel, team)
+
+	var ans int64
+	err := cs.db.Update(func(tx *filekv.Tx) error {
+		c, err := getConversation(tx.Get, k)
+		if err != nil {
+			return err
+		}
+
+		if c.Version != version {
+			return slack.ErrStateChanged
+		}
+
+		c.State, c.Version = state, c.Version+1
+		if err := putConversation(tx, k, c); err != nil {
+			return errors.Wrap(err, "CompareAndSetState Failed")
+		}
+
+		ans = c.Version
+		return nil
+	})
+
+	return ans, err
+}
+
+func (cs *FileConversationStore) SetData(user, channel, team, key, value string) error {
+	k := conversationKey(user, channel, team)
+
+	return cs.db.Update(func(tx *filekv.Tx) error {
+		if _, ok := tx.Get(conversationsBucket, k); !ok {
+			return slack.ErrConversationNotFound
+		}
+
+		tx.Put(dataBucketPrefix+k, key, value)
+		return nil
+	})
+}
+
+func (cs *FileConversationStore) GetData(user, channel, team, key string) (string, error) {
+	k := conversationKey(user, channel, team)
+
+	if _, ok := cs.db.Get(conversationsBucket, k); !ok {
+		return "", slack.ErrConversationNotFound
+	}
+
+	value, ok := cs.db.Get(dataBucketPrefix+k, key)
+	if !ok {
+		return "", slack.ErrItemNotFound
+	}
+
+	return value, nil
+}
+
+func (cs *FileConversationStore) End(user, channel, team string) error {
+	k := conversationKey(user, channel, team)
+
+	return cs.db.Update(func(tx *filekv.Tx) error {
+		if _, ok := tx.Get(conversationsBucket, k); !ok {
+			return slack.ErrConversationNotFound
+		}
+
+		tx.Delete(conversationsBucket, k)
+		tx.DeleteBucket(dataBucketPrefix + k)
+		return nil
+	})
+}
+
+// Compact rewrites the file with only the conversations currently active.
+func (cs *FileConversationStore) Compact() error {
+	return cs.db.Compact()
+}
+
+// Close closes the file.
+func (cs *FileConversationStore) Close() error {
+	return cs.db.Close()
+}
+
+var _ slack.VersionedConversationStore = &FileConversationStore{}
@@ -0,0 +1,212 @@
+package file
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"suy.io/bots/slack"
+	"suy.io/bots/slack/api/oauth"
+	"suy.io/bots/slack/storetest"
+)
+
+func testPath(t *testing.T) (string, func()) {
+	dir, err := ioutil.TempDir("", "slackfile")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return filepath.Join(dir, "test.db"), func() { os.RemoveAll(dir) }
+}
+
+func TestFileBotStore(t *testing.T) {
+	path, done := testPath(t)
+	defer done()
+
+	bs, err := NewFileBotStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p1 := &oauth.AccessResponse{TeamID: "T1", Bot: &oauth.Bot{BotUserID: "U1", BotAccessToken: "xoxb-1"}}
+	p2 := &oauth.AccessResponse{TeamID: "T2", EnterpriseID: "E1", Bot: &oauth.Bot{BotUserID: "U2", BotAccessToken: "xoxb-2"}}
+	p3 := &oauth.AccessResponse{TeamID: "T3", Bot: &oauth.Bot{BotUserID: "U3", BotAccessToken: "xoxb-3"}}
+
+	for _, p := range []*oauth.AccessResponse{p1, p2, p3} {
+		if err := bs.AddBot(p); err != nil {
+			t.Fatalf("FileBotStore.AddBot() error = %v", err)
+		}
+	}
+
+	if err := bs.AddBot(p1); err != slack.ErrBotAlreadyAdded {
+		t.Errorf("FileBotStore.AddBot() error = %v, want %v", err, slack.ErrBotAlreadyAdded)
+	}
+
+	if err := bs.RemoveBot("T3"); err != nil {
+		t.Errorf("FileBotStore.RemoveBot() error = %v", err)
+	}
+
+	if err := bs.RemoveBot("T3"); err != slack.ErrBotNotFound {
+		t.Errorf("FileBotStore.RemoveBot() error = %v, want %v", err, slack.ErrBotNotFound)
+	}
+
+	// bots have to survive reopening the file
+	bs.Close()
+
+	bs, err = NewFileBotStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer bs.Close()
+
+	tests := []struct {
+		name    string
+		key     string
+		want    *oauth.AccessResponse
+		wantErr error
+	}{
+		{"", "T1", p1, nil},
+		{"", "E1/T2", p2, nil},
+		{"", "T2", nil, slack.ErrBotNotFound},
+		{"", "T3", nil, slack.ErrBotNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := bs.GetBot(tt.key)
+			if err != tt.wantErr {
+				t.Errorf("FileBotStore.GetBot() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FileBotStore.GetBot() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	all, err := bs.AllBots()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(all, []*oauth.AccessResponse{p2, p1}) {
+		t.Errorf("FileBotStore.AllBots() = %v, want %v", all, []*oauth.AccessResponse{p2, p1})
+	}
+}
+
+func TestFileConversationStore(t *testing.T) {
+	path, done := testPath(t)
+	defer done()
+
+	cs, err := NewFileConversationStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if cs.IsActive("U1", "C1", "T1") {
+		t.Errorf("FileConversationStore.IsActive() = true before Start")
+	}
+
+	if err := cs.SetData("U1", "C1", "T1", "foo", "bar"); err != slack.ErrConversationNotFound {
+		t.Errorf("FileConversationStore.SetData() error = %v, want %v", err, slack.ErrConversationNotFound)
+	}
+
+	if err := cs.Start("U1", "C1", "T1", "conv"); err != nil {
+		t.Fatalf("FileConversationStore.Start() error = %v", err)
+	}
+
+	if err := cs.Start("U1", "C1", "T1", "conv"); err != slack.ErrConversationExists {
+		t.Errorf("FileConversationStore.Start() error = %v, want %v", err, slack.ErrConversationExists)
+	}
+
+	cs.SetState("U1", "C1", "T1", "next")
+	cs.SetData("U1", "C1", "T1", "foo", "bar")
+	cs.SetData("U1", "C1", "T1", "foo", "baz")
+
+	cs.Close()
+
+	cs, err = NewFileConversationStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer cs.Close()
+
+	if id, state, err := cs.Active("U1", "C1", "T1"); err != nil || id != "conv" || state != "next" {
+		t.Errorf("FileConversationStore.Active() = %v, %v, %v, want conv, next", id, state, err)
+	}
+
+	if v, err := cs.GetData("U1", "C1", "T1", "foo"); err != nil || v != "baz" {
+		t.Errorf("FileConversationStore.GetData() = %v, %v, want baz", v, err)
+	}
+
+	if _, err := cs.GetData("U1", "C1", "T1", "nope"); err != slack.ErrItemNotFound {
+		t.Errorf("FileConversationStore.GetData() error = %v, want %v", err, slack.ErrItemNotFound)
+	}
+
+	if err := cs.End("U1", "C1", "T1"); err != nil {
+		t.Errorf("FileConversationStore.End() error = %v", err)
+	}
+
+	if err := cs.End("U1", "C1", "T1"); err != slack.ErrConversationNotFound {
+		t.Errorf("FileConversationStore.End() error = %v, want %v", err, slack.ErrConversationNotFound)
+	}
+
+	if err := cs.Compact(); err != nil {
+		t.Errorf("FileConversationStore.Compact() error = %v", err)
+	}
+
+	cs.Start("U1", "C1", "T1", "conv")
+	if _, err := cs.GetData("U1", "C1", "T1", "foo"); err != slack.ErrItemNotFound {
+		t.Errorf("FileConversationStore.GetData() kept data from an ended conversation")
+	}
+}
+
+func TestFileStoresConformance(t *testing.T) {
+	dir, err := ioutil.TempDir("", "slackfile")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	n := 0
+	path := func() string {
+		n++
+		return filepath.Join(dir, fmt.Sprintf("%d.db", n))
+	}
+
+	newBotStore := func() slack.BotStore {
+		bs, err := NewFileBotStore(path())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return bs
+	}
+
+	newConversationStore := func() slack.ConversationStore {
+		cs, err := NewFileConversationStore(path())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return cs
+	}
+
+	storetest.TestBotStore(t, newBotStore)
+	storetest.TestUpdateBotStore(t, func() slack.UpdateBotStore {
+		return newBotStore().(slack.UpdateBotStore)
+	})
+	storetest.TestBotStoreConcurrent(t, newBotStore)
+	storetest.TestConversationStore(t, newConversationStore)
+	storetest.TestConversationStoreConcurrent(t, newConversationStore)
+	storetest.TestVersionedConversationStore(t, func() slack.VersionedConversationStore {
+		return newConversationStore().(slack.VersionedConversationStore)
+	})
+}
@@ -3,11 +3,14 @@ package redis
 import (
 	"encoding/json"
 	"log"
+	"strconv"
+	"time"
 
 	"github.com/go-redis/redis"
 
 	"suy.io/bots/slack"
 	"suy.io/bots/slack/api/oauth"
+	"suy.io/bots/slack/connector/cluster"
 )
 
 type RedisBotStore struct {
@@ -29,17 +32,22 @@ func NewRedisBotStore(host string) *RedisBotStore {
 }
 
 func (bs *RedisBotStore) AddBot(p *oauth.AccessResponse) error {
-	log.Println("Adding Bot For team", p.TeamID)
+	log.Println("Adding Bot For team", slack.BotKey(p))
 
 	d, err := json.Marshal(p)
 	if err != nil {
 		return err
 	}
 
-	if err := bs.client.Set(p.TeamID, d, 0).Err(); err != nil {
+	ok, err := bs.client.SetNX(slack.BotKey(p), d, 0).Result()
+	if err != nil {
 		return err
 	}
 
+	if !ok {
+		return slack.ErrBotAlreadyAdded
+	}
+
 	return nil
 }
 
@@ -47,6 +55,10 @@ func (bs *RedisBotStore) GetBot(team string) (*oauth.AccessResponse, error) {
 	log.Println("Getting Bot For team", team)
 
 	d, err := bs.client.Get(team).Result()
+	if err == redis.Nil {
+		return nil, slack.ErrBotNotFound
+	}
+
 	if err != nil {
 		return nil, err
 	}
@@ -62,13 +74,38 @@ func (bs *RedisBotStore) GetBot(team string) (*oauth.AccessResponse, error) {
 	return p, nil
 }
 
+func (bs *RedisBotStore) UpdateBot(p *oauth.AccessResponse) error {
+	log.Println("Updating Bot For team", slack.BotKey(p))
+
+	d, err := json.Marshal(p)
+	if err != nil {
+		return err
+	}
+
+	ok, err := bs.client.SetXX(slack.BotKey(p), d, 0).Result()
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		return slack.ErrBotNotFound
+	}
+
+	return nil
+}
+
 func (bs *RedisBotStore) RemoveBot(team string) error {
 	log.Println("Removing Bot For team", team)
 
-	if err := bs.client.Del(team).Err(); err != nil {
+	n, err := bs.client.Del(team).Result()
+	if err != nil {
 		return err
 	}
 
+	if n == 0 {
+		return slack.ErrBotNotFound
+	}
+
 	return nil
 }
 
@@ -82,9 +119,14 @@ func (bs *RedisBotStore) AllBots() ([]*oauth.AccessResponse, error) {
 
 	bots := make([]*oauth.AccessResponse, 0, len(keys))
 	for _, k := range keys {
+		// the database can be shared with other stores, skip keys that are not bots
+		if t, err := bs.client.Type(k).Result(); err != nil || t != "string" {
+			continue
+		}
+
 		p, err := bs.GetBot(k)
-		if err != nil {
-			return nil, err
+		if err != nil || p.TeamID == "" && p.EnterpriseID == "" {
+			continue
 		}
 
 		bots = append(bots, p)
@@ -93,7 +135,7 @@ func (bs *RedisBotStore) AllBots() ([]*oauth.AccessResponse, error) {
 	return bots, nil
 }
 
-var _ slack.BotStore = &RedisBotStore{}
+var _ slack.UpdateBotStore = &RedisBotStore{}
 
 type RedisConversationStore struct {
 	client *redis.Client
@@ -113,50 +155,355 @@ func NewRedisConversationStore(host string) *RedisConversationStore {
 	return &RedisConversationStore{c}
 }
 
+func conversationKey(user, channel, team string) string {
+	return team + "/" + channel + "/" + user
+}
+
+// startScript starts a conversation if none is active, returning 0 if one is.
+//
+// Data left behind by a conversation that was not ended cleanly is cleared.
+var startScript = redis.NewScript(`
+if redis.call("SETNX", KEYS[1], ARGV[1]) == 0 then
+	return 0
+end
+
+redis.call("DEL", KEYS[4])
+redis.call("SET", KEYS[3], 1)
+redis.call("SET", KEYS[2], "start")
+return 1
+`)
+
 func (cs *RedisConversationStore) Start(user, channel, team, id string) error {
-	if err := cs.client.Set(team+"/"+channel+"/"+user, id, 0).Err(); err != nil {
+	k := conversationKey(user, channel, team)
+
+	res, err := startScript.Run(cs.client, []string{k, k + ":state", k + ":version", k + ":data"}, id).Result()
+	if err != nil {
 		return err
 	}
 
-	return cs.client.Set(team+"/"+channel+"/"+user+":state", "start", 0).Err()
+	if n, _ := res.(int64); n == 0 {
+		return slack.ErrConversationExists
+	}
+
+	return nil
 }
 
 func (cs *RedisConversationStore) IsActive(user, channel, team string) bool {
-	return cs.client.Get(team+"/"+channel+"/"+user).Err() == nil
+	return cs.client.Get(conversationKey(user, channel, team)).Err() == nil
+}
+
+// active checks that a conversation is active, before changing it.
+func (cs *RedisConversationStore) active(k string) error {
+	n, err := cs.client.Exists(k).Result()
+	if err != nil {
+		return err
+	}
+
+	if n == 0 {
+		return slack.ErrConversationNotFound
+	}
+
+	return nil
 }
 
 func (cs *RedisConversationStore) Active(user, channel, team string) (id, state string, err error) {
-	id, err = cs.client.Get(team + "/" + channel + "/" + user).Result()
+	k := conversationKey(user, channel, team)
+
+	id, err = cs.client.Get(k).Result()
+	if err == redis.Nil {
+		return "", "", slack.ErrConversationNotFound
+	}
+
 	if err != nil {
 		return "", "", err
 	}
 
-	state = cs.client.Get(team + "/" + channel + "/" + user + ":state").String()
+	state, err = cs.client.Get(k + ":state").Result()
+	if err == redis.Nil {
+		err = nil
+	}
+
 	return
 }
 
 func (cs *RedisConversationStore) SetState(user, channel, team, state string) error {
-	return cs.client.Set(team+"/"+channel+"/"+user+":state", state, 0).Err()
+	k := conversationKey(user, channel, team)
+	if err := cs.active(k); err != nil {
+		return err
+	}
+
+	if err := cs.client.Set(k+":state", state, 0).Err(); err != nil {
+		return err
+	}
+
+	return cs.client.Incr(k + ":version").Err()
+}
+
+func (cs *RedisConversationStore) ActiveVersion(user, channel, team string) (id, state string, version int64, err error) {
+	k := conversationKey(user, channel, team)
+
+	vals, err := cs.client.MGet(k, k+":state", k+":version").Result()
+	if err != nil {
+		return "", "", 0, err
+	}
+
+	id, ok := vals[0].(string)
+	if !ok {
+		return "", "", 0, slack.ErrConversationNotFound
+	}
+
+	state, _ = vals[1].(string)
+
+	// conversations started before versions were stored have none
+	if v, ok := vals[2].(string); ok {
+		version, err = strconv.ParseInt(v, 10, 64)
+	}
+
+	return
+}
+
+// casScript sets the state only if the version did not change,
+// returning -1 if the conversation is not active and -2 if it was changed.
+var casScript = redis.NewScript(`
+if redis.call("EXISTS", KEYS[1]) == 0 then
+	return -1
+end
+
+if redis.call("GET", KEYS[3]) ~= ARGV[1] then
+	return -2
+end
+
+redis.call("SET", KEYS[2], ARGV[2])
+return redis.call("INCR", KEYS[3])
+`)
+
+func (cs *RedisConversationStore) CompareAndSetState(user, channel, team string, version int64, state string) (int64, error) {
+	k := conversationKey(user, channel, team)
+
+	res, err := casScript.Run(cs.client, []string{k, k + ":state", k + ":version"}, version, state).Result()
+	if err != nil {
+		return 0, err
+	}
+
+	n, _ := res.(int64)
+
+	switch n {
+	case -1:
+		return 0, slack.ErrConversationNotFound
+	case -2:
+		return 0, slack.ErrStateChanged
+	}
+
+	return n, nil
 }
 
 func (cs *RedisConversationStore) SetData(user, channel, team, key, value string) error {
-	return cs.client.HSet(team+"/"+channel+"/"+user+":data", key, value).Err()
+	k := conversationKey(user, channel, team)
+	if err := cs.active(k); err != nil {
+		return err
+	}
+
+	return cs.client.HSet(k+":data", key, value).Err()
 }
 
 func (cs *RedisConversationStore) GetData(user, channel, team, key string) (string, error) {
-	return cs.client.HGet(team+"/"+channel+"/"+user+":data", key).Result()
+	k := conversationKey(user, channel, team)
+	if err := cs.active(k); err != nil {
+		return "", err
+	}
+
+	v, err := cs.client.HGet(k+":data", key).Result()
+	if err == redis.Nil {
+		return "", slack.ErrItemNotFound
+	}
+
+	return v, err
 }
 
 func (cs *RedisConversationStore) End(user, channel, team string) error {
-	if err := cs.client.HDel(team + "/" + channel + "/" + user + ":data").Err(); err != nil {
+	k := conversationKey(user, channel, team)
+
+	n, err := cs.client.Del(k).Result()
+	if err != nil {
+		return err
+	}
+
+	if n == 0 {
+		return slack.ErrConversationNotFound
+	}
+
+	return cs.client.Del(k+":state", k+":version", k+":data").Err()
+}
+
+var _ slack.VersionedConversationStore = &RedisConversationStore{}
+
+type RedisStateStore struct {
+	client *redis.Client
+	ttl    time.Duration
+}
+
+func NewRedisStateStore(host string, ttl time.Duration) *RedisStateStore {
+	c := redis.NewClient(&redis.Options{
+		Addr:     host,
+		Password: "",
+		DB:       0,
+	})
+
+	if _, err := c.Ping().Result(); err != nil {
+		log.Fatal(err)
+	}
+
+	return &RedisStateStore{c, ttl}
+}
+
+func (ss *RedisStateStore) NewState() (string, error) {
+	state, err := slack.RandomState()
+	if err != nil {
+		return "", err
+	}
+
+	if err := ss.client.Set("oauth_state:"+state, 1, ss.ttl).Err(); err != nil {
+		return "", err
+	}
+
+	return state, nil
+}
+
+func (ss *RedisStateStore) VerifyState(state string) error {
+	// deleting makes the check and the consumption atomic, expired states are already gone
+	n, err := ss.client.Del("oauth_state:" + state).Result()
+	if err != nil {
 		return err
 	}
 
-	if err := cs.client.Del(team + "/" + channel + "/" + user + ":state").Err(); err != nil {
+	if n == 0 {
+		return slack.ErrInvalidState
+	}
+
+	return nil
+}
+
+var _ slack.StateStore = &RedisStateStore{}
+
+type RedisSeenStore struct {
+	client *redis.Client
+	ttl    time.Duration
+}
+
+func NewRedisSeenStore(host string, ttl time.Duration) *RedisSeenStore {
+	c := redis.NewClient(&redis.Options{
+		Addr:     host,
+		Password: "",
+		DB:       0,
+	})
+
+	if _, err := c.Ping().Result(); err != nil {
+		log.Fatal(err)
+	}
+
+	return &RedisSeenStore{c, ttl}
+}
+
+func (ss *RedisSeenStore) Seen(id string) (bool, error) {
+	// SETNX only sets ids that are not there, expired ids are already gone
+	set, err := ss.client.SetNX("seen:"+id, 1, ss.ttl).Result()
+	if err != nil {
+		return false, err
+	}
+
+	return !set, nil
+}
+
+var _ slack.SeenStore = &RedisSeenStore{}
+
+const (
+	leasesKey = "connector:leases"
+	addrsKey  = "connector:addrs"
+	teamsKey  = "connector:teams"
+)
+
+// RedisLeaseStore is a cluster.LeaseStore for connector clusters, keeping leases in
+// a sorted set scored by their expiry.
+type RedisLeaseStore struct {
+	client *redis.Client
+}
+
+func NewRedisLeaseStore(host string) *RedisLeaseStore {
+	c := redis.NewClient(&redis.Options{
+		Addr:     host,
+		Password: "",
+		DB:       0,
+	})
+
+	if _, err := c.Ping().Result(); err != nil {
+		log.Fatal(err)
+	}
+
+	return &RedisLeaseStore{c}
+}
+
+func millis(t time.Time) float64 {
+	return float64(t.UnixNano() / int64(time.Millisecond))
+}
+
+func (ls *RedisLeaseStore) Join(id, addr string, ttl time.Duration) error {
+	if err := ls.client.HSet(addrsKey, id, addr).Err(); err != nil {
 		return err
 	}
 
-	return cs.client.Del(team + "/" + channel + "/" + user).Err()
+	return ls.client.ZAdd(leasesKey, redis.Z{Score: millis(time.Now().Add(ttl)), Member: id}).Err()
+}
+
+func (ls *RedisLeaseStore) Leave(id string) error {
+	if err := ls.client.ZRem(leasesKey, id).Err(); err != nil {
+		return err
+	}
+
+	return ls.client.HDel(addrsKey, id).Err()
+}
+
+func (ls *RedisLeaseStore) Members() (map[string]string, error) {
+	now := strconv.FormatFloat(millis(time.Now()), 'f', 0, 64)
+
+	// drop expired leases, so instances that died do not pile up
+	if err := ls.client.ZRemRangeByScore(leasesKey, "-inf", "("+now).Err(); err != nil {
+		return nil, err
+	}
+
+	ids, err := ls.client.ZRangeByScore(leasesKey, redis.ZRangeBy{Min: "(" + now, Max: "+inf"}).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	members := make(map[string]string, len(ids))
+	if len(ids) == 0 {
+		return members, nil
+	}
+
+	addrs, err := ls.client.HMGet(addrsKey, ids...).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	for i, id := range ids {
+		if addr, ok := addrs[i].(string); ok {
+			members[id] = addr
+		}
+	}
+
+	return members, nil
+}
+
+func (ls *RedisLeaseStore) SetTeam(team, url string) error {
+	return ls.client.HSet(teamsKey, team, url).Err()
+}
+
+func (ls *RedisLeaseStore) RemoveTeam(team string) error {
+	return ls.client.HDel(teamsKey, team).Err()
+}
+
+func (ls *RedisLeaseStore) Teams() (map[string]string, error) {
+	return ls.client.HGetAll(teamsKey).Result()
 }
 
-var _ slack.ConversationStore = &RedisConversationStore{}
+var _ cluster.LeaseStore = &RedisLeaseStore{}
@@ -0,0 +1,81 @@
+package redis
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+
+	"suy.io/bots/slack"
+	"suy.io/bots/slack/storetest"
+)
+
+func TestRedisStoresConformance(t *testing.T) {
+	s, err := miniredis.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer s.Close()
+
+	newBotStore := func() slack.BotStore {
+		s.FlushAll()
+		return NewRedisBotStore(s.Addr())
+	}
+
+	newConversationStore := func() slack.ConversationStore {
+		s.FlushAll()
+		return NewRedisConversationStore(s.Addr())
+	}
+
+	storetest.TestBotStore(t, newBotStore)
+	storetest.TestUpdateBotStore(t, func() slack.UpdateBotStore {
+		return newBotStore().(slack.UpdateBotStore)
+	})
+	storetest.TestBotStoreConcurrent(t, newBotStore)
+	storetest.TestConversationStore(t, newConversationStore)
+	storetest.TestConversationStoreConcurrent(t, newConversationStore)
+	storetest.TestVersionedConversationStore(t, func() slack.VersionedConversationStore {
+		return newConversationStore().(slack.VersionedConversationStore)
+	})
+	storetest.TestSeenStore(t, func() slack.SeenStore {
+		s.FlushAll()
+		return NewRedisSeenStore(s.Addr(), slack.DefaultSeenTTL)
+	})
+}
+
+func TestRedisLeaseStore(t *testing.T) {
+	s, err := miniredis.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer s.Close()
+
+	ls := NewRedisLeaseStore(s.Addr())
+
+	ls.Join("a", "http://a", time.Minute)
+	ls.Join("b", "http://b", time.Minute)
+	ls.Join("c", "http://c", -time.Second)
+
+	if got, err := ls.Members(); err != nil || !reflect.DeepEqual(got, map[string]string{"a": "http://a", "b": "http://b"}) {
+		t.Errorf("RedisLeaseStore.Members() = %v, %v, want a and b", got, err)
+	}
+
+	ls.Join("c", "http://c", time.Minute)
+	ls.Leave("a")
+
+	if got, err := ls.Members(); err != nil || !reflect.DeepEqual(got, map[string]string{"b": "http://b", "c": "http://c"}) {
+		t.Errorf("RedisLeaseStore.Members() = %v, %v, want b and c", got, err)
+	}
+
+	ls.SetTeam("T1", "wss://1")
+	ls.SetTeam("T2", "wss://2")
+	ls.SetTeam("T1", "wss://3")
+	ls.RemoveTeam("T2")
+
+	if got, err := ls.Teams(); err != nil || !reflect.DeepEqual(got, map[string]string{"T1": "wss://3"}) {
+		t.Errorf("RedisLeaseStore.Teams() = %v, %v, want T1", got, err)
+	}
+}
@@ -0,0 +1,413 @@
+// Package sql implements slack stores over database/sql.
+//
+// Queries are written to run on both PostgreSQL and SQLite (3.24 or later),
+// the database driver has to be imported by the application.
+package sql // import "suy.io/bots/slack/contrib/sql"
+
+import (
+	"database/sql"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+
+	"suy.io/bots/slack"
+	"suy.io/bots/slack/api/oauth"
+)
+
+// migrations are the schema changes applied by Migrate, in order.
+//
+// Never edit a released migration, add a new one instead.
+var migrations = []string{
+	`CREATE TABLE slack_bots (
+		bot_key TEXT PRIMARY KEY,
+		team_id TEXT NOT NULL,
+		enterprise_id TEXT NOT NULL,
+		payload TEXT NOT NULL
+	)`,
+
+	`CREATE TABLE slack_conversations (
+		team TEXT NOT NULL,
+		channel TEXT NOT NULL,
+		user_id TEXT NOT NULL,
+		id TEXT NOT NULL,
+		state TEXT NOT NULL,
+		PRIMARY KEY (team, channel, user_id)
+	)`,
+
+	`CREATE TABLE slack_conversation_data (
+		team TEXT NOT NULL,
+		channel TEXT NOT NULL,
+		user_id TEXT NOT NULL,
+		data_key TEXT NOT NULL,
+		value TEXT NOT NULL,
+		PRIMARY KEY (team, channel, user_id, data_key)
+	)`,
+
+	`ALTER TABLE slack_conversations ADD COLUMN version INTEGER NOT NULL DEFAULT 1`,
+}
+
+// Migrate creates or updates the tables used by the stores in this package.
+//
+// Every migration runs in its own transaction, and is recorded in the slack_migrations table,
+// so Migrate can be run on every start. A migration is claimed by recording it before it runs,
+// so processes migrating the same database at once apply it only once.
+func Migrate(db *sql.DB) error {
+	if _, err := db.Exec(`CREATE TABLE IF NOT EXISTS slack_migrations (version INTEGER PRIMARY KEY)`); err != nil {
+		return errors.Wrap(err, "Migrate Failed")
+	}
+
+	var version int
+	if err := db.QueryRow(`SELECT COALESCE(MAX(version), 0) FROM slack_migrations`).Scan(&version); err != nil {
+		return errors.Wrap(err, "Migrate Failed")
+	}
+
+	for v := version + 1; v <= len(migrations); v++ {
+		err := transact(db, func(tx *sql.Tx) error {
+			// waits for a concurrent claim to commit, skipping the migration if it did
+			res, err := tx.Exec(`INSERT INTO slack_migrations (version) VALUES ($1) ON CONFLICT (version) DO NOTHING`, v)
+			if err != nil {
+				return err
+			}
+
+			if n, err := res.RowsAffected(); err != nil || n == 0 {
+				return err
+			}
+
+			_, err = tx.Exec(migrations[v-1])
+			return err
+		})
+
+		if err != nil {
+			return errors.Wrapf(err, "Migrate Failed, at version %d", v)
+		}
+	}
+
+	return nil
+}
+
+// transact runs f in a transaction, committing if it succeeds and rolling back otherwise.
+func transact(db *sql.DB, f func(*sql.Tx) error) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+
+	if err := f(tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// SQLBotStore is a slack.BotStore storing bots as JSON in the slack_bots table.
+type SQLBotStore struct {
+	db *sql.DB
+}
+
+// NewSQLBotStore creates a new SQLBotStore, migrating the database if needed.
+func NewSQLBotStore(db *sql.DB) (*SQLBotStore, error) {
+	if err := Migrate(db); err != nil {
+		return nil, errors.Wrap(err, "NewSQLBotStore Failed")
+	}
+
+	return &SQLBotStore{db}, nil
+}
+
+func (bs *SQLBotStore) AddBot(p *oauth.AccessResponse) error {
+	d, err := json.Marshal(p)
+	if err != nil {
+		return errors.Wrap(err, "AddBot Failed")
+	}
+
+	k := slack.BotKey(p)
+
+	res, err := bs.db.Exec(`INSERT INTO slack_bots (bot_key, team_id, enterprise_id, payload) VALUES ($1, $2, $3, $4)
+		ON CONFLICT (bot_key) DO NOTHING`, k, p.TeamID, p.EnterpriseID, string(d))
+
+	if err != nil {
+		return errors.Wrap(err, "AddBot Failed")
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "AddBot Failed")
+	}
+
+	if n == 0 {
+		return slack.ErrBotAlreadyAdded
+	}
+
+	return nil
+}
+
+func (bs *SQLBotStore) GetBot(key string) (*oauth.AccessResponse, error) {
+	var d string
+	if err := bs.db.QueryRow(`SELECT payload FROM slack_bots WHERE bot_key = $1`, key).Scan(&d); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, slack.ErrBotNotFound
+		}
+
+		return nil, errors.Wrap(err, "GetBot Failed")
+	}
+
+	return decodeBot(d)
+}
+
+func (bs *SQLBotStore) UpdateBot(p *oauth.AccessResponse) error {
+	d, err := json.Marshal(p)
+	if err != nil {
+		return errors.Wrap(err, "UpdateBot Failed")
+	}
+
+	res, err := bs.db.Exec(`UPDATE slack_bots SET team_id = $1, enterprise_id = $2, payload = $3 WHERE bot_key = $4`, p.TeamID, p.EnterpriseID, string(d), slack.BotKey(p))
+	if err != nil {
+		return errors.Wrap(err, "UpdateBot Failed")
+	}
+
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return slack.ErrBotNotFound
+	}
+
+	return nil
+}
+
+func (bs *SQLBotStore) RemoveBot(key string) error {
+	res, err := bs.db.Exec(`DELETE FROM slack_bots WHERE bot_key = $1`, key)
+	if err != nil {
+		return errors.Wrap(err, "RemoveBot Failed")
+	}
+
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return slack.ErrBotNotFound
+	}
+
+	return nil
+}
+
+func (bs *SQLBotStore) AllBots() ([]*oauth.AccessResponse, error) {
+	rows, err := bs.db.Query(`SELECT payload FROM slack_bots ORDER BY bot_key`)
+	if err != nil {
+		return nil, errors.Wrap(err, "AllBots Failed")
+	}
+
+	defer rows.Close()
+
+	bots := make([]*oauth.AccessResponse, 0)
+	for rows.Next() {
+		var d string
+		if err := rows.Scan(&d); err != nil {
+			return nil, errors.Wrap(err, "AllBots Failed")
+		}
+
+		p, err := decodeBot(d)
+		if err != nil {
+			return nil, errors.Wrap(err, "AllBots Failed")
+		}
+
+		bots = append(bots, p)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "AllBots Failed")
+	}
+
+	return bots, nil
+}
+
+func decodeBot(d string) (*oauth.AccessResponse, error) {
+	p := &oauth.AccessResponse{
+		Bot: &oauth.Bot{},
+	}
+
+	if err := json.Unmarshal([]byte(d), p); err != nil {
+		return nil, errors.Wrap(err, "Could not decode bot")
+	}
+
+	return p, nil
+}
+
+var _ slack.UpdateBotStore = &SQLBotStore{}
+
+// SQLConversationStore is a slack.ConversationStore storing conversations in the
+// slack_conversations and slack_conversation_data tables.
+type SQLConversationStore struct {
+	db *sql.DB
+}
+
+// NewSQLConversationStore creates a new SQLConversationStore, migrating the database if needed.
+func NewSQLConversationStore(db *sql.DB) (*SQLConversationStore, error) {
+	if err := Migrate(db); err != nil {
+		return nil, errors.Wrap(err, "NewSQLConversationStore Failed")
+	}
+
+	return &SQLConversationStore{db}, nil
+}
+
+// active checks if a conversation is active inside a transaction.
+func active(tx *sql.Tx, user, channel, team string) (bool, error) {
+	var n int
+	err := tx.QueryRow(`SELECT COUNT(*) FROM slack_conversations WHERE team = $1 AND channel = $2 AND user_id = $3`, team, channel, user).Scan(&n)
+	return n > 0, err
+}
+
+func (cs *SQLConversationStore) Start(user, channel, team, id string) error {
+	return transact(cs.db, func(tx *sql.Tx) error {
+		res, err := tx.Exec(`INSERT INTO slack_conversations (team, channel, user_id, id, state, version) VALUES ($1, $2, $3, $4, 'start', 1)
+			ON CONFLICT (team, channel, user_id) DO NOTHING`, team, channel, user, id)
+
+		if err != nil {
+			return errors.Wrap(err, "Start Failed")
+		}
+
+		n, err := res.RowsAffected()
+		if err != nil {
+			return errors.Wrap(err, "Start Failed")
+		}
+
+		if n == 0 {
+			return slack.ErrConversationExists
+		}
+
+		// clear data left behind by a conversation that was not ended cleanly
+		if _, err := tx.Exec(`DELETE FROM slack_conversation_data WHERE team = $1 AND channel = $2 AND user_id = $3`, team, channel, user); err != nil {
+			return errors.Wrap(err, "Start Failed")
+		}
+
+		return nil
+	})
+}
+
+func (cs *SQLConversationStore) IsActive(user, channel, team string) bool {
+	_, _, err := cs.Active(user, channel, team)
+	return err == nil
+}
+
+func (cs *SQLConversationStore) Active(user, channel, team string) (id, state string, err error) {
+	err = cs.db.QueryRow(`SELECT id, state FROM slack_conversations WHERE team = $1 AND channel = $2 AND user_id = $3`, team, channel, user).Scan(&id, &state)
+	if err == sql.ErrNoRows {
+		err = slack.ErrConversationNotFound
+	} else if err != nil {
+		err = errors.Wrap(err, "Active Failed")
+	}
+
+	return
+}
+
+func (cs *SQLConversationStore) SetState(user, channel, team, state string) error {
+	res, err := cs.db.Exec(`UPDATE slack_conversations SET state = $1, version = version + 1 WHERE team = $2 AND channel = $3 AND user_id = $4`, state, team, channel, user)
+	if err != nil {
+		return errors.Wrap(err, "SetState Failed")
+	}
+
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return slack.ErrConversationNotFound
+	}
+
+	return nil
+}
+
+func (cs *SQLConversationStore) ActiveVersion(user, channel, team string) (id, state string, version int64, err error) {
+	err = cs.db.QueryRow(`SELECT id, state, version FROM slack_conversations WHERE team = $1 AND channel = $2 AND user_id = $3`, team, channel, user).Scan(&id, &state, &version)
+	if err == sql.ErrNoRows {
+		err = slack.ErrConversationNotFound
+	} else if err != nil {
+		err = errors.Wrap(err, "ActiveVersion Failed")
+	}
+
+	return
+}
+
+func (cs *SQLConversationStore) CompareAndSetState(user, channel, team string, version int64, state string) (int64, error) {
+	res, err := cs.db.Exec(`UPDATE slack_conversations SET state = $1, version = version + 1 WHERE team = $2 AND channel = $3 AND user_id = $4 AND version = $5`, state, team, channel, user, version)
+	if err != nil {
+		return 0, errors.Wrap(err, "CompareAndSetState Failed")
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "CompareAndSetState Failed")
+	}
+
+	if n == 0 {
+		// either the conversation is gone or someone else moved it
+		if _, _, err := cs.Active(user, channel, team); err != nil {
+			return 0, err
+		}
+
+		return 0, slack.ErrStateChanged
+	}
+
+	return version + 1, nil
+}
+
+func (cs *SQLConversationStore) SetData(user, channel, team, key, value string) error {
+	return transact(cs.db, func(tx *sql.Tx) error {
+		ok, err := active(tx, user, channel, team)
+		if err != nil {
+			return errors.Wrap(err, "SetData Failed")
+		}
+
+		if !ok {
+			return slack.ErrConversationNotFound
+		}
+
+		_, err = tx.Exec(`INSERT INTO slack_conversation_data (team, channel, user_id, data_key, value) VALUES ($1, $2, $3, $4, $5)
+			ON CONFLICT (team, channel, user_id, data_key) DO UPDATE SET value = excluded.value`, team, channel, user, key, value)
+
+		if err != nil {
+			return errors.Wrap(err, "SetData Failed")
+		}
+
+		return nil
+	})
+}
+
+func (cs *SQLConversationStore) GetData(user, channel, team, key string) (string, error) {
+	var value string
+
+	err := transact(cs.db, func(tx *sql.Tx) error {
+		ok, err := active(tx, user, channel, team)
+		if err != nil {
+			return errors.Wrap(err, "GetData Failed")
+		}
+
+		if !ok {
+			return slack.ErrConversationNotFound
+		}
+
+		err = tx.QueryRow(`SELECT value FROM slack_conversation_data WHERE team = $1 AND channel = $2 AND user_id = $3 AND data_key = $4`, team, channel, user, key).Scan(&value)
+		if err == sql.ErrNoRows {
+			return slack.ErrItemNotFound
+		}
+
+		if err != nil {
+			return errors.Wrap(err, "GetData Failed")
+		}
+
+		return nil
+	})
+
+	return value, err
+}
+
+func (cs *SQLConversationStore) End(user, channel, team string) error {
+	return transact(cs.db, func(tx *sql.Tx) error {
+		res, err := tx.Exec(`DELETE FROM slack_conversations WHERE team = $1 AND channel = $2 AND user_id = $3`, team, channel, user)
+		if err != nil {
+			return errors.Wrap(err, "End Failed")
+		}
+
+		if n, err := res.RowsAffected(); err == nil && n == 0 {
+			return slack.ErrConversationNotFound
+		}
+
+		if _, err := tx.Exec(`DELETE FROM slack_conversation_data WHERE team = $1 AND channel = $2 AND user_id = $3`, team, channel, user); err != nil {
+			return errors.Wrap(err, "End Failed")
+		}
+
+		return nil
+	})
+}
+
+var _ slack.VersionedConversationStore = &SQLConversationStore{}
@@ -0,0 +1,277 @@
+package sql
+
+import (
+	"database/sql"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+
+	"suy.io/bots/slack"
+	"suy.io/bots/slack/api/oauth"
+	"suy.io/bots/slack/storetest"
+)
+
+func testDB(t *testing.T) (*sql.DB, func()) {
+	dir, err := ioutil.TempDir("", "slacksql")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	db, err := sql.Open("sqlite3", filepath.Join(dir, "test.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return db, func() {
+		db.Close()
+		os.RemoveAll(dir)
+	}
+}
+
+func TestMigrate(t *testing.T) {
+	db, done := testDB(t)
+	defer done()
+
+	for i := 0; i < 2; i++ {
+		if err := Migrate(db); err != nil {
+			t.Fatalf("Migrate() error = %v", err)
+		}
+	}
+
+	var version int
+	if err := db.QueryRow(`SELECT MAX(version) FROM slack_migrations`).Scan(&version); err != nil {
+		t.Fatal(err)
+	}
+
+	if version != len(migrations) {
+		t.Errorf("Migrate() version = %v, want %v", version, len(migrations))
+	}
+}
+
+func TestMigrate_concurrent(t *testing.T) {
+	db, done := testDB(t)
+	defer done()
+
+	var dbs []*sql.DB
+	for i := 0; i < 4; i++ {
+		d, err := sql.Open("sqlite3", migrateDSN(t, db))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		defer d.Close()
+		dbs = append(dbs, d)
+	}
+
+	errs := make(chan error, len(dbs))
+	for _, d := range dbs {
+		go func(d *sql.DB) {
+			errs <- Migrate(d)
+		}(d)
+	}
+
+	for range dbs {
+		if err := <-errs; err != nil {
+			t.Fatalf("Migrate() error = %v", err)
+		}
+	}
+
+	var n int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM slack_migrations`).Scan(&n); err != nil {
+		t.Fatal(err)
+	}
+
+	if n != len(migrations) {
+		t.Errorf("Migrate() applied %v migrations, want %v", n, len(migrations))
+	}
+}
+
+// migrateDSN returns a DSN for the file behind db that waits on locks held by other connections.
+func migrateDSN(t *testing.T, db *sql.DB) string {
+	var seq int
+	var name, file string
+	if err := db.QueryRow(`PRAGMA database_list`).Scan(&seq, &name, &file); err != nil {
+		t.Fatal(err)
+	}
+
+	return "file:" + file + "?_busy_timeout=10000"
+}
+
+func TestSQLBotStore(t *testing.T) {
+	db, done := testDB(t)
+	defer done()
+
+	bs, err := NewSQLBotStore(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p1 := &oauth.AccessResponse{TeamID: "T1", Bot: &oauth.Bot{BotUserID: "U1", BotAccessToken: "xoxb-1"}}
+	p2 := &oauth.AccessResponse{TeamID: "T2", EnterpriseID: "E1", Bot: &oauth.Bot{BotUserID: "U2", BotAccessToken: "xoxb-2"}}
+
+	if err := bs.AddBot(p1); err != nil {
+		t.Fatalf("SQLBotStore.AddBot() error = %v", err)
+	}
+
+	if err := bs.AddBot(p2); err != nil {
+		t.Fatalf("SQLBotStore.AddBot() error = %v", err)
+	}
+
+	if err := bs.AddBot(p1); err != slack.ErrBotAlreadyAdded {
+		t.Errorf("SQLBotStore.AddBot() error = %v, want %v", err, slack.ErrBotAlreadyAdded)
+	}
+
+	tests := []struct {
+		name    string
+		key     string
+		want    *oauth.AccessResponse
+		wantErr error
+	}{
+		{"", "T1", p1, nil},
+		{"", "E1/T2", p2, nil},
+		{"", "T2", nil, slack.ErrBotNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := bs.GetBot(tt.key)
+			if err != tt.wantErr {
+				t.Errorf("SQLBotStore.GetBot() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SQLBotStore.GetBot() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	all, err := bs.AllBots()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(all, []*oauth.AccessResponse{p2, p1}) {
+		t.Errorf("SQLBotStore.AllBots() = %v, want %v", all, []*oauth.AccessResponse{p2, p1})
+	}
+
+	if err := bs.RemoveBot("T1"); err != nil {
+		t.Errorf("SQLBotStore.RemoveBot() error = %v", err)
+	}
+
+	if err := bs.RemoveBot("T1"); err != slack.ErrBotNotFound {
+		t.Errorf("SQLBotStore.RemoveBot() error = %v, want %v", err, slack.ErrBotNotFound)
+	}
+}
+
+func TestSQLConversationStore(t *testing.T) {
+	db, done := testDB(t)
+	defer done()
+
+	cs, err := NewSQLConversationStore(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if cs.IsActive("U1", "C1", "T1") {
+		t.Errorf("SQLConversationStore.IsActive() = true before Start")
+	}
+
+	if err := cs.SetData("U1", "C1", "T1", "foo", "bar"); err != slack.ErrConversationNotFound {
+		t.Errorf("SQLConversationStore.SetData() error = %v, want %v", err, slack.ErrConversationNotFound)
+	}
+
+	if err := cs.Start("U1", "C1", "T1", "conv"); err != nil {
+		t.Fatalf("SQLConversationStore.Start() error = %v", err)
+	}
+
+	if err := cs.Start("U1", "C1", "T1", "conv"); err != slack.ErrConversationExists {
+		t.Errorf("SQLConversationStore.Start() error = %v, want %v", err, slack.ErrConversationExists)
+	}
+
+	if id, state, err := cs.Active("U1", "C1", "T1"); err != nil || id != "conv" || state != "start" {
+		t.Errorf("SQLConversationStore.Active() = %v, %v, %v, want conv, start", id, state, err)
+	}
+
+	if err := cs.SetState("U1", "C1", "T1", "next"); err != nil {
+		t.Errorf("SQLConversationStore.SetState() error = %v", err)
+	}
+
+	if _, state, _ := cs.Active("U1", "C1", "T1"); state != "next" {
+		t.Errorf("SQLConversationStore.SetState() state = %v, want next", state)
+	}
+
+	cs.SetData("U1", "C1", "T1", "foo", "bar")
+	cs.SetData("U1", "C1", "T1", "foo", "baz")
+
+	if v, err := cs.GetData("U1", "C1", "T1", "foo"); err != nil || v != "baz" {
+		t.Errorf("SQLConversationStore.GetData() = %v, %v, want baz", v, err)
+	}
+
+	if _, err := cs.GetData("U1", "C1", "T1", "nope"); err != slack.ErrItemNotFound {
+		t.Errorf("SQLConversationStore.GetData() error = %v, want %v", err, slack.ErrItemNotFound)
+	}
+
+	if err := cs.End("U1", "C1", "T1"); err != nil {
+		t.Errorf("SQLConversationStore.End() error = %v", err)
+	}
+
+	if err := cs.End("U1", "C1", "T1"); err != slack.ErrConversationNotFound {
+		t.Errorf("SQLConversationStore.End() error = %v, want %v", err, slack.ErrConversationNotFound)
+	}
+
+	if err := cs.SetState("U1", "C1", "T1", "next"); err != slack.ErrConversationNotFound {
+		t.Errorf("SQLConversationStore.SetState() error = %v, want %v", err, slack.ErrConversationNotFound)
+	}
+
+	cs.Start("U1", "C1", "T1", "conv")
+	if _, err := cs.GetData("U1", "C1", "T1", "foo"); err != slack.ErrItemNotFound {
+		t.Errorf("SQLConversationStore.GetData() kept data from an ended conversation")
+	}
+}
+
+func TestSQLStoresConformance(t *testing.T) {
+	var dbs []func()
+	defer func() {
+		for _, done := range dbs {
+			done()
+		}
+	}()
+
+	open := func() *sql.DB {
+		db, done := testDB(t)
+		dbs = append(dbs, done)
+		return db
+	}
+
+	newBotStore := func() slack.BotStore {
+		bs, err := NewSQLBotStore(open())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return bs
+	}
+
+	newConversationStore := func() slack.ConversationStore {
+		cs, err := NewSQLConversationStore(open())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return cs
+	}
+
+	storetest.TestBotStore(t, newBotStore)
+	storetest.TestUpdateBotStore(t, func() slack.UpdateBotStore {
+		return newBotStore().(slack.UpdateBotStore)
+	})
+	storetest.TestConversationStore(t, newConversationStore)
+	storetest.TestVersionedConversationStore(t, func() slack.VersionedConversationStore {
+		return newConversationStore().(slack.VersionedConversationStore)
+	})
+}
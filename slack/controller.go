@@ -1,24 +1,36 @@
 package slack
 
 import (
+	"context"
+	"crypto/subtle"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"log"
 	"net/http"
 	"net/url"
+	"regexp"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/pkg/errors"
 
+	"suy.io/bots/slack/api"
+	"suy.io/bots/slack/api/auth"
 	"suy.io/bots/slack/api/chat"
 	"suy.io/bots/slack/api/oauth"
 	"suy.io/bots/slack/api/rtm"
 	"suy.io/bots/slack/api/team"
+	"suy.io/bots/slack/api/users"
+	"suy.io/bots/slack/connector"
 )
 
 // Controller is essentially a manager for a single slack App.
 //
+// MessageChanged, MessageDeleted, BotMessages, MemberJoinedChannel, MemberLeftChannel, PresenceChange
+// and UserTyping only send the events that arrive after their first call, the ones before are dropped.
+//
 // ffjson: skip
 type Controller struct {
 	clientID     string
@@ -28,8 +40,22 @@ type Controller struct {
 	connector     Connector
 	bots          BotStore
 	conversations ConversationRegistry
+	unfurls       *UnfurlRegistry
 	cs            ConversationStore
+	convLocks     *conversationLocks
+	loops         *loopGuard
+	us            UserStore
+	tr            *tokenRefresher
+	ss            StateStore
+	seen          SeenStore
+	eventError    EventErrorHandler
+	events        *eventPool
+	eventWorkers  int
+	eventQueue    int
+	oauthError    OAuthErrorHandler
+	startError    StartErrorHandler
 	botAdded      chan *Bot
+	botRemoved    chan *BotRemoval
 
 	directMessages  chan *MessagePair
 	directMentions  chan *MessagePair
@@ -38,18 +64,51 @@ type Controller struct {
 	channelJoin     chan *ChannelJoinMessagePair
 	userChannelJoin chan *UserChannelJoinMessagePair
 	groupJoin       chan *GroupJoinMessagePair
+	reactionAdded   chan *ReactionPair
+	reactionRemoved chan *ReactionPair
+	fileShared      chan *FileSharedPair
+	linkShared      chan *LinkSharedPair
+
+	// channels for events that are often unused are created by their accessors,
+	// events are only decoded and delivered once a channel exists
+	subsMu         sync.RWMutex
+	subsClosed     bool
+	messageChanged chan *MessageChangedPair
+	messageDeleted chan *MessageDeletedPair
+	botMessages    chan *BotMessagePair
+	memberJoined   chan *MemberChannelPair
+	memberLeft     chan *MemberChannelPair
+	presenceChange chan *PresenceChangePair
+	userTyping     chan *UserTypingPair
+
+	rtmEventsMu sync.RWMutex
+	rtmEvents   map[string]RTMEventHandler
 
 	interactions       chan *InteractionPair
 	interactionOptions chan *InteractionOptionsPair
 
 	commands chan *Command
+
+	stopMu     sync.RWMutex
+	stopping   bool
+	closed     bool
+	done       chan struct{}
+	listening  chan struct{}
+	deliveries sync.WaitGroup
+	handlers   sync.WaitGroup
 }
 
 // NewController creates a new Controller using the provided functional arguments.
 func NewController(options ...func(*Controller) error) (*Controller, error) {
 	controller := &Controller{
 		conversations: NewConversationRegistry(),
+		unfurls:       NewUnfurlRegistry(),
+		convLocks:     newConversationLocks(),
+		loops:         newLoopGuard(),
+		eventWorkers:  DefaultEventWorkers,
+		eventQueue:    DefaultEventQueueSize,
 		botAdded:      make(chan *Bot),
+		botRemoved:    make(chan *BotRemoval),
 
 		directMessages:  make(chan *MessagePair),
 		directMentions:  make(chan *MessagePair),
@@ -58,11 +117,20 @@ func NewController(options ...func(*Controller) error) (*Controller, error) {
 		channelJoin:     make(chan *ChannelJoinMessagePair),
 		userChannelJoin: make(chan *UserChannelJoinMessagePair),
 		groupJoin:       make(chan *GroupJoinMessagePair),
+		reactionAdded:   make(chan *ReactionPair),
+		reactionRemoved: make(chan *ReactionPair),
+		fileShared:      make(chan *FileSharedPair),
+		linkShared:      make(chan *LinkSharedPair),
+
+		rtmEvents: make(map[string]RTMEventHandler),
 
 		interactions:       make(chan *InteractionPair),
 		interactionOptions: make(chan *InteractionOptionsPair),
 
 		commands: make(chan *Command),
+
+		done:      make(chan struct{}),
+		listening: make(chan struct{}),
 	}
 
 	for _, opt := range options {
@@ -79,10 +147,25 @@ func NewController(options ...func(*Controller) error) (*Controller, error) {
 		controller.cs = NewMemoryConversationStore()
 	}
 
+	if controller.us == nil {
+		controller.us = NewMemoryUserStore(DefaultUserCacheTTL)
+	}
+
 	if controller.connector == nil {
 		controller.connector = newInternalConnector()
 	}
 
+	if controller.ss == nil {
+		controller.ss = NewMemoryStateStore(DefaultStateTTL)
+	}
+
+	if controller.seen == nil {
+		controller.seen = NewMemorySeenStore(DefaultSeenTTL)
+	}
+
+	controller.events = newEventPool(controller.eventWorkers, controller.eventQueue, controller.processEvent)
+	controller.tr = newTokenRefresher(controller.clientID, controller.clientSecret, controller.bots)
+
 	// load cached bots from storage
 	bots, err := controller.bots.AllBots()
 	if err != nil {
@@ -90,13 +173,23 @@ func NewController(options ...func(*Controller) error) (*Controller, error) {
 	}
 
 	for _, b := range bots {
-		bot := newBot(b, controller.connector, controller.conversations, controller.cs)
+		// bots added before their bot id was stored
+		if b.Bot != nil && b.Bot.BotID == "" {
+			if err := identify(b); err != nil {
+				log.Println(errors.Wrapf(err, "Could not get the bot id of %s", BotKey(b)))
+			} else if err := UpdateBot(controller.bots, b); err != nil {
+				log.Println(errors.Wrapf(err, "Could not store the bot id of %s", BotKey(b)))
+			}
+		}
+
+		bot := newBot(b, controller.connector, controller.conversations, controller.cs, controller.us, controller.tr)
 		if err := bot.Start(); err != nil {
-			return nil, errors.Wrap(err, "NewController Failed")
+			controller.handleStartError(b, err)
 		}
 	}
 
-	go controller.listen()
+	// taken here, as Shutdown can close the connector before listen runs
+	go controller.listen(controller.connector.Messages())
 	return controller, nil
 }
 
@@ -172,9 +265,174 @@ func WithConversationStore(cs ConversationStore) func(*Controller) error {
 	}
 }
 
-func (c *Controller) listen() {
-	for msg := range c.connector.Messages() {
+// WithUserStore sets a custom UserStore implementation for caching user data.
+func WithUserStore(us UserStore) func(*Controller) error {
+	return func(c *Controller) error {
+		if us == nil {
+			return ErrInvalidUserStorage
+		}
+
+		c.us = us
+		return nil
+	}
+}
+
+// WithStateStore sets a custom StateStore implementation for issuing and verifying OAuth states.
+//
+// A shared store is needed when the install and OAuth handlers run on more than one instance.
+func WithStateStore(ss StateStore) func(*Controller) error {
+	return func(c *Controller) error {
+		if ss == nil {
+			return ErrInvalidStateStorage
+		}
+
+		c.ss = ss
+		return nil
+	}
+}
+
+// WithSeenStore sets the store that remembers handled events, so retried events are dropped.
+// Apps running several replicas behind a load balancer should share one.
+func WithSeenStore(s SeenStore) func(*Controller) error {
+	return func(c *Controller) error {
+		if s == nil {
+			return ErrInvalidSeenStorage
+		}
+
+		c.seen = s
+		return nil
+	}
+}
+
+// WithOAuthErrorHandler sets a handler to respond to failed installs,
+// instead of the default bare 401 and 500 responses.
+func WithOAuthErrorHandler(h OAuthErrorHandler) func(*Controller) error {
+	return func(c *Controller) error {
+		if h == nil {
+			return ErrInvalidOAuthErrorHandler
+		}
+
+		c.oauthError = h
+		return nil
+	}
+}
+
+// StartErrorHandler is called when a stored bot fails to start in NewController,
+// or fails to start again after slack or a connector asked for a new socket.
+type StartErrorHandler func(p *oauth.AccessResponse, err error)
+
+// WithStartErrorHandler sets a handler that is called for every stored bot that fails to start,
+// instead of skipping it silently.
+func WithStartErrorHandler(h StartErrorHandler) func(*Controller) error {
+	return func(c *Controller) error {
+		if h == nil {
+			return ErrInvalidStartErrorHandler
+		}
+
+		c.startError = h
+		return nil
+	}
+}
+
+// WithBotMessagePolicy sets how messages sent by other bots are handled,
+// by default they are only sent to BotMessages.
+func WithBotMessagePolicy(p BotMessagePolicy) func(*Controller) error {
+	return func(c *Controller) error {
+		if p < BotMessagesSeparate || p > BotMessagesHandle {
+			return ErrInvalidBotMessagePolicy
+		}
+
+		c.loops.policy = p
+		return nil
+	}
+}
+
+// WithRepeatLimit sets how many times another bot can send the same text to a channel in a row within window,
+// before its messages are dropped. A limit of 0 never drops them.
+func WithRepeatLimit(limit int, window time.Duration) func(*Controller) error {
+	return func(c *Controller) error {
+		if limit < 0 || window <= 0 {
+			return ErrInvalidRepeatLimit
+		}
+
+		c.loops.limit, c.loops.window = limit, window
+		return nil
+	}
+}
+
+// WithEventWorkers sets the number of events handled at once, and the number of events that can wait for them,
+// which is split evenly between the workers. The events of a channel are always handled by the same worker, in order,
+// and EventHandler rejects events that arrive while the queue of their worker is full, for slack to retry them later.
+func WithEventWorkers(workers, queue int) func(*Controller) error {
+	return func(c *Controller) error {
+		if workers <= 0 || queue < 0 {
+			return ErrInvalidEventWorkers
+		}
+
+		c.eventWorkers, c.eventQueue = workers, queue
+		return nil
+	}
+}
+
+// EventErrorHandler is called when an event acknowledged by EventHandler fails to be handled.
+type EventErrorHandler func(event []byte, err error)
+
+// WithEventErrorHandler sets a handler that is called for every event that fails to be handled,
+// instead of dropping it silently.
+func WithEventErrorHandler(h EventErrorHandler) func(*Controller) error {
+	return func(c *Controller) error {
+		if h == nil {
+			return ErrInvalidEventErrorHandler
+		}
+
+		c.eventError = h
+		return nil
+	}
+}
+
+// handleStartError removes bots whose token is no longer valid, and reports the error.
+func (c *Controller) handleStartError(p *oauth.AccessResponse, err error) {
+	if se, ok := errors.Cause(err).(*api.Error); ok {
+		switch se.Description {
+		case "invalid_auth", "account_inactive", "token_revoked":
+			if rerr := c.removeBot(BotKey(p), se.Description); rerr != nil {
+				err = errors.Wrap(rerr, err.Error())
+			}
+		}
+	}
+
+	if c.startError != nil {
+		c.startError(p, err)
+	}
+}
+
+// handleGoodbye starts a bot again with a new socket, as slack is about to close the one it has,
+// or a connector took over the team from another instance and cannot reuse its socket url.
+func (c *Controller) handleGoodbye(team string) error {
+	payload, err := c.bots.GetBot(team)
+	if err != nil {
+		return errors.Wrap(err, "Could not handle goodbye")
+	}
+
+	b := newBot(payload, c.connector, c.conversations, c.cs, c.us, c.tr)
+	c.spawn(func() {
+		if err := b.Start(); err != nil {
+			c.handleStartError(payload, err)
+		}
+	})
+
+	return nil
+}
+
+func (c *Controller) listen(msgs <-chan *connector.MessagePayload) {
+	defer close(c.listening)
+
+	for msg := range msgs {
 		c.handleMessage(msg.Message, msg.Team)
+
+		if msg.Ack != nil {
+			msg.Ack()
+		}
 	}
 }
 
@@ -189,30 +447,217 @@ func (c *Controller) CreateAddToSlackURL(scopes []string, redirect, state string
 	return "https://slack.com/oauth/authorize?" + v.Encode(), nil
 }
 
+// OAuthErrorHandler responds to a failed install.
+//
+// The cause of err is ErrInvalidState, ErrStateExpired or ErrOAuthDenied
+// if the request itself was rejected, otherwise the OAuth exchange or storing the bot failed.
+type OAuthErrorHandler func(err error, res http.ResponseWriter, req *http.Request)
+
+func (c *Controller) handleOAuthError(err error, res http.ResponseWriter, req *http.Request) {
+	if c.oauthError != nil {
+		c.oauthError(err, res, req)
+		return
+	}
+
+	switch errors.Cause(err) {
+	case ErrInvalidState, ErrStateExpired, ErrOAuthDenied:
+		http.Error(res, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+	default:
+		http.Error(res, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+	}
+}
+
+// stateCookie binds an OAuth state to the browser it was issued to.
+const stateCookie = "slack_oauth_state"
+
+// setStateCookie remembers the state issued to the installer, until slack redirects them back.
+func setStateCookie(res http.ResponseWriter, state, redirect string) {
+	http.SetCookie(res, &http.Cookie{
+		Name:     stateCookie,
+		Value:    state,
+		Path:     "/",
+		HttpOnly: true,
+		Secure:   strings.HasPrefix(redirect, "https://"),
+		// sent along with the top level redirect from slack
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+// InstallHandler returns a http.HandlerFunc that issues a new OAuth state
+// and redirects the installer to the slack OAuth authorize URL.
+//
+// The state is also set as a cookie, so the install has to be completed in the same browser.
+// Use it along with an OAuthHandler with an empty expectedState.
+func (c *Controller) InstallHandler(scopes []string, redirect string) http.HandlerFunc {
+	return func(res http.ResponseWriter, req *http.Request) {
+		state, err := c.ss.NewState()
+		if err != nil {
+			c.handleOAuthError(errors.Wrap(err, "Install Failed"), res, req)
+			return
+		}
+
+		u, err := c.CreateAddToSlackURL(scopes, redirect, state)
+		if err != nil {
+			c.handleOAuthError(errors.Wrap(err, "Install Failed"), res, req)
+			return
+		}
+
+		setStateCookie(res, state, redirect)
+		http.Redirect(res, req, u, http.StatusFound)
+	}
+}
+
+// InstallV2Handler is InstallHandler for OAuth v2 installs, use it along with an OAuthV2Handler.
+func (c *Controller) InstallV2Handler(botScopes, userScopes []string, redirect string) http.HandlerFunc {
+	return func(res http.ResponseWriter, req *http.Request) {
+		state, err := c.ss.NewState()
+		if err != nil {
+			c.handleOAuthError(errors.Wrap(err, "Install Failed"), res, req)
+			return
+		}
+
+		u, err := c.CreateAddToSlackV2URL(botScopes, userScopes, redirect, state)
+		if err != nil {
+			c.handleOAuthError(errors.Wrap(err, "Install Failed"), res, req)
+			return
+		}
+
+		setStateCookie(res, state, redirect)
+		http.Redirect(res, req, u, http.StatusFound)
+	}
+}
+
+// verifyOAuthRedirect checks an OAuth redirect from slack and gets the code from it.
+//
+// If expectedState is empty, the state has to match the cookie set by InstallHandler,
+// and is verified against the StateStore.
+func (c *Controller) verifyOAuthRedirect(res http.ResponseWriter, req *http.Request, expectedState string) (string, error) {
+	q := req.URL.Query()
+
+	if e := q.Get("error"); e != "" {
+		return "", errors.Wrap(ErrOAuthDenied, e)
+	}
+
+	state := q.Get("state")
+
+	if expectedState != "" {
+		if state != expectedState {
+			return "", ErrInvalidState
+		}
+	} else {
+		// a state issued to another browser is a forged redirect
+		ck, err := req.Cookie(stateCookie)
+		if err != nil || subtle.ConstantTimeCompare([]byte(ck.Value), []byte(state)) != 1 {
+			return "", ErrInvalidState
+		}
+
+		http.SetCookie(res, &http.Cookie{Name: stateCookie, Path: "/", MaxAge: -1})
+
+		if err := c.ss.VerifyState(state); err != nil {
+			return "", err
+		}
+	}
+
+	return q.Get("code"), nil
+}
+
 // OAuthHandler returns a http.HandlerFunc that can complete OAuth handshake with slack, creating new Bots.
+//
+// If expectedState is empty, the state must be one issued by InstallHandler to the same browser,
+// and can only be used once.
 func (c *Controller) OAuthHandler(redirect, expectedState string, onSuccess func(*oauth.AccessResponse, http.ResponseWriter, *http.Request)) http.HandlerFunc {
 	return func(res http.ResponseWriter, req *http.Request) {
-		code := req.URL.Query().Get("code")
-		currentState := req.URL.Query().Get("state")
+		if c.unavailable(res) {
+			return
+		}
 
-		if currentState != expectedState {
-			http.Error(res, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+		code, err := c.verifyOAuthRedirect(res, req, expectedState)
+		if err != nil {
+			c.handleOAuthError(errors.Wrap(err, "OAuth Failed"), res, req)
 			return
 		}
 
 		payload, err := oauth.Access(&oauth.AccessRequest{c.clientID, c.clientSecret, code, redirect})
 		if err != nil {
-			http.Error(res, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			c.handleOAuthError(errors.Wrap(err, "OAuth Failed"), res, req)
 			return
 		}
 
-		if err := c.bots.AddBot(payload); err != nil {
-			http.Error(res, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		if err := c.saveBot(payload); err != nil {
+			c.handleOAuthError(errors.Wrap(err, "OAuth Failed"), res, req)
+			return
+		}
+
+		b := newBot(payload, c.connector, c.conversations, c.cs, c.us, c.tr)
+		c.deliver(func(done <-chan struct{}) {
+			select {
+			case c.botAdded <- b:
+			case <-done:
+			}
+		})
+
+		if onSuccess != nil {
+			onSuccess(payload, res, req)
+		} else {
+			res.WriteHeader(http.StatusOK)
+		}
+	}
+}
+
+// CreateAddToSlackV2URL creates a slack OAuth v2 authorize URL, requesting separate bot and user scopes.
+func (c *Controller) CreateAddToSlackV2URL(botScopes, userScopes []string, redirect, state string) (string, error) {
+	v := make(url.Values)
+	v.Add("client_id", c.clientID)
+	v.Add("scope", strings.Join(botScopes, ","))
+
+	if len(userScopes) > 0 {
+		v.Add("user_scope", strings.Join(userScopes, ","))
+	}
+
+	v.Add("redirect_uri", redirect)
+	v.Add("state", state)
+
+	return "https://slack.com/oauth/v2/authorize?" + v.Encode(), nil
+}
+
+// OAuthV2Handler returns a http.HandlerFunc that can complete an OAuth v2 handshake with slack, creating new Bots.
+//
+// expectedState works like it does for OAuthHandler.
+//
+// If token rotation is enabled for the app, the refresh token is stored with the bot,
+// and the bot token is rotated automatically before it expires.
+func (c *Controller) OAuthV2Handler(redirect, expectedState string, onSuccess func(*oauth.AccessResponse, http.ResponseWriter, *http.Request)) http.HandlerFunc {
+	return func(res http.ResponseWriter, req *http.Request) {
+		if c.unavailable(res) {
+			return
+		}
+
+		code, err := c.verifyOAuthRedirect(res, req, expectedState)
+		if err != nil {
+			c.handleOAuthError(errors.Wrap(err, "OAuth Failed"), res, req)
+			return
+		}
+
+		v2res, err := oauth.V2Access(&oauth.V2AccessRequest{ClientID: c.clientID, ClientSecret: c.clientSecret, Code: code, Redirect: redirect})
+		if err != nil {
+			c.handleOAuthError(errors.Wrap(err, "OAuth Failed"), res, req)
 			return
 		}
 
-		b := newBot(payload, c.connector, c.conversations, c.cs)
-		go func() { c.botAdded <- b }()
+		payload := v2res.AccessResponse()
+
+		if err := c.saveBot(payload); err != nil {
+			c.handleOAuthError(errors.Wrap(err, "OAuth Failed"), res, req)
+			return
+		}
+
+		b := newBot(payload, c.connector, c.conversations, c.cs, c.us, c.tr)
+		c.deliver(func(done <-chan struct{}) {
+			select {
+			case c.botAdded <- b:
+			case <-done:
+			}
+		})
 
 		if onSuccess != nil {
 			onSuccess(payload, res, req)
@@ -222,6 +667,47 @@ func (c *Controller) OAuthHandler(redirect, expectedState string, onSuccess func
 	}
 }
 
+// saveBot stores the bot of an install, replacing the stored one when the app is installed again.
+func (c *Controller) saveBot(p *oauth.AccessResponse) error {
+	// not fatal, only messages the bot posts through integrations are not recognized as its own
+	if err := identify(p); err != nil {
+		log.Println(errors.Wrapf(err, "Could not get the bot id of %s", BotKey(p)))
+	}
+
+	err := c.bots.AddBot(p)
+	if errors.Cause(err) != ErrBotAlreadyAdded {
+		return err
+	}
+
+	// the bot was removed in between, add it again
+	if err := UpdateBot(c.bots, p); errors.Cause(err) != ErrBotNotFound {
+		return err
+	}
+
+	return c.bots.AddBot(p)
+}
+
+// identify sets the bot user and bot IDs of a payload from auth.test, if they are missing.
+//
+// Messages posted through integrations as the bot only carry its bot ID, which OAuth does not return.
+func identify(p *oauth.AccessResponse) error {
+	if p.Bot == nil || p.Bot.BotAccessToken == "" || p.Bot.BotID != "" {
+		return nil
+	}
+
+	res, err := auth.Test(&auth.TestRequest{Token: p.Bot.BotAccessToken})
+	if err != nil {
+		return err
+	}
+
+	p.Bot.BotID = res.BotID
+	if p.Bot.BotUserID == "" {
+		p.Bot.BotUserID = res.UserID
+	}
+
+	return nil
+}
+
 // CreateBot adds a new Bot given a slack access token.
 func (c *Controller) CreateBot(token string) (*Bot, error) {
 	info, err := team.Info(&team.InfoRequest{Token: token})
@@ -231,12 +717,21 @@ func (c *Controller) CreateBot(token string) (*Bot, error) {
 
 	teamID := info.Team.ID
 	payload := &oauth.AccessResponse{TeamID: teamID, Bot: &oauth.Bot{BotAccessToken: token}}
+	if err := identify(payload); err != nil {
+		log.Println(errors.Wrapf(err, "Could not get the bot id of %s", teamID))
+	}
+
 	if err := c.bots.AddBot(payload); err != nil {
 		return nil, errors.Wrap(err, "CreateBot Failed")
 	}
 
-	b := newBot(payload, c.connector, c.conversations, c.cs)
-	go func() { c.botAdded <- b }()
+	b := newBot(payload, c.connector, c.conversations, c.cs, c.us, c.tr)
+	c.deliver(func(done <-chan struct{}) {
+		select {
+		case c.botAdded <- b:
+		case <-done:
+		}
+	})
 	return b, nil
 }
 
@@ -245,9 +740,49 @@ func (c *Controller) BotAdded() <-chan *Bot {
 	return c.botAdded
 }
 
+// BotRemoval is sent when a bot is removed from a team.
+type BotRemoval struct {
+	Team string
+
+	// Reason is the event that removed the bot, app_uninstalled or tokens_revoked,
+	// or the slack error if the bot failed to start with a dead token.
+	Reason string
+}
+
+// BotRemoved gets a channel that sends a payload each time a bot is removed from a team.
+func (c *Controller) BotRemoved() <-chan *BotRemoval {
+	return c.botRemoved
+}
+
+// removeBot removes a bot from storage and closes its connection.
+func (c *Controller) removeBot(team, reason string) error {
+	if err := c.bots.RemoveBot(team); err != nil && errors.Cause(err) != ErrBotNotFound {
+		return errors.Wrap(err, "Remove Bot Failed")
+	}
+
+	// bots only receiving events through the Events API will not have a connection
+	c.connector.Remove(team)
+
+	c.deliver(func(done <-chan struct{}) {
+		select {
+		case c.botRemoved <- &BotRemoval{team, reason}:
+		case <-done:
+		}
+	})
+	return nil
+}
+
 // EventHandler returns a http.HandlerFunc that can listen to slack events.
+//
+// Events are acknowledged once they are queued for the event workers, so slack does not retry them when
+// handling is slow, and failures are reported to the EventErrorHandler. Events that were already seen are dropped.
+// While the queue is full, and after DrainEvents, events are rejected with a 503 for slack to retry them later.
 func (c *Controller) EventHandler() http.HandlerFunc {
 	return func(res http.ResponseWriter, req *http.Request) {
+		if c.unavailable(res) {
+			return
+		}
+
 		if req.Method != http.MethodPost {
 			http.Error(res, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
 			return
@@ -276,11 +811,17 @@ func (c *Controller) EventHandler() http.HandlerFunc {
 		case "url_verification":
 			fmt.Fprint(res, t.Challenge)
 		case "event_callback":
-			if err := c.handleEvent(d); err != nil {
+			e := &eventCallback{}
+			if err := json.Unmarshal(d, e); err != nil || len(e.Event) == 0 {
 				http.Error(res, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
 				return
 			}
 
+			if !c.events.push(eventKey(e), d) {
+				http.Error(res, http.StatusText(http.StatusServiceUnavailable), http.StatusServiceUnavailable)
+				return
+			}
+
 			res.WriteHeader(http.StatusOK)
 		}
 	}
@@ -309,42 +850,110 @@ type EventPayload struct {
 	AuthedUsers []string `json:"authed_users"`
 }
 
-func (c *Controller) handleEvent(data []byte) error {
-	p := &EventPayload{
-		Event: &Event{
-			Message: &rtm.Message{},
-		},
-	}
+// ffjson: noencoder
+type eventCallback struct {
+	EventID      string          `json:"event_id"`
+	TeamID       string          `json:"team_id"`
+	EnterpriseID string          `json:"enterprise_id"`
+	Event        json.RawMessage `json:"event"`
+}
 
-	if err := json.Unmarshal(data, p); err != nil {
-		return errors.Wrap(err, "Could not handle event")
+// processEvent handles an event taken from the queue by an event worker.
+//
+// Retries are dropped here and not in EventHandler, so a rejected event is not remembered as seen.
+func (c *Controller) processEvent(d []byte) error {
+	e := &eventCallback{}
+	if err := json.Unmarshal(d, e); err != nil {
+		return err
 	}
 
-	payload, err := c.bots.GetBot(p.TeamID)
-	if err != nil {
-		return errors.Wrap(err, "Could not handle event")
+	if c.seenEvent(e.EventID) {
+		return errDuplicateEvent
 	}
 
-	b := newBot(payload, c.connector, c.conversations, c.cs)
-	switch p.Event.Type {
-	case "message":
-		if err := c.handleNormalMessage(p.Event.Message, b); err != nil {
-			return errors.Wrap(err, "Could not handle event")
+	if err := c.handleEvent(d); err != nil {
+		if c.eventError != nil {
+			c.eventError(d, err)
 		}
+
+		return err
 	}
 
 	return nil
 }
 
-// ffjson: noencoder
-type interaction struct {
-	*Interaction
-	Token string `json:"token"`
+// EventStats returns the counters of the event workers.
+func (c *Controller) EventStats() EventStats {
+	return c.events.stats()
+}
+
+// DrainEvents stops taking events, and waits for the queued events to be handled or for ctx to be done.
+// EventHandler rejects the events received after it was called.
+func (c *Controller) DrainEvents(ctx context.Context) error {
+	if err := c.events.drain(ctx); err != nil {
+		return errors.Wrap(err, "DrainEvents Failed")
+	}
+
+	return nil
+}
+
+// seenEvent checks if an event was already handled, which is the case for retries.
+// Events are handled if the SeenStore fails, as a duplicate is better than a lost event.
+func (c *Controller) seenEvent(id string) bool {
+	if id == "" {
+		return false
+	}
+
+	seen, err := c.seen.Seen("event:" + id)
+	return err == nil && seen
+}
+
+func (c *Controller) handleEvent(data []byte) error {
+	e := &eventCallback{}
+	if err := json.Unmarshal(data, e); err != nil {
+		return errors.Wrap(err, "Could not handle event")
+	}
+
+	t := &typ{}
+	if err := json.Unmarshal(e.Event, t); err != nil {
+		return errors.Wrap(err, "Could not handle event")
+	}
+
+	// resolve the install the event was delivered for, which for
+	// Enterprise Grid orgs may be keyed by enterprise, or be org-wide.
+	team := e.TeamID
+	if payload, err := FindBot(c.bots, e.EnterpriseID, e.TeamID); err == nil {
+		team = BotKey(payload)
+	}
+
+	switch t.Type {
+	case "app_uninstalled":
+		return c.removeBot(team, t.Type)
+	case "tokens_revoked":
+		return c.handleTokensRevoked(e.Event, team)
+	}
+
+	// the other events have the same payloads as over RTM
+	if err := c.handleMessage(e.Event, team); err != nil {
+		return errors.Wrap(err, "Could not handle event")
+	}
+
+	return nil
+}
+
+// ffjson: noencoder
+type interaction struct {
+	*Interaction
+	Token string `json:"token"`
 }
 
 // InteractionHandler returns a http.HandlerFunc that can be used to handle interactions from slack.
 func (c *Controller) InteractionHandler() http.HandlerFunc {
 	return func(res http.ResponseWriter, req *http.Request) {
+		if c.unavailable(res) {
+			return
+		}
+
 		if req.Method != http.MethodPost {
 			http.Error(res, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
 			return
@@ -375,15 +984,20 @@ func (c *Controller) InteractionHandler() http.HandlerFunc {
 			return
 		}
 
-		payload, err := c.bots.GetBot(iact.Team.ID)
+		payload, err := FindBot(c.bots, iact.Team.EnterpriseID, iact.Team.ID)
 		if err != nil {
 			http.Error(res, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
 			return
 		}
 
 		iact.immediateResponse, iact.token = make(chan []byte), payload.AccessToken
-		b := newBot(payload, c.connector, c.conversations, c.cs)
-		go func() { c.interactions <- &InteractionPair{iact.Interaction, b} }()
+		b := newBot(payload, c.connector, c.conversations, c.cs, c.us, c.tr)
+		c.deliver(func(done <-chan struct{}) {
+			select {
+			case c.interactions <- &InteractionPair{iact.Interaction, b}:
+			case <-done:
+			}
+		})
 
 		select {
 		case m := <-iact.immediateResponse:
@@ -410,6 +1024,10 @@ type interactionOptions struct {
 // NOTE: this blocks calling thread
 func (c *Controller) InteractionOptionsHandler() http.HandlerFunc {
 	return func(res http.ResponseWriter, req *http.Request) {
+		if c.unavailable(res) {
+			return
+		}
+
 		if req.Method != http.MethodPost {
 			http.Error(res, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
 			return
@@ -451,15 +1069,20 @@ func (c *Controller) InteractionOptionsHandler() http.HandlerFunc {
 			return
 		}
 
-		payload, err := c.bots.GetBot(iactopt.Team.ID)
+		payload, err := FindBot(c.bots, iactopt.Team.EnterpriseID, iactopt.Team.ID)
 		if err != nil {
 			http.Error(res, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
 			return
 		}
 
 		iactopt.immediateResponse = make(chan []byte)
-		b := newBot(payload, c.connector, c.conversations, c.cs)
-		go func() { c.interactionOptions <- &InteractionOptionsPair{iactopt.InteractionOptions, b} }()
+		b := newBot(payload, c.connector, c.conversations, c.cs, c.us, c.tr)
+		c.deliver(func(done <-chan struct{}) {
+			select {
+			case c.interactionOptions <- &InteractionOptionsPair{iactopt.InteractionOptions, b}:
+			case <-done:
+			}
+		})
 
 		m := <-iactopt.immediateResponse
 
@@ -472,6 +1095,10 @@ func (c *Controller) InteractionOptionsHandler() http.HandlerFunc {
 // CommandHandler returns a http.HandlerFunc that can handle slack Command requests.
 func (c *Controller) CommandHandler() http.HandlerFunc {
 	return func(res http.ResponseWriter, req *http.Request) {
+		if c.unavailable(res) {
+			return
+		}
+
 		if req.Method != http.MethodPost {
 			http.Error(res, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
 			return
@@ -502,7 +1129,18 @@ func (c *Controller) CommandHandler() http.HandlerFunc {
 		}
 
 		command := newCommand(values)
-		go func() { c.commands <- command }()
+
+		// commands can be used by apps without a bot in the team
+		if payload, err := FindBot(c.bots, command.EnterpriseID, command.TeamID); err == nil {
+			command.bot = newBot(payload, c.connector, c.conversations, c.cs, c.us, c.tr)
+		}
+
+		c.deliver(func(done <-chan struct{}) {
+			select {
+			case c.commands <- command:
+			case <-done:
+			}
+		})
 
 		select {
 		case m := <-command.immediateResponse:
@@ -535,11 +1173,11 @@ func (c *Controller) Commands() <-chan *Command {
 
 // ffjson: noencoder
 type typ struct {
-	SubType   string `json:"subtype"`
-	Type      string `json:"type"`
-	User      string `json:"user"`
-	Token     string `json:"token"`
-	Challenge string `json:"challenge"`
+	SubType   string          `json:"subtype"`
+	Type      string          `json:"type"`
+	User      json.RawMessage `json:"user"`
+	Token     string          `json:"token"`
+	Challenge string          `json:"challenge"`
 }
 
 func (c *Controller) handleMessage(msg []byte, team string) error {
@@ -550,45 +1188,83 @@ func (c *Controller) handleMessage(msg []byte, team string) error {
 
 	switch t.Type {
 	case "message":
-		return c.handleMessageType(msg, t.SubType, t.User, team)
+		// user is the id of a user in messages, but a user object in user events
+		var user string
+		json.Unmarshal(t.User, &user)
+
+		return c.handleMessageType(msg, t.SubType, user, team)
+	case "goodbye":
+		return c.handleGoodbye(team)
+	case "user_change", "team_join":
+		return c.handleUserEvent(msg, team)
+	case "reaction_added", "reaction_removed":
+		return c.handleReaction(msg, t.Type, team)
+	case "file_shared":
+		return c.handleFileShared(msg, team)
+	case "link_shared":
+		return c.handleLinkShared(msg, team)
+	case "member_joined_channel", "member_left_channel":
+		return c.handleMemberChannel(msg, t.Type, team)
+	case "presence_change":
+		return c.handlePresenceChange(msg, team)
+	case "user_typing":
+		return c.handleUserTyping(msg, team)
 	default:
-		return nil
+		return c.handleRTMEvent(msg, t.Type, team)
 	}
 }
 
 // handleMessageType handles the case where the type field of an RTM command is 'message'
-// It categorizes the message into 8 types, each of which have their respective channels
+// It categorizes the message into 11 types, each of which have their respective channels
 // The categories are
 //
 // - DirectMessage
-// - SelfMessage
 // - DirectMention
 // - Mention
 // - Ambient
 // - ChannelJoin
 // - UserChannelJoin
 // - GroupJoin
+// - MessageChanged
+// - MessageDeleted
+// - BotMessage
+//
+// Other subtypes go to the RTMEventHandler registered for "message/<subtype>".
 func (c *Controller) handleMessageType(msg []byte, subtype, user, team string) error {
+	if !c.subscribed(subtype) {
+		return nil
+	}
+
 	payload, err := c.bots.GetBot(team)
 	if err != nil {
 		return errors.Wrap(err, "Could not handle Message Type")
 	}
 
-	bot := newBot(payload, c.connector, c.conversations, c.cs)
+	bot := newBot(payload, c.connector, c.conversations, c.cs, c.us, c.tr)
 
-	if subtype != "" {
-		if subtype == "channel_join" {
-			if user != bot.id {
-				c.handleUserChannelJoin(msg, bot)
-			} else {
-				c.handleChannelJoin(msg, bot)
-			}
-		} else if subtype == "group_join" {
-			c.handleGroupJoin(msg, bot)
+	switch subtype {
+	// NOTE: messages with files, thread replies also posted to the channel and /me messages
+	// are handled like normal messages, with the shared files available in the Files field.
+	case "", "file_share", "thread_broadcast", "me_message":
+	case "channel_join":
+		if user != bot.id {
+			c.handleUserChannelJoin(msg, bot)
+		} else {
+			c.handleChannelJoin(msg, bot)
 		}
 
-		// NOTE: this helps us skip handling a message when subtype is
-		// - "message_changed"
+		return nil
+	case "group_join":
+		c.handleGroupJoin(msg, bot)
+		return nil
+	case "message_changed":
+		return c.handleMessageChanged(msg, bot)
+	case "message_deleted":
+		return c.handleMessageDeleted(msg, bot)
+	case "bot_message":
+		return c.handleBotMessage(msg, bot)
+	default:
+		c.runRTMEventHandler("message/"+subtype, msg, bot)
 		return nil
 	}
 
@@ -597,29 +1273,60 @@ func (c *Controller) handleMessageType(msg []byte, subtype, user, team string) e
 		return errors.Wrap(err, "Could not handle Message Type")
 	}
 
+	if c.loops.self(bot, m.User, m.BotID) {
+		return nil
+	}
+
+	// a message is delivered more than once when it is received over both RTM and the Events API,
+	// or when a connector delivers it again
+	if m.ClientMsgID != "" && c.seenEvent(bot.key+":"+m.ClientMsgID) {
+		return nil
+	}
+
+	// bot users post messages without a subtype
+	if m.BotID != "" {
+		return c.handleBotMessage(msg, bot)
+	}
+
 	c.handleNormalMessage(m, bot)
 	return nil
 }
 
 func (c *Controller) handleNormalMessage(msg *rtm.Message, bot *Bot) error {
-	if id, state, err := c.cs.Active(msg.User, msg.Channel, msg.Team); err == nil {
+	// messages in a conversation are handled one at a time, in order,
+	// so a handler always sees the state left by the previous one
+	unlock := c.convLocks.lock(msg.User, msg.Channel, bot.teamID)
+
+	// NOTE: in shared channels, msg.Team can be the team of the sender,
+	// conversations are started in the team of the bot.
+	if id, state, version, err := ActiveVersion(c.cs, msg.User, msg.Channel, bot.teamID); err == nil {
+		defer unlock()
+
 		conv, err := c.conversations.Get(id)
 		if err != nil {
 			return err
 		}
 
-		conv.mp[state](&chat.Message{
+		// a state the conversation does not define, left by a store or an older version of the app
+		handler, ok := conv.mp[state]
+		if !ok {
+			return errors.Wrapf(ErrStateNotFound, "conversation %s has no state %q", id, state)
+		}
+
+		handler(&chat.Message{
 			Channel:  msg.Channel,
 			Text:     msg.Text,
 			Ts:       msg.Ts,
 			ThreadTs: msg.ThreadTs,
 		}, &Controls{
-			bot, msg.User, msg.Channel,
+			bot, msg.User, msg.Channel, version,
 		})
 
 		return nil
 	}
 
+	unlock()
+
 	if strings.HasPrefix(msg.Channel, "D") {
 		return c.handleDirectMessage(msg, bot)
 	} else if strings.HasPrefix(msg.Text, "<@"+bot.id) {
@@ -632,7 +1339,12 @@ func (c *Controller) handleNormalMessage(msg *rtm.Message, bot *Bot) error {
 }
 
 func (c *Controller) handleDirectMessage(m *rtm.Message, b *Bot) error {
-	go func() { c.directMessages <- &MessagePair{m, b} }()
+	c.deliver(func(done <-chan struct{}) {
+		select {
+		case c.directMessages <- &MessagePair{m, b}:
+		case <-done:
+		}
+	})
 	return nil
 }
 
@@ -642,7 +1354,12 @@ func (c *Controller) DirectMessages() <-chan *MessagePair {
 }
 
 func (c *Controller) handleDirectMention(m *rtm.Message, b *Bot) error {
-	go func() { c.directMentions <- &MessagePair{m, b} }()
+	c.deliver(func(done <-chan struct{}) {
+		select {
+		case c.directMentions <- &MessagePair{m, b}:
+		case <-done:
+		}
+	})
 	return nil
 }
 
@@ -652,7 +1369,12 @@ func (c *Controller) DirectMentions() <-chan *MessagePair {
 }
 
 func (c *Controller) handleMention(m *rtm.Message, b *Bot) error {
-	go func() { c.mentions <- &MessagePair{m, b} }()
+	c.deliver(func(done <-chan struct{}) {
+		select {
+		case c.mentions <- &MessagePair{m, b}:
+		case <-done:
+		}
+	})
 	return nil
 }
 
@@ -662,7 +1384,12 @@ func (c *Controller) Mentions() <-chan *MessagePair {
 }
 
 func (c *Controller) handleAmbientMessage(m *rtm.Message, b *Bot) error {
-	go func() { c.ambientMessages <- &MessagePair{m, b} }()
+	c.deliver(func(done <-chan struct{}) {
+		select {
+		case c.ambientMessages <- &MessagePair{m, b}:
+		case <-done:
+		}
+	})
 	return nil
 }
 
@@ -677,7 +1404,12 @@ func (c *Controller) handleChannelJoin(msg []byte, b *Bot) {
 		return
 	}
 
-	go func() { c.channelJoin <- &ChannelJoinMessagePair{m, b} }()
+	c.deliver(func(done <-chan struct{}) {
+		select {
+		case c.channelJoin <- &ChannelJoinMessagePair{m, b}:
+		case <-done:
+		}
+	})
 }
 
 // ChannelJoin sends a payload each time the bot is added to a new channel.
@@ -691,7 +1423,12 @@ func (c *Controller) handleUserChannelJoin(msg []byte, bot *Bot) {
 		return
 	}
 
-	go func() { c.userChannelJoin <- &UserChannelJoinMessagePair{m, bot} }()
+	c.deliver(func(done <-chan struct{}) {
+		select {
+		case c.userChannelJoin <- &UserChannelJoinMessagePair{m, bot}:
+		case <-done:
+		}
+	})
 }
 
 // UserChannelJoin sends a payload each time a user joins a new channel.
@@ -705,7 +1442,12 @@ func (c *Controller) handleGroupJoin(msg []byte, bot *Bot) {
 		return
 	}
 
-	go func() { c.groupJoin <- &GroupJoinMessagePair{m, bot} }()
+	c.deliver(func(done <-chan struct{}) {
+		select {
+		case c.groupJoin <- &GroupJoinMessagePair{m, bot}:
+		case <-done:
+		}
+	})
 }
 
 // GroupJoin sends a payload each time a user joins a group chat.
@@ -713,6 +1455,538 @@ func (c *Controller) GroupJoin() <-chan *GroupJoinMessagePair {
 	return c.groupJoin
 }
 
+// subscribed checks if there is a channel for a message subtype that is only delivered once its accessor is called.
+func (c *Controller) subscribed(subtype string) bool {
+	c.subsMu.RLock()
+	defer c.subsMu.RUnlock()
+
+	switch subtype {
+	case "message_changed":
+		return c.messageChanged != nil
+	case "message_deleted":
+		return c.messageDeleted != nil
+	}
+
+	return true
+}
+
+func (c *Controller) handleMessageChanged(msg []byte, b *Bot) error {
+	c.subsMu.RLock()
+	ch := c.messageChanged
+	c.subsMu.RUnlock()
+
+	if ch == nil {
+		return nil
+	}
+
+	m := &rtm.MessageChangedMessage{}
+	if err := json.Unmarshal(msg, m); err != nil {
+		return errors.Wrap(err, "Could not handle message changed")
+	}
+
+	c.deliver(func(done <-chan struct{}) {
+		select {
+		case ch <- &MessageChangedPair{m, b}:
+		case <-done:
+		}
+	})
+	return nil
+}
+
+// MessageChanged sends a payload each time a message is edited.
+func (c *Controller) MessageChanged() <-chan *MessageChangedPair {
+	c.subsMu.Lock()
+	defer c.subsMu.Unlock()
+
+	if c.messageChanged == nil {
+		c.messageChanged = make(chan *MessageChangedPair)
+		if c.subsClosed {
+			close(c.messageChanged)
+		}
+	}
+
+	return c.messageChanged
+}
+
+func (c *Controller) handleMessageDeleted(msg []byte, b *Bot) error {
+	c.subsMu.RLock()
+	ch := c.messageDeleted
+	c.subsMu.RUnlock()
+
+	if ch == nil {
+		return nil
+	}
+
+	m := &rtm.MessageDeletedMessage{}
+	if err := json.Unmarshal(msg, m); err != nil {
+		return errors.Wrap(err, "Could not handle message deleted")
+	}
+
+	c.deliver(func(done <-chan struct{}) {
+		select {
+		case ch <- &MessageDeletedPair{m, b}:
+		case <-done:
+		}
+	})
+	return nil
+}
+
+// MessageDeleted sends a payload each time a message is deleted.
+func (c *Controller) MessageDeleted() <-chan *MessageDeletedPair {
+	c.subsMu.Lock()
+	defer c.subsMu.Unlock()
+
+	if c.messageDeleted == nil {
+		c.messageDeleted = make(chan *MessageDeletedPair)
+		if c.subsClosed {
+			close(c.messageDeleted)
+		}
+	}
+
+	return c.messageDeleted
+}
+
+// handleBotMessage handles messages of integrations and other bots, according to the BotMessagePolicy.
+// Messages of the bot itself and messages repeating the same text too often are dropped.
+func (c *Controller) handleBotMessage(msg []byte, b *Bot) error {
+	m := &rtm.BotMessage{}
+	if err := json.Unmarshal(msg, m); err != nil {
+		return errors.Wrap(err, "Could not handle bot message")
+	}
+
+	if c.loops.self(b, m.User, m.BotID) || c.loops.policy == BotMessagesIgnore {
+		return nil
+	}
+
+	if c.loops.repeated(b, m.Channel, m.Text) {
+		return nil
+	}
+
+	if c.loops.policy == BotMessagesHandle {
+		nm := &rtm.Message{}
+		if err := json.Unmarshal(msg, nm); err != nil {
+			return errors.Wrap(err, "Could not handle bot message")
+		}
+
+		return c.handleNormalMessage(nm, b)
+	}
+
+	c.subsMu.RLock()
+	ch := c.botMessages
+	c.subsMu.RUnlock()
+
+	if ch == nil {
+		return nil
+	}
+
+	c.deliver(func(done <-chan struct{}) {
+		select {
+		case ch <- &BotMessagePair{m, b}:
+		case <-done:
+		}
+	})
+	return nil
+}
+
+// BotMessages sends a payload each time an integration or another bot sends a message.
+func (c *Controller) BotMessages() <-chan *BotMessagePair {
+	c.subsMu.Lock()
+	defer c.subsMu.Unlock()
+
+	if c.botMessages == nil {
+		c.botMessages = make(chan *BotMessagePair)
+		if c.subsClosed {
+			close(c.botMessages)
+		}
+	}
+
+	return c.botMessages
+}
+
+func (c *Controller) handleMemberChannel(msg []byte, typ, team string) error {
+	c.subsMu.RLock()
+	ch := c.memberLeft
+	if typ == "member_joined_channel" {
+		ch = c.memberJoined
+	}
+	c.subsMu.RUnlock()
+
+	if ch == nil {
+		return nil
+	}
+
+	payload, err := c.bots.GetBot(team)
+	if err != nil {
+		return errors.Wrap(err, "Could not handle member channel event")
+	}
+
+	m := &rtm.MemberChannelEvent{}
+	if err := json.Unmarshal(msg, m); err != nil {
+		return errors.Wrap(err, "Could not handle member channel event")
+	}
+
+	b := newBot(payload, c.connector, c.conversations, c.cs, c.us, c.tr)
+	c.deliver(func(done <-chan struct{}) {
+		select {
+		case ch <- &MemberChannelPair{m, b}:
+		case <-done:
+		}
+	})
+	return nil
+}
+
+// MemberJoinedChannel sends a payload each time a user joins a channel the bot is in.
+func (c *Controller) MemberJoinedChannel() <-chan *MemberChannelPair {
+	c.subsMu.Lock()
+	defer c.subsMu.Unlock()
+
+	if c.memberJoined == nil {
+		c.memberJoined = make(chan *MemberChannelPair)
+		if c.subsClosed {
+			close(c.memberJoined)
+		}
+	}
+
+	return c.memberJoined
+}
+
+// MemberLeftChannel sends a payload each time a user leaves a channel the bot is in.
+func (c *Controller) MemberLeftChannel() <-chan *MemberChannelPair {
+	c.subsMu.Lock()
+	defer c.subsMu.Unlock()
+
+	if c.memberLeft == nil {
+		c.memberLeft = make(chan *MemberChannelPair)
+		if c.subsClosed {
+			close(c.memberLeft)
+		}
+	}
+
+	return c.memberLeft
+}
+
+func (c *Controller) handlePresenceChange(msg []byte, team string) error {
+	c.subsMu.RLock()
+	ch := c.presenceChange
+	c.subsMu.RUnlock()
+
+	if ch == nil {
+		return nil
+	}
+
+	payload, err := c.bots.GetBot(team)
+	if err != nil {
+		return errors.Wrap(err, "Could not handle presence change")
+	}
+
+	m := &rtm.PresenceChangeEvent{}
+	if err := json.Unmarshal(msg, m); err != nil {
+		return errors.Wrap(err, "Could not handle presence change")
+	}
+
+	b := newBot(payload, c.connector, c.conversations, c.cs, c.us, c.tr)
+	c.deliver(func(done <-chan struct{}) {
+		select {
+		case ch <- &PresenceChangePair{m, b}:
+		case <-done:
+		}
+	})
+	return nil
+}
+
+// PresenceChange sends a payload each time the presence of a user changes,
+// for the users a bot subscribed to by sending a presence_sub with Bot.Send.
+func (c *Controller) PresenceChange() <-chan *PresenceChangePair {
+	c.subsMu.Lock()
+	defer c.subsMu.Unlock()
+
+	if c.presenceChange == nil {
+		c.presenceChange = make(chan *PresenceChangePair)
+		if c.subsClosed {
+			close(c.presenceChange)
+		}
+	}
+
+	return c.presenceChange
+}
+
+func (c *Controller) handleUserTyping(msg []byte, team string) error {
+	c.subsMu.RLock()
+	ch := c.userTyping
+	c.subsMu.RUnlock()
+
+	if ch == nil {
+		return nil
+	}
+
+	payload, err := c.bots.GetBot(team)
+	if err != nil {
+		return errors.Wrap(err, "Could not handle user typing")
+	}
+
+	m := &rtm.UserTypingEvent{}
+	if err := json.Unmarshal(msg, m); err != nil {
+		return errors.Wrap(err, "Could not handle user typing")
+	}
+
+	b := newBot(payload, c.connector, c.conversations, c.cs, c.us, c.tr)
+	c.deliver(func(done <-chan struct{}) {
+		select {
+		case ch <- &UserTypingPair{m, b}:
+		case <-done:
+		}
+	})
+	return nil
+}
+
+// UserTyping sends a payload each time a user is typing in a channel the bot is in.
+func (c *Controller) UserTyping() <-chan *UserTypingPair {
+	c.subsMu.Lock()
+	defer c.subsMu.Unlock()
+
+	if c.userTyping == nil {
+		c.userTyping = make(chan *UserTypingPair)
+		if c.subsClosed {
+			close(c.userTyping)
+		}
+	}
+
+	return c.userTyping
+}
+
+// RTMEventHandler handles an RTM event that has no channel on the Controller, msg is the raw event.
+type RTMEventHandler func(msg []byte, bot *Bot)
+
+// RegisterRTMEventHandler registers a handler for an RTM event type, like "channel_created" or "pin_added".
+// Messages with a subtype that has no channel are registered as "message/<subtype>", like "message/channel_topic".
+//
+// Events with neither a channel nor a handler are ignored.
+func (c *Controller) RegisterRTMEventHandler(typ string, handler RTMEventHandler) error {
+	c.rtmEventsMu.Lock()
+	defer c.rtmEventsMu.Unlock()
+
+	if _, ok := c.rtmEvents[typ]; ok {
+		return ErrRTMEventHandlerExists
+	}
+
+	c.rtmEvents[typ] = handler
+	return nil
+}
+
+func (c *Controller) rtmEventHandler(typ string) RTMEventHandler {
+	c.rtmEventsMu.RLock()
+	defer c.rtmEventsMu.RUnlock()
+
+	return c.rtmEvents[typ]
+}
+
+func (c *Controller) handleRTMEvent(msg []byte, typ, team string) error {
+	if c.rtmEventHandler(typ) == nil {
+		return nil
+	}
+
+	payload, err := c.bots.GetBot(team)
+	if err != nil {
+		return errors.Wrap(err, "Could not handle RTM event")
+	}
+
+	c.runRTMEventHandler(typ, msg, newBot(payload, c.connector, c.conversations, c.cs, c.us, c.tr))
+	return nil
+}
+
+func (c *Controller) runRTMEventHandler(typ string, msg []byte, b *Bot) {
+	if h := c.rtmEventHandler(typ); h != nil {
+		c.spawn(func() { h(msg, b) })
+	}
+}
+
+// ffjson: noencoder
+type userEvent struct {
+	Type string      `json:"type"`
+	User *users.User `json:"user"`
+}
+
+// handleUserEvent invalidates the cached user for user_change and team_join events,
+// team is the key of the bot, which Bot.UserInfo caches users by.
+func (c *Controller) handleUserEvent(msg []byte, team string) error {
+	e := &userEvent{}
+	if err := json.Unmarshal(msg, e); err != nil {
+		return errors.Wrap(err, "Could not handle user event")
+	}
+
+	if e.User == nil {
+		return nil
+	}
+
+	return c.us.RemoveUser(team, e.User.ID)
+}
+
+// ffjson: noencoder
+type tokensRevokedEvent struct {
+	Tokens struct {
+		OAuth []string `json:"oauth"`
+		Bot   []string `json:"bot"`
+	} `json:"tokens"`
+}
+
+// handleTokensRevoked removes the bot for a team if its token was revoked.
+func (c *Controller) handleTokensRevoked(msg []byte, team string) error {
+	e := &tokensRevokedEvent{}
+	if err := json.Unmarshal(msg, e); err != nil {
+		return errors.Wrap(err, "Could not handle tokens revoked")
+	}
+
+	if len(e.Tokens.Bot) == 0 {
+		return nil
+	}
+
+	payload, err := c.bots.GetBot(team)
+	if err != nil {
+		if errors.Cause(err) == ErrBotNotFound {
+			return nil
+		}
+
+		return errors.Wrap(err, "Could not handle tokens revoked")
+	}
+
+	for _, id := range e.Tokens.Bot {
+		if payload.Bot == nil || payload.Bot.BotUserID == "" || id == payload.Bot.BotUserID {
+			return c.removeBot(team, "tokens_revoked")
+		}
+	}
+
+	return nil
+}
+
+func (c *Controller) handleReaction(msg []byte, typ, team string) error {
+	payload, err := c.bots.GetBot(team)
+	if err != nil {
+		return errors.Wrap(err, "Could not handle reaction")
+	}
+
+	m := &rtm.ReactionMessage{}
+	if err := json.Unmarshal(msg, m); err != nil {
+		return errors.Wrap(err, "Could not handle reaction")
+	}
+
+	b := newBot(payload, c.connector, c.conversations, c.cs, c.us, c.tr)
+	if typ == "reaction_added" {
+		c.deliver(func(done <-chan struct{}) {
+			select {
+			case c.reactionAdded <- &ReactionPair{m, b}:
+			case <-done:
+			}
+		})
+	} else {
+		c.deliver(func(done <-chan struct{}) {
+			select {
+			case c.reactionRemoved <- &ReactionPair{m, b}:
+			case <-done:
+			}
+		})
+	}
+
+	return nil
+}
+
+// ReactionAdded sends a payload each time a reaction is added to a message.
+func (c *Controller) ReactionAdded() <-chan *ReactionPair {
+	return c.reactionAdded
+}
+
+// ReactionRemoved sends a payload each time a reaction is removed from a message.
+func (c *Controller) ReactionRemoved() <-chan *ReactionPair {
+	return c.reactionRemoved
+}
+
+func (c *Controller) handleFileShared(msg []byte, team string) error {
+	payload, err := c.bots.GetBot(team)
+	if err != nil {
+		return errors.Wrap(err, "Could not handle file shared")
+	}
+
+	m := &rtm.FileSharedMessage{}
+	if err := json.Unmarshal(msg, m); err != nil {
+		return errors.Wrap(err, "Could not handle file shared")
+	}
+
+	b := newBot(payload, c.connector, c.conversations, c.cs, c.us, c.tr)
+	c.deliver(func(done <-chan struct{}) {
+		select {
+		case c.fileShared <- &FileSharedPair{m, b}:
+		case <-done:
+		}
+	})
+	return nil
+}
+
+// FileShared sends a payload each time a file is shared in a conversation the bot is in.
+func (c *Controller) FileShared() <-chan *FileSharedPair {
+	return c.fileShared
+}
+
+// handleLinkShared unfurls the links that have a registered UnfurlHandler
+// and sends the rest to the LinkShared channel.
+func (c *Controller) handleLinkShared(msg []byte, team string) error {
+	payload, err := c.bots.GetBot(team)
+	if err != nil {
+		return errors.Wrap(err, "Could not handle link shared")
+	}
+
+	m := &rtm.LinkSharedMessage{}
+	if err := json.Unmarshal(msg, m); err != nil {
+		return errors.Wrap(err, "Could not handle link shared")
+	}
+
+	b := newBot(payload, c.connector, c.conversations, c.cs, c.us, c.tr)
+
+	handled, unhandled := make([]*rtm.Link, 0, len(m.Links)), make([]*rtm.Link, 0, len(m.Links))
+	for _, l := range m.Links {
+		if _, err := c.unfurls.Get(l); err == nil {
+			handled = append(handled, l)
+		} else {
+			unhandled = append(unhandled, l)
+		}
+	}
+
+	if len(handled) > 0 {
+		c.spawn(func() {
+			if unfurls := c.unfurls.unfurl(handled, b); len(unfurls) > 0 {
+				b.Unfurl(m.Channel, m.MessageTs, unfurls)
+			}
+		})
+	}
+
+	if len(unhandled) > 0 {
+		um := *m
+		um.Links = unhandled
+		c.deliver(func(done <-chan struct{}) {
+			select {
+			case c.linkShared <- &LinkSharedPair{&um, b}:
+			case <-done:
+			}
+		})
+	}
+
+	return nil
+}
+
+// LinkShared sends a payload each time links without a registered UnfurlHandler
+// are shared in a conversation.
+func (c *Controller) LinkShared() <-chan *LinkSharedPair {
+	return c.linkShared
+}
+
+// RegisterUnfurlDomain registers a handler to unfurl links on a domain and its subdomains.
+func (c *Controller) RegisterUnfurlDomain(domain string, handler UnfurlHandler) error {
+	return c.unfurls.AddDomain(domain, handler)
+}
+
+// RegisterUnfurlPattern registers a handler to unfurl links that match a pattern.
+func (c *Controller) RegisterUnfurlPattern(pattern *regexp.Regexp, handler UnfurlHandler) error {
+	return c.unfurls.AddPattern(pattern, handler)
+}
+
 //
 // Interactions
 //
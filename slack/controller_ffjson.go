@@ -10,8 +10,254 @@ import (
 	"fmt"
 	fflib "github.com/pquerna/ffjson/fflib/v1"
 	"suy.io/bots/slack/api/chat"
+	"suy.io/bots/slack/api/files"
+	"suy.io/bots/slack/api/rtm"
+	"suy.io/bots/slack/api/users"
 )
 
+// MarshalJSON marshal bytes to json - template
+func (j *BotRemoval) MarshalJSON() ([]byte, error) {
+	var buf fflib.Buffer
+	if j == nil {
+		buf.WriteString("null")
+		return buf.Bytes(), nil
+	}
+	err := j.MarshalJSONBuf(&buf)
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// MarshalJSONBuf marshal buff to json - template
+func (j *BotRemoval) MarshalJSONBuf(buf fflib.EncodingBuffer) error {
+	if j == nil {
+		buf.WriteString("null")
+		return nil
+	}
+	var err error
+	var obj []byte
+	_ = obj
+	_ = err
+	buf.WriteString(`{"Team":`)
+	fflib.WriteJsonString(buf, string(j.Team))
+	buf.WriteString(`,"Reason":`)
+	fflib.WriteJsonString(buf, string(j.Reason))
+	buf.WriteByte('}')
+	return nil
+}
+
+const (
+	ffjtBotRemovalbase = iota
+	ffjtBotRemovalnosuchkey
+
+	ffjtBotRemovalTeam
+
+	ffjtBotRemovalReason
+)
+
+var ffjKeyBotRemovalTeam = []byte("Team")
+
+var ffjKeyBotRemovalReason = []byte("Reason")
+
+// UnmarshalJSON umarshall json - template of ffjson
+func (j *BotRemoval) UnmarshalJSON(input []byte) error {
+	fs := fflib.NewFFLexer(input)
+	return j.UnmarshalJSONFFLexer(fs, fflib.FFParse_map_start)
+}
+
+// UnmarshalJSONFFLexer fast json unmarshall - template ffjson
+func (j *BotRemoval) UnmarshalJSONFFLexer(fs *fflib.FFLexer, state fflib.FFParseState) error {
+	var err error
+	currentKey := ffjtBotRemovalbase
+	_ = currentKey
+	tok := fflib.FFTok_init
+	wantedTok := fflib.FFTok_init
+
+mainparse:
+	for {
+		tok = fs.Scan()
+		//	println(fmt.Sprintf("debug: tok: %v  state: %v", tok, state))
+		if tok == fflib.FFTok_error {
+			goto tokerror
+		}
+
+		switch state {
+
+		case fflib.FFParse_map_start:
+			if tok != fflib.FFTok_left_bracket {
+				wantedTok = fflib.FFTok_left_bracket
+				goto wrongtokenerror
+			}
+			state = fflib.FFParse_want_key
+			continue
+
+		case fflib.FFParse_after_value:
+			if tok == fflib.FFTok_comma {
+				state = fflib.FFParse_want_key
+			} else if tok == fflib.FFTok_right_bracket {
+				goto done
+			} else {
+				wantedTok = fflib.FFTok_comma
+				goto wrongtokenerror
+			}
+
+		case fflib.FFParse_want_key:
+			// json {} ended. goto exit. woo.
+			if tok == fflib.FFTok_right_bracket {
+				goto done
+			}
+			if tok != fflib.FFTok_string {
+				wantedTok = fflib.FFTok_string
+				goto wrongtokenerror
+			}
+
+			kn := fs.Output.Bytes()
+			if len(kn) <= 0 {
+				// "" case. hrm.
+				currentKey = ffjtBotRemovalnosuchkey
+				state = fflib.FFParse_want_colon
+				goto mainparse
+			} else {
+				switch kn[0] {
+
+				case 'R':
+
+					if bytes.Equal(ffjKeyBotRemovalReason, kn) {
+						currentKey = ffjtBotRemovalReason
+						state = fflib.FFParse_want_colon
+						goto mainparse
+					}
+
+				case 'T':
+
+					if bytes.Equal(ffjKeyBotRemovalTeam, kn) {
+						currentKey = ffjtBotRemovalTeam
+						state = fflib.FFParse_want_colon
+						goto mainparse
+					}
+
+				}
+
+				if fflib.EqualFoldRight(ffjKeyBotRemovalReason, kn) {
+					currentKey = ffjtBotRemovalReason
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
+				if fflib.SimpleLetterEqualFold(ffjKeyBotRemovalTeam, kn) {
+					currentKey = ffjtBotRemovalTeam
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
+				currentKey = ffjtBotRemovalnosuchkey
+				state = fflib.FFParse_want_colon
+				goto mainparse
+			}
+
+		case fflib.FFParse_want_colon:
+			if tok != fflib.FFTok_colon {
+				wantedTok = fflib.FFTok_colon
+				goto wrongtokenerror
+			}
+			state = fflib.FFParse_want_value
+			continue
+		case fflib.FFParse_want_value:
+
+			if tok == fflib.FFTok_left_brace || tok == fflib.FFTok_left_bracket || tok == fflib.FFTok_integer || tok == fflib.FFTok_double || tok == fflib.FFTok_string || tok == fflib.FFTok_bool || tok == fflib.FFTok_null {
+				switch currentKey {
+
+				case ffjtBotRemovalTeam:
+					goto handle_Team
+
+				case ffjtBotRemovalReason:
+					goto handle_Reason
+
+				case ffjtBotRemovalnosuchkey:
+					err = fs.SkipField(tok)
+					if err != nil {
+						return fs.WrapErr(err)
+					}
+					state = fflib.FFParse_after_value
+					goto mainparse
+				}
+			} else {
+				goto wantedvalue
+			}
+		}
+	}
+
+handle_Team:
+
+	/* handler: j.Team type=string kind=string quoted=false*/
+
+	{
+
+		{
+			if tok != fflib.FFTok_string && tok != fflib.FFTok_null {
+				return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for string", tok))
+			}
+		}
+
+		if tok == fflib.FFTok_null {
+
+		} else {
+
+			outBuf := fs.Output.Bytes()
+
+			j.Team = string(string(outBuf))
+
+		}
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+handle_Reason:
+
+	/* handler: j.Reason type=string kind=string quoted=false*/
+
+	{
+
+		{
+			if tok != fflib.FFTok_string && tok != fflib.FFTok_null {
+				return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for string", tok))
+			}
+		}
+
+		if tok == fflib.FFTok_null {
+
+		} else {
+
+			outBuf := fs.Output.Bytes()
+
+			j.Reason = string(string(outBuf))
+
+		}
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+wantedvalue:
+	return fs.WrapErr(fmt.Errorf("wanted value token, but got token: %v", tok))
+wrongtokenerror:
+	return fs.WrapErr(fmt.Errorf("ffjson: wanted token: %v, but got token: %v output=%s", wantedTok, tok, fs.Output.String()))
+tokerror:
+	if fs.BigError != nil {
+		return fs.WrapErr(fs.BigError)
+	}
+	err = fs.Error.ToError()
+	if err != nil {
+		return fs.WrapErr(err)
+	}
+	panic("ffjson-generated: unreachable, please report bug.")
+done:
+
+	return nil
+}
+
 const (
 	ffjtEventbase = iota
 	ffjtEventnosuchkey
@@ -33,6 +279,16 @@ const (
 	ffjtEventTs
 
 	ffjtEventUser
+
+	ffjtEventBotID
+
+	ffjtEventClientMsgID
+
+	ffjtEventUserTeam
+
+	ffjtEventFiles
+
+	ffjtEventEdited
 )
 
 var ffjKeyEventType = []byte("type")
@@ -53,6 +309,16 @@ var ffjKeyEventTs = []byte("ts")
 
 var ffjKeyEventUser = []byte("user")
 
+var ffjKeyEventBotID = []byte("bot_id")
+
+var ffjKeyEventClientMsgID = []byte("client_msg_id")
+
+var ffjKeyEventUserTeam = []byte("user_team")
+
+var ffjKeyEventFiles = []byte("files")
+
+var ffjKeyEventEdited = []byte("edited")
+
 // UnmarshalJSON umarshall json - template of ffjson
 func (j *Event) UnmarshalJSON(input []byte) error {
 	fs := fflib.NewFFLexer(input)
@@ -114,12 +380,25 @@ mainparse:
 			} else {
 				switch kn[0] {
 
+				case 'b':
+
+					if bytes.Equal(ffjKeyEventBotID, kn) {
+						currentKey = ffjtEventBotID
+						state = fflib.FFParse_want_colon
+						goto mainparse
+					}
+
 				case 'c':
 
 					if bytes.Equal(ffjKeyEventChannel, kn) {
 						currentKey = ffjtEventChannel
 						state = fflib.FFParse_want_colon
 						goto mainparse
+
+					} else if bytes.Equal(ffjKeyEventClientMsgID, kn) {
+						currentKey = ffjtEventClientMsgID
+						state = fflib.FFParse_want_colon
+						goto mainparse
 					}
 
 				case 'e':
@@ -128,6 +407,19 @@ mainparse:
 						currentKey = ffjtEventEventTs
 						state = fflib.FFParse_want_colon
 						goto mainparse
+
+					} else if bytes.Equal(ffjKeyEventEdited, kn) {
+						currentKey = ffjtEventEdited
+						state = fflib.FFParse_want_colon
+						goto mainparse
+					}
+
+				case 'f':
+
+					if bytes.Equal(ffjKeyEventFiles, kn) {
+						currentKey = ffjtEventFiles
+						state = fflib.FFParse_want_colon
+						goto mainparse
 					}
 
 				case 's':
@@ -172,10 +464,45 @@ mainparse:
 						currentKey = ffjtEventUser
 						state = fflib.FFParse_want_colon
 						goto mainparse
+
+					} else if bytes.Equal(ffjKeyEventUserTeam, kn) {
+						currentKey = ffjtEventUserTeam
+						state = fflib.FFParse_want_colon
+						goto mainparse
 					}
 
 				}
 
+				if fflib.SimpleLetterEqualFold(ffjKeyEventEdited, kn) {
+					currentKey = ffjtEventEdited
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
+				if fflib.EqualFoldRight(ffjKeyEventFiles, kn) {
+					currentKey = ffjtEventFiles
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
+				if fflib.EqualFoldRight(ffjKeyEventUserTeam, kn) {
+					currentKey = ffjtEventUserTeam
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
+				if fflib.EqualFoldRight(ffjKeyEventClientMsgID, kn) {
+					currentKey = ffjtEventClientMsgID
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
+				if fflib.AsciiEqualFold(ffjKeyEventBotID, kn) {
+					currentKey = ffjtEventBotID
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
 				if fflib.EqualFoldRight(ffjKeyEventUser, kn) {
 					currentKey = ffjtEventUser
 					state = fflib.FFParse_want_colon
@@ -274,6 +601,21 @@ mainparse:
 				case ffjtEventUser:
 					goto handle_User
 
+				case ffjtEventBotID:
+					goto handle_BotID
+
+				case ffjtEventClientMsgID:
+					goto handle_ClientMsgID
+
+				case ffjtEventUserTeam:
+					goto handle_UserTeam
+
+				case ffjtEventFiles:
+					goto handle_Files
+
+				case ffjtEventEdited:
+					goto handle_Edited
+
 				case ffjtEventnosuchkey:
 					err = fs.SkipField(tok)
 					if err != nil {
@@ -522,15 +864,193 @@ handle_User:
 	state = fflib.FFParse_after_value
 	goto mainparse
 
-wantedvalue:
-	return fs.WrapErr(fmt.Errorf("wanted value token, but got token: %v", tok))
-wrongtokenerror:
-	return fs.WrapErr(fmt.Errorf("ffjson: wanted token: %v, but got token: %v output=%s", wantedTok, tok, fs.Output.String()))
-tokerror:
-	if fs.BigError != nil {
-		return fs.WrapErr(fs.BigError)
-	}
-	err = fs.Error.ToError()
+handle_BotID:
+
+	/* handler: j.BotID type=string kind=string quoted=false*/
+
+	{
+
+		{
+			if tok != fflib.FFTok_string && tok != fflib.FFTok_null {
+				return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for string", tok))
+			}
+		}
+
+		if tok == fflib.FFTok_null {
+
+		} else {
+
+			outBuf := fs.Output.Bytes()
+
+			j.BotID = string(string(outBuf))
+
+		}
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+handle_ClientMsgID:
+
+	/* handler: j.ClientMsgID type=string kind=string quoted=false*/
+
+	{
+
+		{
+			if tok != fflib.FFTok_string && tok != fflib.FFTok_null {
+				return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for string", tok))
+			}
+		}
+
+		if tok == fflib.FFTok_null {
+
+		} else {
+
+			outBuf := fs.Output.Bytes()
+
+			j.ClientMsgID = string(string(outBuf))
+
+		}
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+handle_UserTeam:
+
+	/* handler: j.UserTeam type=string kind=string quoted=false*/
+
+	{
+
+		{
+			if tok != fflib.FFTok_string && tok != fflib.FFTok_null {
+				return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for string", tok))
+			}
+		}
+
+		if tok == fflib.FFTok_null {
+
+		} else {
+
+			outBuf := fs.Output.Bytes()
+
+			j.UserTeam = string(string(outBuf))
+
+		}
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+handle_Files:
+
+	/* handler: j.Files type=[]*files.File kind=slice quoted=false*/
+
+	{
+
+		{
+			if tok != fflib.FFTok_left_brace && tok != fflib.FFTok_null {
+				return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for ", tok))
+			}
+		}
+
+		if tok == fflib.FFTok_null {
+			j.Files = nil
+		} else {
+
+			j.Files = []*files.File{}
+
+			wantVal := true
+
+			for {
+
+				var tmpJFiles *files.File
+
+				tok = fs.Scan()
+				if tok == fflib.FFTok_error {
+					goto tokerror
+				}
+				if tok == fflib.FFTok_right_brace {
+					break
+				}
+
+				if tok == fflib.FFTok_comma {
+					if wantVal == true {
+						// TODO(pquerna): this isn't an ideal error message, this handles
+						// things like [,,,] as an array value.
+						return fs.WrapErr(fmt.Errorf("wanted value token, but got token: %v", tok))
+					}
+					continue
+				} else {
+					wantVal = true
+				}
+
+				/* handler: tmpJFiles type=*files.File kind=ptr quoted=false*/
+
+				{
+					if tok == fflib.FFTok_null {
+
+						tmpJFiles = nil
+
+					} else {
+
+						if tmpJFiles == nil {
+							tmpJFiles = new(files.File)
+						}
+
+						err = tmpJFiles.UnmarshalJSONFFLexer(fs, fflib.FFParse_want_key)
+						if err != nil {
+							return err
+						}
+					}
+					state = fflib.FFParse_after_value
+				}
+
+				j.Files = append(j.Files, tmpJFiles)
+
+				wantVal = false
+			}
+		}
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+handle_Edited:
+
+	/* handler: j.Edited type=rtm.Edited kind=struct quoted=false*/
+
+	{
+		if tok == fflib.FFTok_null {
+
+			j.Edited = nil
+
+		} else {
+
+			if j.Edited == nil {
+				j.Edited = new(rtm.Edited)
+			}
+
+			err = j.Edited.UnmarshalJSONFFLexer(fs, fflib.FFParse_want_key)
+			if err != nil {
+				return err
+			}
+		}
+		state = fflib.FFParse_after_value
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+wantedvalue:
+	return fs.WrapErr(fmt.Errorf("wanted value token, but got token: %v", tok))
+wrongtokenerror:
+	return fs.WrapErr(fmt.Errorf("ffjson: wanted token: %v, but got token: %v output=%s", wantedTok, tok, fs.Output.String()))
+tokerror:
+	if fs.BigError != nil {
+		return fs.WrapErr(fs.BigError)
+	}
+	err = fs.Error.ToError()
 	if err != nil {
 		return fs.WrapErr(err)
 	}
@@ -1071,80 +1591,36 @@ done:
 }
 
 const (
-	ffjtinteractionbase = iota
-	ffjtinteractionnosuchkey
-
-	ffjtinteractionToken
-
-	ffjtinteractionActions
-
-	ffjtinteractionTeam
-
-	ffjtinteractionChannel
-
-	ffjtinteractionUser
-
-	ffjtinteractionActionTs
-
-	ffjtinteractionMessageTs
-
-	ffjtinteractionAttachmentID
-
-	ffjtinteractionCallbackID
-
-	ffjtinteractionIsAppUnfurl
-
-	ffjtinteractionOriginalMessage
+	ffjteventCallbackbase = iota
+	ffjteventCallbacknosuchkey
 
-	ffjtinteractionResponseURL
+	ffjteventCallbackEventID
 
-	ffjtinteractionTriggerID
+	ffjteventCallbackTeamID
 
-	ffjtinteractionType
+	ffjteventCallbackEnterpriseID
 
-	ffjtinteractionSubmission
+	ffjteventCallbackEvent
 )
 
-var ffjKeyinteractionToken = []byte("token")
-
-var ffjKeyinteractionActions = []byte("actions")
-
-var ffjKeyinteractionTeam = []byte("team")
-
-var ffjKeyinteractionChannel = []byte("channel")
-
-var ffjKeyinteractionUser = []byte("user")
-
-var ffjKeyinteractionActionTs = []byte("action_ts")
-
-var ffjKeyinteractionMessageTs = []byte("message_ts")
-
-var ffjKeyinteractionAttachmentID = []byte("attachment_id")
-
-var ffjKeyinteractionCallbackID = []byte("callback_id")
-
-var ffjKeyinteractionIsAppUnfurl = []byte("is_app_unfurl")
-
-var ffjKeyinteractionOriginalMessage = []byte("original_message")
-
-var ffjKeyinteractionResponseURL = []byte("response_url")
+var ffjKeyeventCallbackEventID = []byte("event_id")
 
-var ffjKeyinteractionTriggerID = []byte("trigger_id")
+var ffjKeyeventCallbackTeamID = []byte("team_id")
 
-var ffjKeyinteractionType = []byte("type")
+var ffjKeyeventCallbackEnterpriseID = []byte("enterprise_id")
 
-var ffjKeyinteractionSubmission = []byte("submission")
+var ffjKeyeventCallbackEvent = []byte("event")
 
 // UnmarshalJSON umarshall json - template of ffjson
-func (j *interaction) UnmarshalJSON(input []byte) error {
+func (j *eventCallback) UnmarshalJSON(input []byte) error {
 	fs := fflib.NewFFLexer(input)
 	return j.UnmarshalJSONFFLexer(fs, fflib.FFParse_map_start)
 }
 
 // UnmarshalJSONFFLexer fast json unmarshall - template ffjson
-func (j *interaction) UnmarshalJSONFFLexer(fs *fflib.FFLexer, state fflib.FFParseState) error {
+func (j *eventCallback) UnmarshalJSONFFLexer(fs *fflib.FFLexer, state fflib.FFParseState) error {
 	var err error
-	currentKey := ffjtinteractionbase
+	currentKey := ffjteventCallbackbase
 	_ = currentKey
 	tok := fflib.FFTok_init
 	wantedTok := fflib.FFTok_init
@@ -1190,58 +1666,400 @@ mainparse:
 			kn := fs.Output.Bytes()
 			if len(kn) <= 0 {
 				// "" case. hrm.
-				currentKey = ffjtinteractionnosuchkey
+				currentKey = ffjteventCallbacknosuchkey
 				state = fflib.FFParse_want_colon
 				goto mainparse
 			} else {
 				switch kn[0] {
 
-				case 'a':
+				case 'e':
 
-					if bytes.Equal(ffjKeyinteractionActions, kn) {
-						currentKey = ffjtinteractionActions
+					if bytes.Equal(ffjKeyeventCallbackEventID, kn) {
+						currentKey = ffjteventCallbackEventID
 						state = fflib.FFParse_want_colon
 						goto mainparse
 
-					} else if bytes.Equal(ffjKeyinteractionActionTs, kn) {
-						currentKey = ffjtinteractionActionTs
+					} else if bytes.Equal(ffjKeyeventCallbackEnterpriseID, kn) {
+						currentKey = ffjteventCallbackEnterpriseID
 						state = fflib.FFParse_want_colon
 						goto mainparse
 
-					} else if bytes.Equal(ffjKeyinteractionAttachmentID, kn) {
-						currentKey = ffjtinteractionAttachmentID
+					} else if bytes.Equal(ffjKeyeventCallbackEvent, kn) {
+						currentKey = ffjteventCallbackEvent
 						state = fflib.FFParse_want_colon
 						goto mainparse
 					}
 
-				case 'c':
-
-					if bytes.Equal(ffjKeyinteractionChannel, kn) {
-						currentKey = ffjtinteractionChannel
-						state = fflib.FFParse_want_colon
-						goto mainparse
+				case 't':
 
-					} else if bytes.Equal(ffjKeyinteractionCallbackID, kn) {
-						currentKey = ffjtinteractionCallbackID
+					if bytes.Equal(ffjKeyeventCallbackTeamID, kn) {
+						currentKey = ffjteventCallbackTeamID
 						state = fflib.FFParse_want_colon
 						goto mainparse
 					}
 
-				case 'i':
+				}
 
-					if bytes.Equal(ffjKeyinteractionIsAppUnfurl, kn) {
-						currentKey = ffjtinteractionIsAppUnfurl
-						state = fflib.FFParse_want_colon
-						goto mainparse
-					}
+				if fflib.SimpleLetterEqualFold(ffjKeyeventCallbackEvent, kn) {
+					currentKey = ffjteventCallbackEvent
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
 
-				case 'm':
+				if fflib.EqualFoldRight(ffjKeyeventCallbackEnterpriseID, kn) {
+					currentKey = ffjteventCallbackEnterpriseID
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
 
-					if bytes.Equal(ffjKeyinteractionMessageTs, kn) {
-						currentKey = ffjtinteractionMessageTs
-						state = fflib.FFParse_want_colon
-						goto mainparse
-					}
+				if fflib.AsciiEqualFold(ffjKeyeventCallbackTeamID, kn) {
+					currentKey = ffjteventCallbackTeamID
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
+				if fflib.AsciiEqualFold(ffjKeyeventCallbackEventID, kn) {
+					currentKey = ffjteventCallbackEventID
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
+				currentKey = ffjteventCallbacknosuchkey
+				state = fflib.FFParse_want_colon
+				goto mainparse
+			}
+
+		case fflib.FFParse_want_colon:
+			if tok != fflib.FFTok_colon {
+				wantedTok = fflib.FFTok_colon
+				goto wrongtokenerror
+			}
+			state = fflib.FFParse_want_value
+			continue
+		case fflib.FFParse_want_value:
+
+			if tok == fflib.FFTok_left_brace || tok == fflib.FFTok_left_bracket || tok == fflib.FFTok_integer || tok == fflib.FFTok_double || tok == fflib.FFTok_string || tok == fflib.FFTok_bool || tok == fflib.FFTok_null {
+				switch currentKey {
+
+				case ffjteventCallbackEventID:
+					goto handle_EventID
+
+				case ffjteventCallbackTeamID:
+					goto handle_TeamID
+
+				case ffjteventCallbackEnterpriseID:
+					goto handle_EnterpriseID
+
+				case ffjteventCallbackEvent:
+					goto handle_Event
+
+				case ffjteventCallbacknosuchkey:
+					err = fs.SkipField(tok)
+					if err != nil {
+						return fs.WrapErr(err)
+					}
+					state = fflib.FFParse_after_value
+					goto mainparse
+				}
+			} else {
+				goto wantedvalue
+			}
+		}
+	}
+
+handle_EventID:
+
+	/* handler: j.EventID type=string kind=string quoted=false*/
+
+	{
+
+		{
+			if tok != fflib.FFTok_string && tok != fflib.FFTok_null {
+				return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for string", tok))
+			}
+		}
+
+		if tok == fflib.FFTok_null {
+
+		} else {
+
+			outBuf := fs.Output.Bytes()
+
+			j.EventID = string(string(outBuf))
+
+		}
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+handle_TeamID:
+
+	/* handler: j.TeamID type=string kind=string quoted=false*/
+
+	{
+
+		{
+			if tok != fflib.FFTok_string && tok != fflib.FFTok_null {
+				return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for string", tok))
+			}
+		}
+
+		if tok == fflib.FFTok_null {
+
+		} else {
+
+			outBuf := fs.Output.Bytes()
+
+			j.TeamID = string(string(outBuf))
+
+		}
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+handle_EnterpriseID:
+
+	/* handler: j.EnterpriseID type=string kind=string quoted=false*/
+
+	{
+
+		{
+			if tok != fflib.FFTok_string && tok != fflib.FFTok_null {
+				return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for string", tok))
+			}
+		}
+
+		if tok == fflib.FFTok_null {
+
+		} else {
+
+			outBuf := fs.Output.Bytes()
+
+			j.EnterpriseID = string(string(outBuf))
+
+		}
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+handle_Event:
+
+	/* handler: j.Event type=jsontext.Value kind=slice quoted=false*/
+
+	{
+		if tok == fflib.FFTok_null {
+
+		} else {
+
+			tbuf, err := fs.CaptureField(tok)
+			if err != nil {
+				return fs.WrapErr(err)
+			}
+
+			err = j.Event.UnmarshalJSON(tbuf)
+			if err != nil {
+				return fs.WrapErr(err)
+			}
+		}
+		state = fflib.FFParse_after_value
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+wantedvalue:
+	return fs.WrapErr(fmt.Errorf("wanted value token, but got token: %v", tok))
+wrongtokenerror:
+	return fs.WrapErr(fmt.Errorf("ffjson: wanted token: %v, but got token: %v output=%s", wantedTok, tok, fs.Output.String()))
+tokerror:
+	if fs.BigError != nil {
+		return fs.WrapErr(fs.BigError)
+	}
+	err = fs.Error.ToError()
+	if err != nil {
+		return fs.WrapErr(err)
+	}
+	panic("ffjson-generated: unreachable, please report bug.")
+done:
+
+	return nil
+}
+
+const (
+	ffjtinteractionbase = iota
+	ffjtinteractionnosuchkey
+
+	ffjtinteractionToken
+
+	ffjtinteractionActions
+
+	ffjtinteractionTeam
+
+	ffjtinteractionChannel
+
+	ffjtinteractionUser
+
+	ffjtinteractionActionTs
+
+	ffjtinteractionMessageTs
+
+	ffjtinteractionAttachmentID
+
+	ffjtinteractionCallbackID
+
+	ffjtinteractionIsAppUnfurl
+
+	ffjtinteractionOriginalMessage
+
+	ffjtinteractionResponseURL
+
+	ffjtinteractionTriggerID
+
+	ffjtinteractionType
+
+	ffjtinteractionSubmission
+)
+
+var ffjKeyinteractionToken = []byte("token")
+
+var ffjKeyinteractionActions = []byte("actions")
+
+var ffjKeyinteractionTeam = []byte("team")
+
+var ffjKeyinteractionChannel = []byte("channel")
+
+var ffjKeyinteractionUser = []byte("user")
+
+var ffjKeyinteractionActionTs = []byte("action_ts")
+
+var ffjKeyinteractionMessageTs = []byte("message_ts")
+
+var ffjKeyinteractionAttachmentID = []byte("attachment_id")
+
+var ffjKeyinteractionCallbackID = []byte("callback_id")
+
+var ffjKeyinteractionIsAppUnfurl = []byte("is_app_unfurl")
+
+var ffjKeyinteractionOriginalMessage = []byte("original_message")
+
+var ffjKeyinteractionResponseURL = []byte("response_url")
+
+var ffjKeyinteractionTriggerID = []byte("trigger_id")
+
+var ffjKeyinteractionType = []byte("type")
+
+var ffjKeyinteractionSubmission = []byte("submission")
+
+// UnmarshalJSON umarshall json - template of ffjson
+func (j *interaction) UnmarshalJSON(input []byte) error {
+	fs := fflib.NewFFLexer(input)
+	return j.UnmarshalJSONFFLexer(fs, fflib.FFParse_map_start)
+}
+
+// UnmarshalJSONFFLexer fast json unmarshall - template ffjson
+func (j *interaction) UnmarshalJSONFFLexer(fs *fflib.FFLexer, state fflib.FFParseState) error {
+	var err error
+	currentKey := ffjtinteractionbase
+	_ = currentKey
+	tok := fflib.FFTok_init
+	wantedTok := fflib.FFTok_init
+
+mainparse:
+	for {
+		tok = fs.Scan()
+		//	println(fmt.Sprintf("debug: tok: %v  state: %v", tok, state))
+		if tok == fflib.FFTok_error {
+			goto tokerror
+		}
+
+		switch state {
+
+		case fflib.FFParse_map_start:
+			if tok != fflib.FFTok_left_bracket {
+				wantedTok = fflib.FFTok_left_bracket
+				goto wrongtokenerror
+			}
+			state = fflib.FFParse_want_key
+			continue
+
+		case fflib.FFParse_after_value:
+			if tok == fflib.FFTok_comma {
+				state = fflib.FFParse_want_key
+			} else if tok == fflib.FFTok_right_bracket {
+				goto done
+			} else {
+				wantedTok = fflib.FFTok_comma
+				goto wrongtokenerror
+			}
+
+		case fflib.FFParse_want_key:
+			// json {} ended. goto exit. woo.
+			if tok == fflib.FFTok_right_bracket {
+				goto done
+			}
+			if tok != fflib.FFTok_string {
+				wantedTok = fflib.FFTok_string
+				goto wrongtokenerror
+			}
+
+			kn := fs.Output.Bytes()
+			if len(kn) <= 0 {
+				// "" case. hrm.
+				currentKey = ffjtinteractionnosuchkey
+				state = fflib.FFParse_want_colon
+				goto mainparse
+			} else {
+				switch kn[0] {
+
+				case 'a':
+
+					if bytes.Equal(ffjKeyinteractionActions, kn) {
+						currentKey = ffjtinteractionActions
+						state = fflib.FFParse_want_colon
+						goto mainparse
+
+					} else if bytes.Equal(ffjKeyinteractionActionTs, kn) {
+						currentKey = ffjtinteractionActionTs
+						state = fflib.FFParse_want_colon
+						goto mainparse
+
+					} else if bytes.Equal(ffjKeyinteractionAttachmentID, kn) {
+						currentKey = ffjtinteractionAttachmentID
+						state = fflib.FFParse_want_colon
+						goto mainparse
+					}
+
+				case 'c':
+
+					if bytes.Equal(ffjKeyinteractionChannel, kn) {
+						currentKey = ffjtinteractionChannel
+						state = fflib.FFParse_want_colon
+						goto mainparse
+
+					} else if bytes.Equal(ffjKeyinteractionCallbackID, kn) {
+						currentKey = ffjtinteractionCallbackID
+						state = fflib.FFParse_want_colon
+						goto mainparse
+					}
+
+				case 'i':
+
+					if bytes.Equal(ffjKeyinteractionIsAppUnfurl, kn) {
+						currentKey = ffjtinteractionIsAppUnfurl
+						state = fflib.FFParse_want_colon
+						goto mainparse
+					}
+
+				case 'm':
+
+					if bytes.Equal(ffjKeyinteractionMessageTs, kn) {
+						currentKey = ffjtinteractionMessageTs
+						state = fflib.FFParse_want_colon
+						goto mainparse
+					}
 
 				case 'o':
 
@@ -1600,10 +2418,10 @@ handle_Actions:
 
 handle_Team:
 
-	/* handler: j.Team type=struct { ID string "json:\"id\""; Domain string "json:\"domain\"" } kind=struct quoted=false*/
+	/* handler: j.Team type=struct { ID string "json:\"id\""; Domain string "json:\"domain\""; EnterpriseID string "json:\"enterprise_id\"" } kind=struct quoted=false*/
 
 	{
-		/* Falling back. type=struct { ID string "json:\"id\""; Domain string "json:\"domain\"" } kind=struct */
+		/* Falling back. type=struct { ID string "json:\"id\""; Domain string "json:\"domain\""; EnterpriseID string "json:\"enterprise_id\"" } kind=struct */
 		tbuf, err := fs.CaptureField(tok)
 		if err != nil {
 			return fs.WrapErr(err)
@@ -1903,7 +2721,7 @@ handle_Type:
 
 handle_Submission:
 
-	/* handler: j.Submission type=json.RawMessage kind=slice quoted=false*/
+	/* handler: j.Submission type=jsontext.Value kind=slice quoted=false*/
 
 	{
 		if tok == fflib.FFTok_null {
@@ -2350,10 +3168,10 @@ handle_CallbackID:
 
 handle_Team:
 
-	/* handler: j.Team type=struct { ID string "json:\"id\""; Domain string "json:\"domain\"" } kind=struct quoted=false*/
+	/* handler: j.Team type=struct { ID string "json:\"id\""; Domain string "json:\"domain\""; EnterpriseID string "json:\"enterprise_id\"" } kind=struct quoted=false*/
 
 	{
-		/* Falling back. type=struct { ID string "json:\"id\""; Domain string "json:\"domain\"" } kind=struct */
+		/* Falling back. type=struct { ID string "json:\"id\""; Domain string "json:\"domain\""; EnterpriseID string "json:\"enterprise_id\"" } kind=struct */
 		tbuf, err := fs.CaptureField(tok)
 		if err != nil {
 			return fs.WrapErr(err)
@@ -2448,38 +3266,196 @@ handle_MessageTs:
 
 		if tok == fflib.FFTok_null {
 
-		} else {
+		} else {
+
+			outBuf := fs.Output.Bytes()
+
+			j.MessageTs = string(string(outBuf))
+
+		}
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+handle_AttachmentID:
+
+	/* handler: j.AttachmentID type=string kind=string quoted=false*/
+
+	{
+
+		{
+			if tok != fflib.FFTok_string && tok != fflib.FFTok_null {
+				return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for string", tok))
+			}
+		}
+
+		if tok == fflib.FFTok_null {
+
+		} else {
+
+			outBuf := fs.Output.Bytes()
+
+			j.AttachmentID = string(string(outBuf))
+
+		}
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+wantedvalue:
+	return fs.WrapErr(fmt.Errorf("wanted value token, but got token: %v", tok))
+wrongtokenerror:
+	return fs.WrapErr(fmt.Errorf("ffjson: wanted token: %v, but got token: %v output=%s", wantedTok, tok, fs.Output.String()))
+tokerror:
+	if fs.BigError != nil {
+		return fs.WrapErr(fs.BigError)
+	}
+	err = fs.Error.ToError()
+	if err != nil {
+		return fs.WrapErr(err)
+	}
+	panic("ffjson-generated: unreachable, please report bug.")
+done:
+
+	return nil
+}
+
+const (
+	ffjttokensRevokedEventbase = iota
+	ffjttokensRevokedEventnosuchkey
+
+	ffjttokensRevokedEventTokens
+)
+
+var ffjKeytokensRevokedEventTokens = []byte("tokens")
+
+// UnmarshalJSON umarshall json - template of ffjson
+func (j *tokensRevokedEvent) UnmarshalJSON(input []byte) error {
+	fs := fflib.NewFFLexer(input)
+	return j.UnmarshalJSONFFLexer(fs, fflib.FFParse_map_start)
+}
+
+// UnmarshalJSONFFLexer fast json unmarshall - template ffjson
+func (j *tokensRevokedEvent) UnmarshalJSONFFLexer(fs *fflib.FFLexer, state fflib.FFParseState) error {
+	var err error
+	currentKey := ffjttokensRevokedEventbase
+	_ = currentKey
+	tok := fflib.FFTok_init
+	wantedTok := fflib.FFTok_init
+
+mainparse:
+	for {
+		tok = fs.Scan()
+		//	println(fmt.Sprintf("debug: tok: %v  state: %v", tok, state))
+		if tok == fflib.FFTok_error {
+			goto tokerror
+		}
+
+		switch state {
+
+		case fflib.FFParse_map_start:
+			if tok != fflib.FFTok_left_bracket {
+				wantedTok = fflib.FFTok_left_bracket
+				goto wrongtokenerror
+			}
+			state = fflib.FFParse_want_key
+			continue
+
+		case fflib.FFParse_after_value:
+			if tok == fflib.FFTok_comma {
+				state = fflib.FFParse_want_key
+			} else if tok == fflib.FFTok_right_bracket {
+				goto done
+			} else {
+				wantedTok = fflib.FFTok_comma
+				goto wrongtokenerror
+			}
+
+		case fflib.FFParse_want_key:
+			// json {} ended. goto exit. woo.
+			if tok == fflib.FFTok_right_bracket {
+				goto done
+			}
+			if tok != fflib.FFTok_string {
+				wantedTok = fflib.FFTok_string
+				goto wrongtokenerror
+			}
+
+			kn := fs.Output.Bytes()
+			if len(kn) <= 0 {
+				// "" case. hrm.
+				currentKey = ffjttokensRevokedEventnosuchkey
+				state = fflib.FFParse_want_colon
+				goto mainparse
+			} else {
+				switch kn[0] {
+
+				case 't':
 
-			outBuf := fs.Output.Bytes()
+					if bytes.Equal(ffjKeytokensRevokedEventTokens, kn) {
+						currentKey = ffjttokensRevokedEventTokens
+						state = fflib.FFParse_want_colon
+						goto mainparse
+					}
 
-			j.MessageTs = string(string(outBuf))
+				}
 
-		}
-	}
+				if fflib.EqualFoldRight(ffjKeytokensRevokedEventTokens, kn) {
+					currentKey = ffjttokensRevokedEventTokens
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
 
-	state = fflib.FFParse_after_value
-	goto mainparse
+				currentKey = ffjttokensRevokedEventnosuchkey
+				state = fflib.FFParse_want_colon
+				goto mainparse
+			}
 
-handle_AttachmentID:
+		case fflib.FFParse_want_colon:
+			if tok != fflib.FFTok_colon {
+				wantedTok = fflib.FFTok_colon
+				goto wrongtokenerror
+			}
+			state = fflib.FFParse_want_value
+			continue
+		case fflib.FFParse_want_value:
 
-	/* handler: j.AttachmentID type=string kind=string quoted=false*/
+			if tok == fflib.FFTok_left_brace || tok == fflib.FFTok_left_bracket || tok == fflib.FFTok_integer || tok == fflib.FFTok_double || tok == fflib.FFTok_string || tok == fflib.FFTok_bool || tok == fflib.FFTok_null {
+				switch currentKey {
 
-	{
+				case ffjttokensRevokedEventTokens:
+					goto handle_Tokens
 
-		{
-			if tok != fflib.FFTok_string && tok != fflib.FFTok_null {
-				return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for string", tok))
+				case ffjttokensRevokedEventnosuchkey:
+					err = fs.SkipField(tok)
+					if err != nil {
+						return fs.WrapErr(err)
+					}
+					state = fflib.FFParse_after_value
+					goto mainparse
+				}
+			} else {
+				goto wantedvalue
 			}
 		}
+	}
 
-		if tok == fflib.FFTok_null {
-
-		} else {
+handle_Tokens:
 
-			outBuf := fs.Output.Bytes()
+	/* handler: j.Tokens type=struct { OAuth []string "json:\"oauth\""; Bot []string "json:\"bot\"" } kind=struct quoted=false*/
 
-			j.AttachmentID = string(string(outBuf))
+	{
+		/* Falling back. type=struct { OAuth []string "json:\"oauth\""; Bot []string "json:\"bot\"" } kind=struct */
+		tbuf, err := fs.CaptureField(tok)
+		if err != nil {
+			return fs.WrapErr(err)
+		}
 
+		err = json.Unmarshal(tbuf, &j.Tokens)
+		if err != nil {
+			return fs.WrapErr(err)
 		}
 	}
 
@@ -2759,7 +3735,32 @@ handle_Type:
 
 handle_User:
 
-	/* handler: j.User type=string kind=string quoted=false*/
+	/* handler: j.User type=jsontext.Value kind=slice quoted=false*/
+
+	{
+		if tok == fflib.FFTok_null {
+
+		} else {
+
+			tbuf, err := fs.CaptureField(tok)
+			if err != nil {
+				return fs.WrapErr(err)
+			}
+
+			err = j.User.UnmarshalJSON(tbuf)
+			if err != nil {
+				return fs.WrapErr(err)
+			}
+		}
+		state = fflib.FFParse_after_value
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+handle_Token:
+
+	/* handler: j.Token type=string kind=string quoted=false*/
 
 	{
 
@@ -2775,7 +3776,7 @@ handle_User:
 
 			outBuf := fs.Output.Bytes()
 
-			j.User = string(string(outBuf))
+			j.Token = string(string(outBuf))
 
 		}
 	}
@@ -2783,9 +3784,9 @@ handle_User:
 	state = fflib.FFParse_after_value
 	goto mainparse
 
-handle_Token:
+handle_Challenge:
 
-	/* handler: j.Token type=string kind=string quoted=false*/
+	/* handler: j.Challenge type=string kind=string quoted=false*/
 
 	{
 
@@ -2801,7 +3802,7 @@ handle_Token:
 
 			outBuf := fs.Output.Bytes()
 
-			j.Token = string(string(outBuf))
+			j.Challenge = string(string(outBuf))
 
 		}
 	}
@@ -2809,9 +3810,168 @@ handle_Token:
 	state = fflib.FFParse_after_value
 	goto mainparse
 
-handle_Challenge:
+wantedvalue:
+	return fs.WrapErr(fmt.Errorf("wanted value token, but got token: %v", tok))
+wrongtokenerror:
+	return fs.WrapErr(fmt.Errorf("ffjson: wanted token: %v, but got token: %v output=%s", wantedTok, tok, fs.Output.String()))
+tokerror:
+	if fs.BigError != nil {
+		return fs.WrapErr(fs.BigError)
+	}
+	err = fs.Error.ToError()
+	if err != nil {
+		return fs.WrapErr(err)
+	}
+	panic("ffjson-generated: unreachable, please report bug.")
+done:
 
-	/* handler: j.Challenge type=string kind=string quoted=false*/
+	return nil
+}
+
+const (
+	ffjtuserEventbase = iota
+	ffjtuserEventnosuchkey
+
+	ffjtuserEventType
+
+	ffjtuserEventUser
+)
+
+var ffjKeyuserEventType = []byte("type")
+
+var ffjKeyuserEventUser = []byte("user")
+
+// UnmarshalJSON umarshall json - template of ffjson
+func (j *userEvent) UnmarshalJSON(input []byte) error {
+	fs := fflib.NewFFLexer(input)
+	return j.UnmarshalJSONFFLexer(fs, fflib.FFParse_map_start)
+}
+
+// UnmarshalJSONFFLexer fast json unmarshall - template ffjson
+func (j *userEvent) UnmarshalJSONFFLexer(fs *fflib.FFLexer, state fflib.FFParseState) error {
+	var err error
+	currentKey := ffjtuserEventbase
+	_ = currentKey
+	tok := fflib.FFTok_init
+	wantedTok := fflib.FFTok_init
+
+mainparse:
+	for {
+		tok = fs.Scan()
+		//	println(fmt.Sprintf("debug: tok: %v  state: %v", tok, state))
+		if tok == fflib.FFTok_error {
+			goto tokerror
+		}
+
+		switch state {
+
+		case fflib.FFParse_map_start:
+			if tok != fflib.FFTok_left_bracket {
+				wantedTok = fflib.FFTok_left_bracket
+				goto wrongtokenerror
+			}
+			state = fflib.FFParse_want_key
+			continue
+
+		case fflib.FFParse_after_value:
+			if tok == fflib.FFTok_comma {
+				state = fflib.FFParse_want_key
+			} else if tok == fflib.FFTok_right_bracket {
+				goto done
+			} else {
+				wantedTok = fflib.FFTok_comma
+				goto wrongtokenerror
+			}
+
+		case fflib.FFParse_want_key:
+			// json {} ended. goto exit. woo.
+			if tok == fflib.FFTok_right_bracket {
+				goto done
+			}
+			if tok != fflib.FFTok_string {
+				wantedTok = fflib.FFTok_string
+				goto wrongtokenerror
+			}
+
+			kn := fs.Output.Bytes()
+			if len(kn) <= 0 {
+				// "" case. hrm.
+				currentKey = ffjtuserEventnosuchkey
+				state = fflib.FFParse_want_colon
+				goto mainparse
+			} else {
+				switch kn[0] {
+
+				case 't':
+
+					if bytes.Equal(ffjKeyuserEventType, kn) {
+						currentKey = ffjtuserEventType
+						state = fflib.FFParse_want_colon
+						goto mainparse
+					}
+
+				case 'u':
+
+					if bytes.Equal(ffjKeyuserEventUser, kn) {
+						currentKey = ffjtuserEventUser
+						state = fflib.FFParse_want_colon
+						goto mainparse
+					}
+
+				}
+
+				if fflib.EqualFoldRight(ffjKeyuserEventUser, kn) {
+					currentKey = ffjtuserEventUser
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
+				if fflib.SimpleLetterEqualFold(ffjKeyuserEventType, kn) {
+					currentKey = ffjtuserEventType
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
+				currentKey = ffjtuserEventnosuchkey
+				state = fflib.FFParse_want_colon
+				goto mainparse
+			}
+
+		case fflib.FFParse_want_colon:
+			if tok != fflib.FFTok_colon {
+				wantedTok = fflib.FFTok_colon
+				goto wrongtokenerror
+			}
+			state = fflib.FFParse_want_value
+			continue
+		case fflib.FFParse_want_value:
+
+			if tok == fflib.FFTok_left_brace || tok == fflib.FFTok_left_bracket || tok == fflib.FFTok_integer || tok == fflib.FFTok_double || tok == fflib.FFTok_string || tok == fflib.FFTok_bool || tok == fflib.FFTok_null {
+				switch currentKey {
+
+				case ffjtuserEventType:
+					goto handle_Type
+
+				case ffjtuserEventUser:
+					goto handle_User
+
+				case ffjtuserEventnosuchkey:
+					err = fs.SkipField(tok)
+					if err != nil {
+						return fs.WrapErr(err)
+					}
+					state = fflib.FFParse_after_value
+					goto mainparse
+				}
+			} else {
+				goto wantedvalue
+			}
+		}
+	}
+
+handle_Type:
+
+	/* handler: j.Type type=string kind=string quoted=false*/
 
 	{
 
@@ -2827,9 +3987,35 @@ handle_Challenge:
 
 			outBuf := fs.Output.Bytes()
 
-			j.Challenge = string(string(outBuf))
+			j.Type = string(string(outBuf))
+
+		}
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+handle_User:
+
+	/* handler: j.User type=users.User kind=struct quoted=false*/
+
+	{
+		if tok == fflib.FFTok_null {
+
+			j.User = nil
 
+		} else {
+
+			if j.User == nil {
+				j.User = new(users.User)
+			}
+
+			err = j.User.UnmarshalJSONFFLexer(fs, fflib.FFParse_want_key)
+			if err != nil {
+				return err
+			}
 		}
+		state = fflib.FFParse_after_value
 	}
 
 	state = fflib.FFParse_after_value
@@ -1,6 +1,8 @@
 package slack
 
 import (
+	"sync"
+
 	"suy.io/bots/slack/api/chat"
 )
 
@@ -9,6 +11,9 @@ import (
 type Controls struct {
 	b             *Bot
 	user, channel string
+
+	// version is the version of the state the handler was called for, 0 if unknown
+	version int64
 }
 
 // Get gets a value for a key in the current conversation state.
@@ -22,8 +27,22 @@ func (c *Controls) Set(key string, value string) error {
 }
 
 // To makes a state transition.
+//
+// If the ConversationStore is a VersionedConversationStore, the transition is only made if the state
+// has not changed since the handler was called, returning ErrStateChanged otherwise.
 func (c *Controls) To(state string) error {
-	return c.b.cs.SetState(c.user, c.channel, c.b.teamID, state)
+	vs, ok := c.b.cs.(VersionedConversationStore)
+	if !ok || c.version == 0 {
+		return c.b.cs.SetState(c.user, c.channel, c.b.teamID, state)
+	}
+
+	v, err := vs.CompareAndSetState(c.user, c.channel, c.b.teamID, c.version, state)
+	if err != nil {
+		return err
+	}
+
+	c.version = v
+	return nil
 }
 
 // End ends the conversation.
@@ -91,3 +110,45 @@ func (c ConversationRegistry) Get(name string) (*Conversation, error) {
 
 	return conv, nil
 }
+
+// conversationLocks serializes the handling of messages for a conversation,
+// letting waiting messages through in the order they arrived.
+//
+// ffjson: skip
+type conversationLocks struct {
+	mu     sync.Mutex
+	queues map[string][]chan struct{}
+}
+
+func newConversationLocks() *conversationLocks {
+	return &conversationLocks{queues: make(map[string][]chan struct{})}
+}
+
+// lock waits for all earlier messages for a conversation to be handled, returning a function to unlock it.
+func (l *conversationLocks) lock(user, channel, team string) func() {
+	k := user + "_" + channel + "_" + team
+
+	l.mu.Lock()
+	turn := make(chan struct{})
+	l.queues[k] = append(l.queues[k], turn)
+	if len(l.queues[k]) == 1 {
+		close(turn)
+	}
+	l.mu.Unlock()
+
+	<-turn
+
+	return func() {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+
+		q := l.queues[k][1:]
+		if len(q) == 0 {
+			delete(l.queues, k)
+			return
+		}
+
+		l.queues[k] = q
+		close(q[0])
+	}
+}
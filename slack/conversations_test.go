@@ -2,6 +2,8 @@ package slack
 
 import (
 	"reflect"
+	"runtime"
+	"sync"
 	"testing"
 
 	"suy.io/bots/slack/api/chat"
@@ -112,6 +114,7 @@ func TestControls_To(t *testing.T) {
 		b       *Bot
 		user    string
 		channel string
+		version int64
 	}
 
 	type args struct {
@@ -124,13 +127,19 @@ func TestControls_To(t *testing.T) {
 		t.Fatal(err)
 	}
 
+	b := &Bot{cs: cs, teamID: "T12345678"}
+
 	tests := []struct {
-		name    string
-		fields  fields
-		args    args
-		wantErr bool
+		name      string
+		fields    fields
+		args      args
+		wantState string
+		wantErr   bool
 	}{
-		{"", fields{&Bot{cs: cs, teamID: "T12345678"}, "U12345678", "C12345678"}, args{"next"}, false},
+		{"", fields{b, "U12345678", "C12345678", 0}, args{"next"}, "next", false},
+		{"", fields{b, "U12345678", "C12345678", 2}, args{"after"}, "after", false},
+		{"", fields{b, "U12345678", "C12345678", 2}, args{"again"}, "after", true},
+		{"", fields{b, "U12345678", "C87654321", 1}, args{"next"}, "", true},
 	}
 
 	for _, tt := range tests {
@@ -139,19 +148,83 @@ func TestControls_To(t *testing.T) {
 				b:       tt.fields.b,
 				user:    tt.fields.user,
 				channel: tt.fields.channel,
+				version: tt.fields.version,
 			}
 
 			if err := c.To(tt.args.state); (err != nil) != tt.wantErr {
 				t.Errorf("Controls.To() error = %v, wantErr %v", err, tt.wantErr)
 			}
 
-			if cs.active[tt.fields.user+"_"+tt.fields.channel+"_"+tt.fields.b.teamID].state != tt.args.state {
-				t.Errorf("Controls.To() = %v, want %v", cs.active[tt.fields.user+"_"+tt.fields.channel+"_"+tt.fields.b.teamID].state, tt.args.state)
+			if _, state, _ := cs.Active(tt.fields.user, tt.fields.channel, tt.fields.b.teamID); state != tt.wantState {
+				t.Errorf("Controls.To() = %v, want %v", state, tt.wantState)
 			}
 		})
 	}
 }
 
+func TestControls_To_twice(t *testing.T) {
+	cs := NewMemoryConversationStore()
+	cs.Start("U12345678", "C12345678", "T12345678", "test")
+
+	c := &Controls{&Bot{cs: cs, teamID: "T12345678"}, "U12345678", "C12345678", 1}
+
+	for _, state := range []string{"one", "two"} {
+		if err := c.To(state); err != nil {
+			t.Errorf("Controls.To(%v) error = %v", state, err)
+		}
+	}
+}
+
+func TestConversationLocks(t *testing.T) {
+	l := newConversationLocks()
+
+	unlock := l.lock("U1", "C1", "T1")
+
+	var (
+		mu    sync.Mutex
+		order []int
+		wg    sync.WaitGroup
+	)
+
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			done := l.lock("U1", "C1", "T1")
+
+			mu.Lock()
+			order = append(order, i)
+			mu.Unlock()
+
+			done()
+		}(i)
+
+		// wait for the goroutine to queue up before starting the next one
+		for queued := 0; queued < i+2; {
+			l.mu.Lock()
+			queued = len(l.queues["U1_C1_T1"])
+			l.mu.Unlock()
+
+			runtime.Gosched()
+		}
+	}
+
+	// other conversations are not blocked
+	l.lock("U2", "C1", "T1")()
+
+	unlock()
+	wg.Wait()
+
+	if !reflect.DeepEqual(order, []int{0, 1, 2, 3, 4}) {
+		t.Errorf("conversationLocks.lock() order = %v, want %v", order, []int{0, 1, 2, 3, 4})
+	}
+
+	if len(l.queues) != 0 {
+		t.Errorf("conversationLocks.lock() left %v queues", len(l.queues))
+	}
+}
+
 func TestControls_End(t *testing.T) {
 	type fields struct {
 		b       *Bot
@@ -0,0 +1,431 @@
+package slack
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"io"
+	"io/ioutil"
+	"log"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"suy.io/bots/slack/api/oauth"
+)
+
+const (
+	// encryptedPrefix marks a token value encrypted by EncryptedBotStore.
+	encryptedPrefix = "enc:"
+
+	// encryptedV2 tokens are bound to the key of their bot.
+	encryptedV2 = encryptedPrefix + "v2:"
+)
+
+// KeyProvider provides the key encryption keys used by EncryptedBotStore.
+//
+// Keys must be 16, 24 or 32 bytes long, to use AES-128, AES-192 or AES-256.
+type KeyProvider interface {
+	// CurrentKey gets the key new tokens are encrypted with, along with its id
+	CurrentKey() (id string, key []byte, err error)
+
+	// Key gets a key by its id, to decrypt tokens encrypted with it
+	Key(id string) ([]byte, error)
+}
+
+// RotatingKeyProvider is a KeyProvider holding multiple keys by id,
+// encrypting with the current one and decrypting with any of them.
+//
+// ffjson: skip
+type RotatingKeyProvider struct {
+	current string
+	keys    map[string][]byte
+}
+
+// NewRotatingKeyProvider creates a new RotatingKeyProvider that encrypts using the key with id current.
+//
+// Keys that are no longer current should be kept until EncryptedBotStore.Migrate has re-encrypted
+// all tokens with the current key.
+func NewRotatingKeyProvider(current string, keys map[string][]byte) (*RotatingKeyProvider, error) {
+	if _, ok := keys[current]; !ok {
+		return nil, ErrKeyNotFound
+	}
+
+	for id, key := range keys {
+		if id == "" || strings.Contains(id, ":") {
+			return nil, ErrInvalidKeyID
+		}
+
+		if !validKey(key) {
+			return nil, ErrInvalidKey
+		}
+	}
+
+	return &RotatingKeyProvider{current, keys}, nil
+}
+
+// NewStaticKeyProvider creates a KeyProvider with a single key, that has the id "default".
+func NewStaticKeyProvider(key []byte) (*RotatingKeyProvider, error) {
+	return NewRotatingKeyProvider("default", map[string][]byte{"default": key})
+}
+
+// NewFileKeyProvider creates a KeyProvider with a single key with the given id, read from a file.
+//
+// The id is stored with every token, so it has to stay the same for as long as the key is used,
+// wherever the file is mounted. The file can contain the raw key, or the key encoded as hex or base64.
+func NewFileKeyProvider(id, path string) (*RotatingKeyProvider, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "NewFileKeyProvider Failed")
+	}
+
+	return NewRotatingKeyProvider(id, map[string][]byte{id: decodeKey(data)})
+}
+
+// CurrentKey gets the current key.
+func (kp *RotatingKeyProvider) CurrentKey() (string, []byte, error) {
+	return kp.current, kp.keys[kp.current], nil
+}
+
+// Key gets a key by id.
+func (kp *RotatingKeyProvider) Key(id string) ([]byte, error) {
+	key, ok := kp.keys[id]
+	if !ok {
+		return nil, ErrKeyNotFound
+	}
+
+	return key, nil
+}
+
+var _ KeyProvider = &RotatingKeyProvider{}
+
+func validKey(key []byte) bool {
+	return len(key) == 16 || len(key) == 24 || len(key) == 32
+}
+
+// decodeKey decodes a key stored as text, falling back to the raw bytes.
+func decodeKey(data []byte) []byte {
+	text := string(bytes.TrimSpace(data))
+
+	if key, err := hex.DecodeString(text); err == nil && validKey(key) {
+		return key
+	}
+
+	if key, err := base64.StdEncoding.DecodeString(text); err == nil && validKey(key) {
+		return key
+	}
+
+	return data
+}
+
+// EncryptedBotStore wraps a BotStore, encrypting the tokens of stored bots.
+//
+// Each token is encrypted with AES-GCM using a random data key, which is itself
+// encrypted with the current key of the KeyProvider and stored alongside the token.
+// Both are bound to the key of the bot, so a token copied to another bot fails to decrypt.
+// Tokens that are not encrypted are read as is, so existing stores can be wrapped
+// and then encrypted using Migrate.
+//
+// ffjson: skip
+type EncryptedBotStore struct {
+	bs BotStore
+	kp KeyProvider
+}
+
+// NewEncryptedBotStore creates a new EncryptedBotStore storing bots in bs.
+func NewEncryptedBotStore(bs BotStore, kp KeyProvider) *EncryptedBotStore {
+	return &EncryptedBotStore{bs, kp}
+}
+
+// AddBot encrypts the tokens of a bot and stores it.
+func (s *EncryptedBotStore) AddBot(p *oauth.AccessResponse) error {
+	ep, err := s.encrypt(p)
+	if err != nil {
+		return errors.Wrap(err, "AddBot Failed")
+	}
+
+	return s.bs.AddBot(ep)
+}
+
+// GetBot gets a bot and decrypts its tokens.
+func (s *EncryptedBotStore) GetBot(key string) (*oauth.AccessResponse, error) {
+	p, err := s.bs.GetBot(key)
+	if err != nil {
+		return nil, err
+	}
+
+	dp, err := s.decrypt(p)
+	if err != nil {
+		return nil, errors.Wrap(err, "GetBot Failed")
+	}
+
+	return dp, nil
+}
+
+// UpdateBot encrypts the tokens of a bot and replaces the stored one.
+func (s *EncryptedBotStore) UpdateBot(p *oauth.AccessResponse) error {
+	ep, err := s.encrypt(p)
+	if err != nil {
+		return errors.Wrap(err, "UpdateBot Failed")
+	}
+
+	return UpdateBot(s.bs, ep)
+}
+
+// RemoveBot removes a bot.
+func (s *EncryptedBotStore) RemoveBot(key string) error {
+	return s.bs.RemoveBot(key)
+}
+
+// AllBots gets all bots with their tokens decrypted, logging and skipping the ones that fail to decrypt.
+func (s *EncryptedBotStore) AllBots() ([]*oauth.AccessResponse, error) {
+	bots, err := s.bs.AllBots()
+	if err != nil {
+		return nil, err
+	}
+
+	ans := make([]*oauth.AccessResponse, 0, len(bots))
+	for _, p := range bots {
+		dp, err := s.decrypt(p)
+		if err != nil {
+			// one bad record should not keep every other bot from starting
+			log.Println(errors.Wrapf(err, "AllBots skipped the bot %s", BotKey(p)))
+			continue
+		}
+
+		ans = append(ans, dp)
+	}
+
+	return ans, nil
+}
+
+// Migrate re-encrypts the tokens of all stored bots that are either in plaintext,
+// or encrypted with a key other than the current one, returning the number of bots updated.
+//
+// Every bot is read again and replaced with UpdateBot, so a failure leaves its old record in place.
+func (s *EncryptedBotStore) Migrate() (int, error) {
+	id, _, err := s.kp.CurrentKey()
+	if err != nil {
+		return 0, errors.Wrap(err, "Migrate Failed")
+	}
+
+	bots, err := s.bs.AllBots()
+	if err != nil {
+		return 0, errors.Wrap(err, "Migrate Failed")
+	}
+
+	n := 0
+	for _, p := range bots {
+		if !needsMigration(p, id) {
+			continue
+		}
+
+		// the tokens may have been rotated since AllBots
+		cur, err := s.bs.GetBot(BotKey(p))
+		if errors.Cause(err) == ErrBotNotFound {
+			continue
+		}
+
+		if err != nil {
+			return n, errors.Wrap(err, "Migrate Failed")
+		}
+
+		dp, err := s.decrypt(cur)
+		if err != nil {
+			return n, errors.Wrap(err, "Migrate Failed")
+		}
+
+		ep, err := s.encrypt(dp)
+		if err != nil {
+			return n, errors.Wrap(err, "Migrate Failed")
+		}
+
+		if err := UpdateBot(s.bs, ep); err != nil {
+			return n, errors.Wrap(err, "Migrate Failed")
+		}
+
+		n++
+	}
+
+	return n, nil
+}
+
+var _ UpdateBotStore = &EncryptedBotStore{}
+
+// botTokens gets pointers to all the tokens in a payload.
+func botTokens(p *oauth.AccessResponse) []*string {
+	ans := []*string{&p.AccessToken, &p.RefreshToken}
+	if p.Bot != nil {
+		ans = append(ans, &p.Bot.BotAccessToken, &p.Bot.RefreshToken)
+	}
+
+	return ans
+}
+
+// clonePayload copies a payload so its tokens can be replaced.
+func clonePayload(p *oauth.AccessResponse) *oauth.AccessResponse {
+	np := *p
+	if p.Bot != nil {
+		nb := *p.Bot
+		np.Bot = &nb
+	}
+
+	return &np
+}
+
+func needsMigration(p *oauth.AccessResponse, current string) bool {
+	for _, t := range botTokens(p) {
+		if *t != "" && !strings.HasPrefix(*t, encryptedV2+current+":") {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (s *EncryptedBotStore) encrypt(p *oauth.AccessResponse) (*oauth.AccessResponse, error) {
+	id, kek, err := s.kp.CurrentKey()
+	if err != nil {
+		return nil, err
+	}
+
+	ans, ad := clonePayload(p), []byte(BotKey(p))
+	for _, t := range botTokens(ans) {
+		if *t == "" || strings.HasPrefix(*t, encryptedPrefix) {
+			continue
+		}
+
+		if *t, err = encryptToken(*t, id, kek, ad); err != nil {
+			return nil, err
+		}
+	}
+
+	return ans, nil
+}
+
+func (s *EncryptedBotStore) decrypt(p *oauth.AccessResponse) (*oauth.AccessResponse, error) {
+	ans, ad := clonePayload(p), []byte(BotKey(p))
+	for _, t := range botTokens(ans) {
+		if !strings.HasPrefix(*t, encryptedPrefix) {
+			continue
+		}
+
+		var err error
+		if *t, err = s.decryptToken(*t, ad); err != nil {
+			return nil, err
+		}
+	}
+
+	return ans, nil
+}
+
+// sealGCM encrypts plaintext with AES-GCM, authenticating ad along with it,
+// and prefixing the result with the nonce.
+func sealGCM(key, plaintext, ad []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+
+	return gcm.Seal(nonce, nonce, plaintext, ad), nil
+}
+
+// openGCM decrypts data created by sealGCM with the same ad.
+func openGCM(key, data, ad []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) < gcm.NonceSize() {
+		return nil, ErrInvalidCiphertext
+	}
+
+	plaintext, err := gcm.Open(nil, data[:gcm.NonceSize()], data[gcm.NonceSize():], ad)
+	if err != nil {
+		return nil, ErrInvalidCiphertext
+	}
+
+	return plaintext, nil
+}
+
+// encryptToken encrypts a token as enc:v2:<key id>:<encrypted data key>:<encrypted token>,
+// with both bound to ad.
+func encryptToken(token, id string, kek, ad []byte) (string, error) {
+	dek := make([]byte, 32)
+	if _, err := io.ReadFull(rand.Reader, dek); err != nil {
+		return "", err
+	}
+
+	wrapped, err := sealGCM(kek, dek, ad)
+	if err != nil {
+		return "", err
+	}
+
+	ct, err := sealGCM(dek, []byte(token), ad)
+	if err != nil {
+		return "", err
+	}
+
+	enc := base64.RawURLEncoding
+	return encryptedV2 + id + ":" + enc.EncodeToString(wrapped) + ":" + enc.EncodeToString(ct), nil
+}
+
+// decryptToken decrypts a token created by encryptToken with the same ad.
+func (s *EncryptedBotStore) decryptToken(token string, ad []byte) (string, error) {
+	if !strings.HasPrefix(token, encryptedV2) {
+		return "", ErrInvalidCiphertext
+	}
+
+	parts := strings.Split(strings.TrimPrefix(token, encryptedV2), ":")
+	if len(parts) != 3 {
+		return "", ErrInvalidCiphertext
+	}
+
+	kek, err := s.kp.Key(parts[0])
+	if err != nil {
+		return "", err
+	}
+
+	enc := base64.RawURLEncoding
+
+	wrapped, err := enc.DecodeString(parts[1])
+	if err != nil {
+		return "", ErrInvalidCiphertext
+	}
+
+	ct, err := enc.DecodeString(parts[2])
+	if err != nil {
+		return "", ErrInvalidCiphertext
+	}
+
+	dek, err := openGCM(kek, wrapped, ad)
+	if err != nil {
+		return "", err
+	}
+
+	plaintext, err := openGCM(dek, ct, ad)
+	if err != nil {
+		return "", err
+	}
+
+	return string(plaintext), nil
+}
@@ -0,0 +1,267 @@
+package slack
+
+import (
+	"bytes"
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+
+	"suy.io/bots/slack/api/oauth"
+)
+
+var (
+	testKey1 = bytes.Repeat([]byte{1}, 32)
+	testKey2 = bytes.Repeat([]byte{2}, 32)
+)
+
+func TestNewRotatingKeyProvider(t *testing.T) {
+	type args struct {
+		current string
+		keys    map[string][]byte
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		wantErr error
+	}{
+		{"", args{"k1", map[string][]byte{"k1": testKey1, "k2": testKey2}}, nil},
+		{"", args{"k3", map[string][]byte{"k1": testKey1}}, ErrKeyNotFound},
+		{"", args{"k1", map[string][]byte{"k1": []byte("short")}}, ErrInvalidKey},
+		{"", args{"k:1", map[string][]byte{"k:1": testKey1}}, ErrInvalidKeyID},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewRotatingKeyProvider(tt.args.current, tt.args.keys); err != tt.wantErr {
+				t.Errorf("NewRotatingKeyProvider() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNewFileKeyProvider(t *testing.T) {
+	dir, err := ioutil.TempDir("", "slack")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	hexFile := filepath.Join(dir, "hex.key")
+	ioutil.WriteFile(hexFile, []byte(hex.EncodeToString(testKey1)+"\n"), 0600)
+
+	rawFile := filepath.Join(dir, "raw.key")
+	ioutil.WriteFile(rawFile, testKey2, 0600)
+
+	tests := []struct {
+		name    string
+		id      string
+		path    string
+		wantKey []byte
+		wantErr bool
+	}{
+		{"", "k1", hexFile, testKey1, false},
+		{"", "k2", rawFile, testKey2, false},
+		{"", "k3", filepath.Join(dir, "missing.key"), nil, true},
+		{"", "", hexFile, nil, true},
+		{"", "k:1", hexFile, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kp, err := NewFileKeyProvider(tt.id, tt.path)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewFileKeyProvider() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			id, key, _ := kp.CurrentKey()
+			if id != tt.id || !bytes.Equal(key, tt.wantKey) {
+				t.Errorf("NewFileKeyProvider() = %v %x, want %v %x", id, key, tt.id, tt.wantKey)
+			}
+		})
+	}
+}
+
+func TestEncryptedBotStore(t *testing.T) {
+	kp, err := NewStaticKeyProvider(testKey1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	inner := NewMemoryBotStore()
+	s := NewEncryptedBotStore(inner, kp)
+
+	p := &oauth.AccessResponse{
+		TeamID:      "T1234567",
+		AccessToken: "xoxp-user",
+		Bot:         &oauth.Bot{BotUserID: "U1234567", BotAccessToken: "xoxb-bot", RefreshToken: "xoxe-refresh"},
+	}
+
+	if err := s.AddBot(p); err != nil {
+		t.Fatal(err)
+	}
+
+	raw, err := inner.GetBot("T1234567")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tok := range []string{raw.AccessToken, raw.Bot.BotAccessToken, raw.Bot.RefreshToken} {
+		if !strings.HasPrefix(tok, "enc:v2:default:") {
+			t.Errorf("EncryptedBotStore.AddBot() stored %v, want encrypted token", tok)
+		}
+	}
+
+	if raw.RefreshToken != "" || raw.Bot.BotUserID != "U1234567" {
+		t.Errorf("EncryptedBotStore.AddBot() changed fields that are not tokens")
+	}
+
+	if p.Bot.BotAccessToken != "xoxb-bot" {
+		t.Errorf("EncryptedBotStore.AddBot() modified the passed payload")
+	}
+
+	got, err := s.GetBot("T1234567")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(got, p) {
+		t.Errorf("EncryptedBotStore.GetBot() = %v, want %v", got, p)
+	}
+
+	all, err := s.AllBots()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(all, []*oauth.AccessResponse{p}) {
+		t.Errorf("EncryptedBotStore.AllBots() = %v, want %v", all, []*oauth.AccessResponse{p})
+	}
+
+	other, _ := NewStaticKeyProvider(testKey2)
+	if _, err := NewEncryptedBotStore(inner, other).GetBot("T1234567"); err == nil {
+		t.Errorf("EncryptedBotStore.GetBot() with the wrong key error = nil, want error")
+	}
+}
+
+func TestEncryptedBotStore_Migrate(t *testing.T) {
+	inner := NewMemoryBotStore()
+	inner.AddBot(&oauth.AccessResponse{TeamID: "T1", Bot: &oauth.Bot{BotAccessToken: "xoxb-plain"}})
+
+	old, err := NewRotatingKeyProvider("k1", map[string][]byte{"k1": testKey1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	NewEncryptedBotStore(inner, old).AddBot(&oauth.AccessResponse{TeamID: "T2", Bot: &oauth.Bot{BotAccessToken: "xoxb-k1"}})
+
+	kp, err := NewRotatingKeyProvider("k2", map[string][]byte{"k1": testKey1, "k2": testKey2})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := NewEncryptedBotStore(inner, kp)
+
+	tests := []struct {
+		name string
+		want int
+	}{
+		{"", 2},
+		{"", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.Migrate()
+			if err != nil {
+				t.Fatalf("EncryptedBotStore.Migrate() error = %v", err)
+			}
+
+			if got != tt.want {
+				t.Errorf("EncryptedBotStore.Migrate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	for team, want := range map[string]string{"T1": "xoxb-plain", "T2": "xoxb-k1"} {
+		raw, _ := inner.GetBot(team)
+		if !strings.HasPrefix(raw.Bot.BotAccessToken, "enc:v2:k2:") {
+			t.Errorf("EncryptedBotStore.Migrate() stored %v, want token encrypted with k2", raw.Bot.BotAccessToken)
+		}
+
+		p, err := s.GetBot(team)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if p.Bot.BotAccessToken != want {
+			t.Errorf("EncryptedBotStore.GetBot() = %v, want %v", p.Bot.BotAccessToken, want)
+		}
+	}
+}
+
+func TestEncryptedBotStore_bound(t *testing.T) {
+	kp, err := NewStaticKeyProvider(testKey1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	inner := NewMemoryBotStore()
+	s := NewEncryptedBotStore(inner, kp)
+
+	s.AddBot(&oauth.AccessResponse{TeamID: "T1", Bot: &oauth.Bot{BotAccessToken: "xoxb-1"}})
+	inner.AddBot(&oauth.AccessResponse{TeamID: "T2", Bot: &oauth.Bot{BotAccessToken: "xoxb-2"}})
+
+	// a token copied from another bot does not decrypt
+	raw, _ := inner.GetBot("T1")
+	inner.AddBot(&oauth.AccessResponse{TeamID: "T3", Bot: &oauth.Bot{BotAccessToken: raw.Bot.BotAccessToken}})
+
+	if _, err := s.GetBot("T3"); err == nil {
+		t.Errorf("EncryptedBotStore.GetBot() of a copied token error = nil, want error")
+	}
+
+	// tokens of other formats are not read
+	inner.AddBot(&oauth.AccessResponse{TeamID: "T4", Bot: &oauth.Bot{BotAccessToken: "enc:v1:" + strings.TrimPrefix(raw.Bot.BotAccessToken, encryptedV2)}})
+
+	if _, err := s.GetBot("T4"); errors.Cause(err) != ErrInvalidCiphertext {
+		t.Errorf("EncryptedBotStore.GetBot() of an unknown format error = %v, want %v", err, ErrInvalidCiphertext)
+	}
+
+	inner.RemoveBot("T4")
+
+	// the bad record is skipped
+	all, err := s.AllBots()
+	if err != nil {
+		t.Fatalf("EncryptedBotStore.AllBots() error = %v", err)
+	}
+
+	if len(all) != 2 {
+		t.Errorf("EncryptedBotStore.AllBots() got %v bots, want %v", len(all), 2)
+	}
+
+	inner.RemoveBot("T3")
+
+	if n, err := s.Migrate(); err != nil || n != 1 {
+		t.Errorf("EncryptedBotStore.Migrate() = %v, %v, want %v", n, err, 1)
+	}
+
+	if raw, _ := inner.GetBot("T2"); !strings.HasPrefix(raw.Bot.BotAccessToken, "enc:v2:default:") {
+		t.Errorf("EncryptedBotStore.Migrate() stored %v, want a v2 token", raw.Bot.BotAccessToken)
+	}
+
+	if p, err := s.GetBot("T2"); err != nil || p.Bot.BotAccessToken != "xoxb-2" {
+		t.Errorf("EncryptedBotStore.GetBot() after Migrate() = %v, %v, want %v", p, err, "xoxb-2")
+	}
+}
@@ -0,0 +1,189 @@
+package slack
+
+import (
+	"context"
+	"encoding/json"
+	"hash/fnv"
+	"sync"
+	"sync/atomic"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// DefaultEventWorkers is the number of events the Controller handles at once.
+	DefaultEventWorkers = 10
+
+	// DefaultEventQueueSize is the number of events that can wait for the workers,
+	// before EventHandler rejects events for slack to retry later.
+	DefaultEventQueueSize = 1000
+)
+
+var errDuplicateEvent = errors.New("Duplicate Event")
+
+// EventStats are counters of the events received by EventHandler,
+// a growing queue or rejected events mean events are handled slower than they arrive.
+type EventStats struct {
+	// Workers is the number of workers handling events, and Busy the number of them handling one now.
+	Workers, Busy int
+
+	// Queued is the number of events waiting for a worker, out of QueueSize.
+	Queued, QueueSize int
+
+	// Handled, Failed and Duplicates count the events taken by workers,
+	// Duplicates are the ones dropped as retries of events already handled.
+	Handled, Failed, Duplicates uint64
+
+	// Rejected counts the events that arrived while the queue was full, or after draining,
+	// slack retries them later.
+	Rejected uint64
+}
+
+// eventPool handles events with a fixed number of workers, each taking events from its own bounded queue.
+// Events are queued by key, so the events of a key are handled one at a time, in the order they arrived.
+//
+// ffjson: skip
+type eventPool struct {
+	queues []chan []byte
+	handle func([]byte) error
+	wg     sync.WaitGroup
+
+	mu     sync.RWMutex
+	closed bool
+
+	busy                                  int64
+	handled, failed, duplicates, rejected uint64
+}
+
+// newEventPool creates an eventPool, splitting size over the queues of the workers.
+func newEventPool(workers, size int, handle func([]byte) error) *eventPool {
+	p := &eventPool{
+		queues: make([]chan []byte, workers),
+		handle: handle,
+	}
+
+	p.wg.Add(workers)
+	for i := range p.queues {
+		p.queues[i] = make(chan []byte, (size+workers-1)/workers)
+		go p.work(p.queues[i])
+	}
+
+	return p
+}
+
+func (p *eventPool) work(queue chan []byte) {
+	defer p.wg.Done()
+
+	for d := range queue {
+		atomic.AddInt64(&p.busy, 1)
+		err := p.handle(d)
+		atomic.AddInt64(&p.busy, -1)
+
+		switch err {
+		case nil:
+			atomic.AddUint64(&p.handled, 1)
+		case errDuplicateEvent:
+			atomic.AddUint64(&p.duplicates, 1)
+		default:
+			atomic.AddUint64(&p.failed, 1)
+		}
+	}
+}
+
+// push queues an event for the worker of its key without waiting,
+// returning false if the queue of the worker is full or drained.
+func (p *eventPool) push(key string, d []byte) bool {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if !p.closed {
+		h := fnv.New32a()
+		h.Write([]byte(key))
+
+		select {
+		case p.queues[h.Sum32()%uint32(len(p.queues))] <- d:
+			return true
+		default:
+		}
+	}
+
+	atomic.AddUint64(&p.rejected, 1)
+	return false
+}
+
+// drain stops taking events, and waits for the queued ones to be handled or for ctx to be done.
+func (p *eventPool) drain(ctx context.Context) error {
+	p.mu.Lock()
+	if !p.closed {
+		p.closed = true
+		for _, queue := range p.queues {
+			close(queue)
+		}
+	}
+	p.mu.Unlock()
+
+	return waitContext(ctx, p.wg.Wait)
+}
+
+// waitContext calls wait, returning early with the error of ctx if it is done first.
+func waitContext(ctx context.Context, wait func()) error {
+	done := make(chan struct{})
+	go func() {
+		wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (p *eventPool) stats() EventStats {
+	s := EventStats{
+		Workers:    len(p.queues),
+		Busy:       int(atomic.LoadInt64(&p.busy)),
+		Handled:    atomic.LoadUint64(&p.handled),
+		Failed:     atomic.LoadUint64(&p.failed),
+		Duplicates: atomic.LoadUint64(&p.duplicates),
+		Rejected:   atomic.LoadUint64(&p.rejected),
+	}
+
+	for _, queue := range p.queues {
+		s.Queued += len(queue)
+		s.QueueSize += cap(queue)
+	}
+
+	return s
+}
+
+// eventRoute is the part of an event that tells the conversation it belongs to.
+//
+// ffjson: skip
+type eventRoute struct {
+	Channel string `json:"channel"`
+	User    string `json:"user"`
+	Item    struct {
+		Channel string `json:"channel"`
+	} `json:"item"`
+}
+
+// eventKey gets the key an event is queued by, the install and channel of the event,
+// or the install and user for events outside of a channel.
+func eventKey(e *eventCallback) string {
+	// fields of other types, like the channel object of channel_created, are left empty
+	r := &eventRoute{}
+	json.Unmarshal(e.Event, r)
+
+	key := installKey(e.EnterpriseID, e.TeamID)
+	switch {
+	case r.Channel != "":
+		return key + ":" + r.Channel
+	case r.Item.Channel != "":
+		return key + ":" + r.Item.Channel
+	default:
+		return key + ":" + r.User
+	}
+}
@@ -0,0 +1,208 @@
+package slack
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestEventPool(t *testing.T) {
+	release := make(chan struct{})
+	started := make(chan struct{}, 4)
+
+	p := newEventPool(1, 1, func(d []byte) error {
+		started <- struct{}{}
+		<-release
+
+		switch string(d) {
+		case "duplicate":
+			return errDuplicateEvent
+		case "fail":
+			return errors.New("fail")
+		}
+
+		return nil
+	})
+
+	if !p.push("k", []byte("ok")) {
+		t.Fatalf("eventPool.push() = false, want true")
+	}
+
+	<-started
+
+	if !p.push("k", []byte("fail")) {
+		t.Fatalf("eventPool.push() to the queue = false, want true")
+	}
+
+	if p.push("k", []byte("duplicate")) {
+		t.Errorf("eventPool.push() to a full queue = true, want false")
+	}
+
+	if got, want := p.stats(), (EventStats{Workers: 1, Busy: 1, Queued: 1, QueueSize: 1, Rejected: 1}); got != want {
+		t.Errorf("eventPool.stats() = %+v, want %+v", got, want)
+	}
+
+	close(release)
+
+	if err := p.drain(context.Background()); err != nil {
+		t.Fatalf("eventPool.drain() error = %v", err)
+	}
+
+	if p.push("k", []byte("ok")) {
+		t.Errorf("eventPool.push() after drain = true, want false")
+	}
+
+	if got, want := p.stats(), (EventStats{Workers: 1, QueueSize: 1, Handled: 1, Failed: 1, Rejected: 2}); got != want {
+		t.Errorf("eventPool.stats() = %+v, want %+v", got, want)
+	}
+}
+
+func TestEventPool_drainTimeout(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+
+	p := newEventPool(1, 1, func(d []byte) error {
+		<-release
+		return nil
+	})
+
+	p.push("k", []byte("ok"))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if err := p.drain(ctx); err != context.DeadlineExceeded {
+		t.Errorf("eventPool.drain() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestController_DrainEvents(t *testing.T) {
+	c, err := NewController(WithEventWorkers(2, 10))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	event := func(id string) *http.Request {
+		return httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"type":"event_callback","team_id":"T404","event_id":"`+id+`","event":{"type":"message","channel":"C1","user":"U1","text":"hello"}}`))
+	}
+
+	for _, id := range []string{"Ev1", "Ev2", "Ev1"} {
+		res := httptest.NewRecorder()
+		c.EventHandler()(res, event(id))
+
+		if res.Code != http.StatusOK {
+			t.Errorf("Controller.EventHandler() = %v, want %v", res.Code, http.StatusOK)
+		}
+	}
+
+	if err := c.DrainEvents(context.Background()); err != nil {
+		t.Fatalf("Controller.DrainEvents() error = %v", err)
+	}
+
+	res := httptest.NewRecorder()
+	c.EventHandler()(res, event("Ev3"))
+
+	if res.Code != http.StatusServiceUnavailable {
+		t.Errorf("Controller.EventHandler() after DrainEvents() = %v, want %v", res.Code, http.StatusServiceUnavailable)
+	}
+
+	// the team has no bot, so the events fail
+	if got, want := c.EventStats(), (EventStats{Workers: 2, QueueSize: 10, Failed: 2, Duplicates: 1, Rejected: 1}); got != want {
+		t.Errorf("Controller.EventStats() = %+v, want %+v", got, want)
+	}
+}
+
+func TestWithEventWorkers(t *testing.T) {
+	tests := []struct {
+		workers, queue int
+		wantErr        bool
+	}{
+		{1, 0, false},
+		{10, 100, false},
+		{0, 100, true},
+		{1, -1, true},
+	}
+
+	for _, tt := range tests {
+		if err := WithEventWorkers(tt.workers, tt.queue)(&Controller{}); (err != nil) != tt.wantErr {
+			t.Errorf("WithEventWorkers(%v, %v) error = %v, wantErr %v", tt.workers, tt.queue, err, tt.wantErr)
+		}
+	}
+}
+
+func TestEventPool_order(t *testing.T) {
+	var (
+		mu  sync.Mutex
+		got = map[string][]string{}
+	)
+
+	p := newEventPool(4, 400, func(d []byte) error {
+		// later events finish first, unless they wait for the ones before
+		time.Sleep(time.Duration(d[len(d)-1]%3) * time.Millisecond)
+
+		mu.Lock()
+		k := string(d[:2])
+		got[k] = append(got[k], string(d))
+		mu.Unlock()
+
+		return nil
+	})
+
+	for i := 0; i < 20; i++ {
+		for _, k := range []string{"C1", "C2", "C3"} {
+			if !p.push("T1:"+k, []byte(k+"-"+strconv.Itoa(i))) {
+				t.Fatalf("eventPool.push() = false, want true")
+			}
+		}
+	}
+
+	if err := p.drain(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, k := range []string{"C1", "C2", "C3"} {
+		for i, d := range got[k] {
+			if want := k + "-" + strconv.Itoa(i); d != want {
+				t.Errorf("eventPool handled %v at %v, want %v", d, i, want)
+			}
+		}
+	}
+
+	if s := p.stats(); s.QueueSize != 400 {
+		t.Errorf("eventPool.stats().QueueSize = %v, want %v", s.QueueSize, 400)
+	}
+}
+
+func Test_eventKey(t *testing.T) {
+	tests := []struct {
+		name  string
+		event string
+		want  string
+	}{
+		{"message", `{"team_id":"T1","event":{"type":"message","channel":"C1","user":"U1"}}`, "T1:C1"},
+		{"enterprise", `{"team_id":"T1","enterprise_id":"E1","event":{"type":"message","channel":"C1","user":"U1"}}`, "E1/T1:C1"},
+		{"reaction", `{"team_id":"T1","event":{"type":"reaction_added","user":"U1","item":{"type":"message","channel":"C2"}}}`, "T1:C2"},
+		{"user", `{"team_id":"T1","event":{"type":"app_home_opened","user":"U1"}}`, "T1:U1"},
+		{"channel object", `{"team_id":"T1","event":{"type":"channel_created","channel":{"id":"C3"}}}`, "T1:"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := &eventCallback{}
+			if err := json.Unmarshal([]byte(tt.event), e); err != nil {
+				t.Fatal(err)
+			}
+
+			if got := eventKey(e); got != tt.want {
+				t.Errorf("eventKey() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
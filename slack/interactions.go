@@ -35,8 +35,9 @@ type Interaction struct {
 		} `json:"selected_options,omitempty"`
 	} `json:"actions"`
 	Team *struct {
-		ID     string `json:"id"`
-		Domain string `json:"domain"`
+		ID           string `json:"id"`
+		Domain       string `json:"domain"`
+		EnterpriseID string `json:"enterprise_id"`
 	} `json:"team"`
 	Channel *struct {
 		ID   string `json:"id"`
@@ -146,8 +147,9 @@ type InteractionOptions struct {
 	Value      string `json:"value"`
 	CallbackID string `json:"callback_id"`
 	Team       struct {
-		ID     string `json:"id"`
-		Domain string `json:"domain"`
+		ID           string `json:"id"`
+		Domain       string `json:"domain"`
+		EnterpriseID string `json:"enterprise_id"`
 	} `json:"team"`
 	Channel struct {
 		ID   string `json:"id"`
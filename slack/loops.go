@@ -0,0 +1,103 @@
+package slack
+
+import (
+	"sync"
+	"time"
+)
+
+// BotMessagePolicy decides how messages sent by other bots are handled.
+type BotMessagePolicy int
+
+const (
+	// BotMessagesSeparate sends messages of other bots only to BotMessages, this is the default.
+	BotMessagesSeparate BotMessagePolicy = iota
+
+	// BotMessagesIgnore drops messages of other bots.
+	BotMessagesIgnore
+
+	// BotMessagesHandle handles messages of other bots like messages of users,
+	// so they are sent to DirectMessages, DirectMentions, Mentions, AmbientMessages
+	// or an active conversation.
+	BotMessagesHandle
+)
+
+const (
+	// DefaultRepeatLimit is the number of times a bot can send the same text to a channel in a row,
+	// before its messages are dropped.
+	DefaultRepeatLimit = 3
+
+	// DefaultRepeatWindow is how long repeats of a text are counted for.
+	DefaultRepeatWindow = time.Minute
+)
+
+// loopGuard keeps bots from answering themselves, or each other forever.
+//
+// Messages sent by the bot itself are always dropped. Its users post as its bot user,
+// but integrations posting as the bot only carry its bot ID, which is looked up when the bot is added.
+//
+// Two bots answering each other usually end up repeating the same text, so messages of other
+// bots repeating the last text sent to a channel more than limit times within window are dropped.
+//
+// ffjson: skip
+type loopGuard struct {
+	policy BotMessagePolicy
+	limit  int
+	window time.Duration
+
+	mu      sync.Mutex
+	repeats map[string]*repeat
+	swept   time.Time
+}
+
+type repeat struct {
+	text  string
+	count int
+	since time.Time
+}
+
+func newLoopGuard() *loopGuard {
+	return &loopGuard{
+		policy:  BotMessagesSeparate,
+		limit:   DefaultRepeatLimit,
+		window:  DefaultRepeatWindow,
+		repeats: make(map[string]*repeat),
+	}
+}
+
+// self checks if a message from user, or from the bot with botID, was sent by bot.
+func (g *loopGuard) self(bot *Bot, user, botID string) bool {
+	return (user != "" && user == bot.id) || (botID != "" && botID == bot.botID)
+}
+
+// repeated counts a message of another bot, checking if it repeats the same text too often.
+func (g *loopGuard) repeated(bot *Bot, channel, text string) bool {
+	if g.limit == 0 {
+		return false
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	now := time.Now()
+
+	// drop channels that went quiet once a window, so the map does not grow with every channel ever seen
+	if now.Sub(g.swept) > g.window {
+		g.swept = now
+
+		for k, r := range g.repeats {
+			if now.Sub(r.since) > g.window {
+				delete(g.repeats, k)
+			}
+		}
+	}
+
+	k := bot.key + "_" + channel
+	r, ok := g.repeats[k]
+	if !ok || r.text != text || now.Sub(r.since) > g.window {
+		g.repeats[k] = &repeat{text, 1, now}
+		return false
+	}
+
+	r.count++
+	return r.count > g.limit
+}
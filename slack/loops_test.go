@@ -0,0 +1,253 @@
+package slack
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"suy.io/bots/slack/api"
+	"suy.io/bots/slack/api/oauth"
+)
+
+func TestLoopGuard_self(t *testing.T) {
+	g := newLoopGuard()
+	bot := &Bot{id: "U123", botID: "B123", key: "T123"}
+
+	tests := []struct {
+		name  string
+		user  string
+		botID string
+		want  bool
+	}{
+		{"user", "U1", "", false},
+		{"other bot", "", "B2", false},
+		{"bot user", "U123", "", true},
+		{"bot user with bot id", "U123", "B123", true},
+		{"own bot id", "", "B123", true},
+		{"other bot user", "U2", "B2", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := g.self(bot, tt.user, tt.botID); got != tt.want {
+				t.Errorf("loopGuard.self() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoopGuard_repeated(t *testing.T) {
+	g := newLoopGuard()
+	g.limit = 2
+	bot := &Bot{id: "U123", key: "T123"}
+
+	tests := []struct {
+		channel, text string
+		want          bool
+	}{
+		{"C1", "ping", false},
+		{"C1", "ping", false},
+		{"C2", "ping", false},
+		{"C1", "ping", true},
+		{"C1", "ping", true},
+		{"C1", "pong", false},
+		{"C1", "ping", false},
+	}
+
+	for i, tt := range tests {
+		if got := g.repeated(bot, tt.channel, tt.text); got != tt.want {
+			t.Errorf("%d: loopGuard.repeated(%v, %v) = %v, want %v", i, tt.channel, tt.text, got, tt.want)
+		}
+	}
+
+	g.window = time.Millisecond
+	g.repeated(bot, "C3", "ping")
+	g.repeated(bot, "C3", "ping")
+	time.Sleep(5 * time.Millisecond)
+
+	if g.repeated(bot, "C3", "ping") {
+		t.Errorf("loopGuard.repeated() = true after window, want false")
+	}
+
+	g.limit = 0
+	for i := 0; i < 5; i++ {
+		if g.repeated(bot, "C4", "ping") {
+			t.Errorf("loopGuard.repeated() = true with no limit, want false")
+		}
+	}
+}
+
+func TestController_handleMessage_bots(t *testing.T) {
+	tests := []struct {
+		name    string
+		options []func(*Controller) error
+		msgs    []string
+		want    string
+	}{
+		{
+			"own message dropped",
+			nil,
+			[]string{
+				`{"type":"message","channel":"C1","user":"U123","text":"echo","ts":"1.1"}`,
+				`{"type":"message","channel":"C1","user":"U1","text":"human","ts":"1.2"}`,
+			},
+			"ambient human",
+		},
+		{
+			"own bot id dropped",
+			nil,
+			[]string{
+				`{"type":"message","subtype":"bot_message","channel":"C1","bot_id":"B123","text":"echo","ts":"1.1"}`,
+				`{"type":"message","channel":"C1","user":"U123","bot_id":"B123","text":"echo","ts":"1.2"}`,
+				`{"type":"message","subtype":"bot_message","channel":"C1","bot_id":"B1","text":"other","ts":"1.3"}`,
+			},
+			"bot other",
+		},
+		{
+			"bot user message is a bot message",
+			nil,
+			[]string{
+				`{"type":"message","channel":"C1","user":"U1","bot_id":"B1","text":"beep","ts":"1.1"}`,
+			},
+			"bot beep",
+		},
+		{
+			"ignore",
+			[]func(*Controller) error{WithBotMessagePolicy(BotMessagesIgnore)},
+			[]string{
+				`{"type":"message","subtype":"bot_message","channel":"C1","bot_id":"B1","text":"beep","ts":"1.1"}`,
+				`{"type":"message","channel":"C1","user":"U1","text":"human","ts":"1.2"}`,
+			},
+			"ambient human",
+		},
+		{
+			"handle",
+			[]func(*Controller) error{WithBotMessagePolicy(BotMessagesHandle)},
+			[]string{
+				`{"type":"message","subtype":"bot_message","channel":"C1","bot_id":"B1","text":"beep","ts":"1.1"}`,
+			},
+			"ambient beep",
+		},
+		{
+			"repeats dropped",
+			[]func(*Controller) error{WithRepeatLimit(1, time.Minute), WithBotMessagePolicy(BotMessagesHandle)},
+			[]string{
+				`{"type":"message","channel":"C1","user":"U1","bot_id":"B1","text":"beep","ts":"1.1"}`,
+				`{"type":"message","channel":"C1","user":"U1","bot_id":"B1","text":"beep","ts":"1.2"}`,
+				`{"type":"message","channel":"C1","user":"U2","text":"beep","ts":"1.3"}`,
+			},
+			"ambient beep 1.1,ambient beep 1.3",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := NewController(tt.options...)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			c.bots.AddBot(&oauth.AccessResponse{TeamID: "T123", Bot: &oauth.Bot{BotUserID: "U123", BotID: "B123"}})
+			c.BotMessages()
+
+			for _, msg := range tt.msgs {
+				if err := c.handleMessage([]byte(msg), "T123"); err != nil {
+					t.Fatalf("Controller.handleMessage() error = %v", err)
+				}
+
+				// channel sends happen in goroutines, let each settle to keep the order
+				time.Sleep(10 * time.Millisecond)
+			}
+
+			got := ""
+			for {
+				var s string
+
+				select {
+				case p := <-c.AmbientMessages():
+					s = "ambient " + p.Message.Text
+					if tt.name == "repeats dropped" {
+						s += " " + p.Message.Ts
+					}
+				case p := <-c.BotMessages():
+					s = "bot " + p.Text
+				case <-time.After(50 * time.Millisecond):
+				}
+
+				if s == "" {
+					break
+				}
+
+				if got != "" {
+					got += ","
+				}
+
+				got += s
+			}
+
+			if got != tt.want {
+				t.Errorf("Controller.handleMessage() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithRepeatLimit(t *testing.T) {
+	tests := []struct {
+		limit   int
+		window  time.Duration
+		wantErr bool
+	}{
+		{0, time.Minute, false},
+		{5, time.Second, false},
+		{-1, time.Minute, true},
+		{3, 0, true},
+	}
+
+	for _, tt := range tests {
+		c := &Controller{loops: newLoopGuard()}
+		if err := WithRepeatLimit(tt.limit, tt.window)(c); (err != nil) != tt.wantErr {
+			t.Errorf("WithRepeatLimit(%v, %v) error = %v, wantErr %v", tt.limit, tt.window, err, tt.wantErr)
+		}
+	}
+
+	if err := WithBotMessagePolicy(BotMessagePolicy(7))(&Controller{loops: newLoopGuard()}); err != ErrInvalidBotMessagePolicy {
+		t.Errorf("WithBotMessagePolicy() error = %v, want %v", err, ErrInvalidBotMessagePolicy)
+	}
+}
+
+func TestNewController_identify(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == "/auth.test" {
+			fmt.Fprint(res, `{"ok":true,"user_id":"U123","bot_id":"B123"}`)
+			return
+		}
+
+		// the bot is not connected
+		fmt.Fprint(res, `{"ok":false,"error":"fatal_error"}`)
+	}))
+
+	defer s.Close()
+
+	api.SLACK_API_ROOT = s.URL
+
+	// added before bot ids were stored
+	bs := NewMemoryBotStore()
+	bs.AddBot(&oauth.AccessResponse{TeamID: "T123", Bot: &oauth.Bot{BotUserID: "U123", BotAccessToken: "xoxb-123"}})
+
+	_, err := NewController(WithBotStore(bs), WithStartErrorHandler(func(*oauth.AccessResponse, error) {}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p, err := bs.GetBot("T123")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if p.Bot.BotID != "B123" {
+		t.Errorf("stored bot id = %v, want B123", p.Bot.BotID)
+	}
+}
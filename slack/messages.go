@@ -2,6 +2,8 @@ package slack
 
 import (
 	"suy.io/bots/slack/api/chat"
+	"suy.io/bots/slack/api/conversations"
+	"suy.io/bots/slack/api/files"
 	"suy.io/bots/slack/api/rtm"
 )
 
@@ -22,11 +24,37 @@ func (mp *MessagePair) StartConversation(name string) error {
 	return mp.Bot.StartConversation(mp.Message.User, mp.Message.Channel, name)
 }
 
+// React adds an emoji reaction to the message with the pair's bot.
+func (mp *MessagePair) React(emoji string) error {
+	return mp.Bot.React(mp.Message, emoji)
+}
+
+// ThreadHistory gets the messages in the thread of the message with the pair's bot.
+func (mp *MessagePair) ThreadHistory() ([]*conversations.Message, error) {
+	return mp.Bot.ThreadHistory(mp.Message)
+}
+
 // Typing sends a typing indicator.
 func (mp *MessagePair) Typing() error {
 	return mp.Bot.Typing(mp.Channel)
 }
 
+// External checks if the message was sent in a shared channel by a user from another team.
+//
+// It is always false for org-wide Enterprise Grid installs, where every team in the org shares the bot.
+func (mp *MessagePair) External() bool {
+	if mp.Bot.enterpriseID != "" && mp.Bot.teamID == mp.Bot.enterpriseID {
+		return false
+	}
+
+	team := mp.Message.UserTeam
+	if team == "" {
+		team = mp.Message.SourceTeam
+	}
+
+	return team != "" && team != mp.Bot.teamID
+}
+
 // ChannelJoinMessagePair is sent for channel join events.
 //
 // ffjson: skip
@@ -51,6 +79,118 @@ type GroupJoinMessagePair struct {
 	*Bot
 }
 
+// MessageChangedPair is sent when a message is edited.
+//
+// ffjson: skip
+type MessageChangedPair struct {
+	*rtm.MessageChangedMessage
+	*Bot
+}
+
+// Edited gets the message after the edit, with its channel set.
+func (mp *MessageChangedPair) Edited() *rtm.Message {
+	if mp.MessageChangedMessage.Message == nil {
+		return &rtm.Message{Channel: mp.MessageChangedMessage.Channel}
+	}
+
+	m := *mp.MessageChangedMessage.Message
+	m.Channel = mp.MessageChangedMessage.Channel
+
+	return &m
+}
+
+// Reply replies to the edited message with the pair's bot.
+func (mp *MessageChangedPair) Reply(msg *chat.Message) (*chat.Message, error) {
+	return mp.Bot.Reply(mp.Edited(), msg)
+}
+
+// MessageDeletedPair is sent when a message is deleted.
+//
+// ffjson: skip
+type MessageDeletedPair struct {
+	*rtm.MessageDeletedMessage
+	*Bot
+}
+
+// BotMessagePair is sent for messages of integrations and other bots.
+//
+// ffjson: skip
+type BotMessagePair struct {
+	*rtm.BotMessage
+	*Bot
+}
+
+// MemberChannelPair is sent when a user joins or leaves a channel.
+//
+// ffjson: skip
+type MemberChannelPair struct {
+	*rtm.MemberChannelEvent
+	*Bot
+}
+
+// PresenceChangePair is sent when the presence of a user changes.
+//
+// ffjson: skip
+type PresenceChangePair struct {
+	*rtm.PresenceChangeEvent
+	*Bot
+}
+
+// UserTypingPair is sent when a user is typing.
+//
+// ffjson: skip
+type UserTypingPair struct {
+	*rtm.UserTypingEvent
+	*Bot
+}
+
+// ReactionPair is sent when a reaction is added or removed.
+//
+// ffjson: skip
+type ReactionPair struct {
+	*rtm.ReactionMessage
+	*Bot
+}
+
+// Message gets the message the reaction was added to or removed from.
+func (rp *ReactionPair) Message() *rtm.Message {
+	if rp.ReactionMessage.Item == nil {
+		return &rtm.Message{}
+	}
+
+	return &rtm.Message{
+		Channel: rp.ReactionMessage.Item.Channel,
+		Ts:      rp.ReactionMessage.Item.Ts,
+		User:    rp.ReactionMessage.ItemUser,
+	}
+}
+
+// FileSharedPair is sent each time a file is shared.
+//
+// ffjson: skip
+type FileSharedPair struct {
+	*rtm.FileSharedMessage
+	*Bot
+}
+
+// Info gets the information for the shared file with the pair's bot.
+func (fp *FileSharedPair) Info() (*files.File, error) {
+	return fp.Bot.FileInfo(fp.FileSharedMessage.FileID)
+}
+
+// LinkSharedPair is sent when links that have no registered UnfurlHandler are shared.
+//
+// ffjson: skip
+type LinkSharedPair struct {
+	*rtm.LinkSharedMessage
+	*Bot
+}
+
+// Unfurl attaches previews for the shared links with the pair's bot.
+func (lp *LinkSharedPair) Unfurl(unfurls map[string]*chat.Attachment) error {
+	return lp.Bot.Unfurl(lp.LinkSharedMessage.Channel, lp.LinkSharedMessage.MessageTs, unfurls)
+}
+
 // InteractionPair is sent for interactions
 //
 // ffjson: skip
@@ -0,0 +1,162 @@
+package slack
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/pkg/errors"
+)
+
+// deliver runs a send on a public channel in a goroutine, so handling never waits for receivers.
+// The send has to give up once done is closed, as Shutdown closes the channels after that.
+func (c *Controller) deliver(send func(done <-chan struct{})) {
+	c.stopMu.RLock()
+	defer c.stopMu.RUnlock()
+
+	if c.closed {
+		return
+	}
+
+	c.deliveries.Add(1)
+	go func() {
+		defer c.deliveries.Done()
+		send(c.done)
+	}()
+}
+
+// spawn runs a handler in a goroutine, that Shutdown waits for.
+func (c *Controller) spawn(f func()) {
+	c.stopMu.RLock()
+	defer c.stopMu.RUnlock()
+
+	if c.closed {
+		return
+	}
+
+	c.handlers.Add(1)
+	go func() {
+		defer c.handlers.Done()
+		f()
+	}()
+}
+
+// unavailable responds with a 503 once Shutdown was called.
+func (c *Controller) unavailable(res http.ResponseWriter) bool {
+	c.stopMu.RLock()
+	defer c.stopMu.RUnlock()
+
+	if !c.stopping {
+		return false
+	}
+
+	http.Error(res, http.StatusText(http.StatusServiceUnavailable), http.StatusServiceUnavailable)
+	return true
+}
+
+// Shutdown stops the Controller. It rejects HTTP requests with a 503, closes the connector and its sockets,
+// waits for the events and messages received so far to be handled and taken from the public channels,
+// and then closes the public channels, so loops ranging over them end.
+//
+// Payloads sent to channels nobody receives from hold Shutdown until ctx is done, so ctx should have a deadline.
+// When ctx is done first, the payloads left are dropped, the channels are still closed,
+// and the error of ctx is returned.
+func (c *Controller) Shutdown(ctx context.Context) error {
+	c.stopMu.Lock()
+	if c.stopping {
+		c.stopMu.Unlock()
+		return errors.Wrap(ErrControllerShutdown, "Shutdown Failed")
+	}
+
+	c.stopping = true
+	c.stopMu.Unlock()
+
+	c.connector.Close()
+
+	// handling adds handlers and deliveries, so each step waits for the ones before it
+	err := waitContext(ctx, func() { <-c.listening })
+	if err == nil {
+		err = c.events.drain(ctx)
+	}
+
+	if err == nil {
+		err = waitContext(ctx, c.handlers.Wait)
+	}
+
+	c.stopMu.Lock()
+	c.closed = true
+	c.stopMu.Unlock()
+
+	if err == nil {
+		err = waitContext(ctx, c.deliveries.Wait)
+	}
+
+	close(c.done)
+	c.deliveries.Wait()
+	c.closeChannels()
+
+	if err != nil {
+		return errors.Wrap(err, "Shutdown Failed")
+	}
+
+	return nil
+}
+
+func (c *Controller) closeChannels() {
+	close(c.botAdded)
+	close(c.botRemoved)
+
+	close(c.directMessages)
+	close(c.directMentions)
+	close(c.mentions)
+	close(c.ambientMessages)
+	close(c.channelJoin)
+	close(c.userChannelJoin)
+	close(c.groupJoin)
+	close(c.reactionAdded)
+	close(c.reactionRemoved)
+	close(c.fileShared)
+	close(c.linkShared)
+	c.closeSubscriptions()
+
+	close(c.interactions)
+	close(c.interactionOptions)
+
+	close(c.commands)
+}
+
+// closeSubscriptions closes the channels created by their accessors,
+// the ones created after this are returned closed.
+func (c *Controller) closeSubscriptions() {
+	c.subsMu.Lock()
+	defer c.subsMu.Unlock()
+
+	c.subsClosed = true
+
+	if c.messageChanged != nil {
+		close(c.messageChanged)
+	}
+
+	if c.messageDeleted != nil {
+		close(c.messageDeleted)
+	}
+
+	if c.botMessages != nil {
+		close(c.botMessages)
+	}
+
+	if c.memberJoined != nil {
+		close(c.memberJoined)
+	}
+
+	if c.memberLeft != nil {
+		close(c.memberLeft)
+	}
+
+	if c.presenceChange != nil {
+		close(c.presenceChange)
+	}
+
+	if c.userTyping != nil {
+		close(c.userTyping)
+	}
+}
@@ -0,0 +1,132 @@
+package slack
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/pkg/errors"
+
+	"suy.io/bots/slack/api/oauth"
+)
+
+func TestController_Shutdown(t *testing.T) {
+	u := websocket.Upgrader{}
+	closed := make(chan struct{})
+
+	s := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		conn, err := u.Upgrade(res, req, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		conn.WriteMessage(websocket.TextMessage, []byte(`{"type":"message","channel":"C1","user":"U1","text":"over rtm","ts":"1.1"}`))
+
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				close(closed)
+				return
+			}
+		}
+	}))
+
+	defer s.Close()
+
+	c, err := NewController()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c.bots.AddBot(&oauth.AccessResponse{TeamID: "T123", Bot: &oauth.Bot{BotUserID: "U123"}})
+
+	got := make(chan string)
+	go func() {
+		for p := range c.AmbientMessages() {
+			got <- p.Message.Text
+		}
+
+		close(got)
+	}()
+
+	if err := c.connector.Add("T123", strings.Replace(s.URL, "http", "ws", 1)); err != nil {
+		t.Fatal(err)
+	}
+
+	if text := <-got; text != "over rtm" {
+		t.Errorf("Controller.AmbientMessages() = %v, want %v", text, "over rtm")
+	}
+
+	res := httptest.NewRecorder()
+	c.EventHandler()(res, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"type":"event_callback","team_id":"T123","event_id":"Ev1","event":{"type":"message","channel":"C1","user":"U1","text":"over events","ts":"1.2"}}`)))
+
+	if res.Code != http.StatusOK {
+		t.Fatalf("Controller.EventHandler() = %v, want %v", res.Code, http.StatusOK)
+	}
+
+	done := make(chan error)
+	go func() { done <- c.Shutdown(context.Background()) }()
+
+	// the queued event is still handled and delivered
+	if text := <-got; text != "over events" {
+		t.Errorf("Controller.AmbientMessages() = %v, want %v", text, "over events")
+	}
+
+	if err := <-done; err != nil {
+		t.Errorf("Controller.Shutdown() error = %v", err)
+	}
+
+	if _, ok := <-got; ok {
+		t.Errorf("Controller.AmbientMessages() was not closed")
+	}
+
+	select {
+	case <-closed:
+	case <-time.After(time.Second):
+		t.Errorf("Controller.Shutdown() did not close the socket")
+	}
+
+	res = httptest.NewRecorder()
+	c.EventHandler()(res, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"type":"event_callback","team_id":"T123","event_id":"Ev2","event":{"type":"message"}}`)))
+
+	if res.Code != http.StatusServiceUnavailable {
+		t.Errorf("Controller.EventHandler() after Shutdown() = %v, want %v", res.Code, http.StatusServiceUnavailable)
+	}
+
+	if err := c.Shutdown(context.Background()); errors.Cause(err) != ErrControllerShutdown {
+		t.Errorf("Controller.Shutdown() error = %v, want %v", err, ErrControllerShutdown)
+	}
+}
+
+func TestController_Shutdown_timeout(t *testing.T) {
+	c, err := NewController()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c.bots.AddBot(&oauth.AccessResponse{TeamID: "T123", Bot: &oauth.Bot{BotUserID: "U123"}})
+
+	// nobody receives direct messages
+	if err := c.handleMessage([]byte(`{"type":"message","channel":"D1","user":"U1","text":"hello","ts":"1.1"}`), "T123"); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	if err := c.Shutdown(ctx); errors.Cause(err) != context.DeadlineExceeded {
+		t.Errorf("Controller.Shutdown() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+
+	if _, ok := <-c.DirectMessages(); ok {
+		t.Errorf("Controller.DirectMessages() was not closed")
+	}
+
+	// handling after Shutdown drops payloads instead of sending on closed channels
+	if err := c.handleMessage([]byte(`{"type":"message","channel":"D1","user":"U1","text":"hello","ts":"1.2"}`), "T123"); err != nil {
+		t.Errorf("Controller.handleMessage() after Shutdown() error = %v", err)
+	}
+}
@@ -10,11 +10,26 @@ var (
 	ErrInvalidConnector           = errors.New("Invalid Connector")
 	ErrInvalidBotStorage          = errors.New("Invalid Bot Storage")
 	ErrInvalidConversationStorage = errors.New("Invalid Conversation Storage")
+	ErrInvalidUserStorage         = errors.New("Invalid User Storage")
+	ErrInvalidStateStorage        = errors.New("Invalid State Storage")
+	ErrInvalidSeenStorage         = errors.New("Invalid Seen Storage")
+	ErrInvalidOAuthErrorHandler   = errors.New("Invalid OAuth Error Handler")
+	ErrInvalidStartErrorHandler   = errors.New("Invalid Start Error Handler")
+	ErrInvalidEventErrorHandler   = errors.New("Invalid Event Error Handler")
+	ErrInvalidEventWorkers        = errors.New("Invalid Event Workers")
+	ErrInvalidBotMessagePolicy    = errors.New("Invalid Bot Message Policy")
+	ErrInvalidRepeatLimit         = errors.New("Invalid Repeat Limit")
+
+	ErrInvalidState = errors.New("Invalid OAuth State")
+	ErrStateExpired = errors.New("OAuth State Expired")
+	ErrOAuthDenied  = errors.New("OAuth Access Denied")
 
 	ErrConversationExists        = errors.New("Conversation Already Exists")
 	ErrConversationNotFound      = errors.New("Conversation Not Found")
 	ErrConversationAlreadyActive = errors.New("Conversation Already Active")
 	ErrNoStartState              = errors.New("Conversation Has no start state")
+	ErrStateChanged              = errors.New("Conversation State Changed")
+	ErrStateNotFound             = errors.New("Conversation State Not Found")
 
 	ErrInvalidMessage = errors.New("invalid message")
 	ErrChannelUnset   = errors.New("channel is not set")
@@ -26,7 +41,22 @@ var (
 
 	ErrStateAlreadyExists = errors.New("State Already Defined")
 
+	ErrUnfurlHandlerExists   = errors.New("Unfurl Handler Already Exists")
+	ErrUnfurlHandlerNotFound = errors.New("Unfurl Handler Not Found")
+
+	ErrRTMEventHandlerExists = errors.New("RTM Event Handler Already Exists")
+
+	ErrControllerShutdown = errors.New("Controller Already Shut Down")
+
 	ErrBotNotFound     = errors.New("Bot Not Found")
 	ErrBotAlreadyAdded = errors.New("Bot Already Added")
 	ErrItemNotFound    = errors.New("Item Not Found")
+	ErrUserNotFound    = errors.New("User Not Found")
+
+	ErrNoRefreshToken = errors.New("Bot token has expired and has no refresh token")
+
+	ErrInvalidKey        = errors.New("Invalid Encryption Key, must be 16, 24 or 32 bytes")
+	ErrInvalidKeyID      = errors.New("Invalid Encryption Key ID")
+	ErrKeyNotFound       = errors.New("Encryption Key Not Found")
+	ErrInvalidCiphertext = errors.New("Invalid Encrypted Token")
 )
@@ -1,26 +1,133 @@
 package slack
 
-import "suy.io/bots/slack/api/oauth"
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"suy.io/bots/slack/api/oauth"
+	"suy.io/bots/slack/api/users"
+)
 
 // BotStore is an interface used to store bot data.
+//
+// Bots are stored under the key returned by BotKey, which is the team ID
+// for installs outside of an Enterprise Grid org.
 type BotStore interface {
 	// AddBot adds a new bot
 	AddBot(*oauth.AccessResponse) error
 
-	// GetBot gets the bot payload for a team id
+	// GetBot gets the bot payload for a key,
+	// returning ErrBotNotFound if there is none
 	GetBot(string) (*oauth.AccessResponse, error)
 
-	// RemoveBot removes a bot given the team ID
+	// RemoveBot removes a bot given its key
 	RemoveBot(string) error
 
 	// AllBots gets all the bots stored ever
 	AllBots() ([]*oauth.AccessResponse, error)
 }
 
-// MemoryBotStore is an in-memory BotStore implementation.
+// BotKey gets the key a bot is stored under in a BotStore.
+//
+// Workspace installs are keyed by team ID, workspace installs in an Enterprise Grid org
+// by enterprise and team ID, and org-wide installs by enterprise ID.
+func BotKey(p *oauth.AccessResponse) string {
+	if p.IsEnterpriseInstall {
+		return p.EnterpriseID
+	}
+
+	return installKey(p.EnterpriseID, p.TeamID)
+}
+
+func installKey(enterprise, team string) string {
+	if enterprise == "" {
+		return team
+	}
+
+	if team == "" {
+		return enterprise
+	}
+
+	return enterprise + "/" + team
+}
+
+// FindBot gets the bot installed for a team in an (optional) enterprise.
+//
+// If there is no install for the workspace in the enterprise, it falls back to
+// a bot stored by team ID alone, and then to an org-wide install for the enterprise.
+func FindBot(bs BotStore, enterprise, team string) (*oauth.AccessResponse, error) {
+	keys := []string{installKey(enterprise, team)}
+	if enterprise != "" {
+		keys = append(keys, team, enterprise)
+	}
+
+	for _, k := range keys {
+		if k == "" {
+			continue
+		}
+
+		p, err := bs.GetBot(k)
+		if err == nil {
+			return p, nil
+		}
+
+		if errors.Cause(err) != ErrBotNotFound {
+			return nil, err
+		}
+	}
+
+	return nil, ErrBotNotFound
+}
+
+// UpdateBotStore is implemented by BotStores that can replace a stored bot as a single atomic operation.
+type UpdateBotStore interface {
+	BotStore
+
+	// UpdateBot replaces the stored bot with the same key,
+	// returning ErrBotNotFound if there is none
+	UpdateBot(*oauth.AccessResponse) error
+}
+
+// UpdateBot replaces the bot stored in bs under the key of p, returning ErrBotNotFound if there is none.
+//
+// If bs does not implement UpdateBotStore, the stored bot is removed and p added,
+// and the removed bot is added back if adding p fails.
+func UpdateBot(bs BotStore, p *oauth.AccessResponse) error {
+	if s, ok := bs.(UpdateBotStore); ok {
+		return s.UpdateBot(p)
+	}
+
+	k := BotKey(p)
+
+	old, err := bs.GetBot(k)
+	if err != nil {
+		return err
+	}
+
+	if err := bs.RemoveBot(k); err != nil {
+		return err
+	}
+
+	if err := bs.AddBot(p); err != nil {
+		if errors.Cause(err) != ErrBotAlreadyAdded {
+			bs.AddBot(old)
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// MemoryBotStore is an in-memory BotStore implementation, safe for concurrent use.
 //
 // ffjson: skip
 type MemoryBotStore struct {
+	mu   sync.RWMutex
 	bots map[string]*oauth.AccessResponse
 }
 
@@ -33,17 +140,24 @@ func NewMemoryBotStore() *MemoryBotStore {
 
 // AddBot adds a new paylaod to the store.
 func (bs *MemoryBotStore) AddBot(p *oauth.AccessResponse) error {
-	if _, ok := bs.bots[p.TeamID]; ok {
+	bs.mu.Lock()
+	defer bs.mu.Unlock()
+
+	k := BotKey(p)
+	if _, ok := bs.bots[k]; ok {
 		return ErrBotAlreadyAdded
 	}
 
-	bs.bots[p.TeamID] = p
+	bs.bots[k] = p
 	return nil
 }
 
-// GetBot gets a stored payload given a team.
-func (bs *MemoryBotStore) GetBot(team string) (*oauth.AccessResponse, error) {
-	b, ok := bs.bots[team]
+// GetBot gets a stored payload given its key.
+func (bs *MemoryBotStore) GetBot(key string) (*oauth.AccessResponse, error) {
+	bs.mu.RLock()
+	defer bs.mu.RUnlock()
+
+	b, ok := bs.bots[key]
 	if !ok {
 		return nil, ErrBotNotFound
 	}
@@ -51,18 +165,38 @@ func (bs *MemoryBotStore) GetBot(team string) (*oauth.AccessResponse, error) {
 	return b, nil
 }
 
-// RemoveBot removes a stored payload given the team.
-func (bs *MemoryBotStore) RemoveBot(team string) error {
-	if _, ok := bs.bots[team]; !ok {
+// UpdateBot replaces a stored payload.
+func (bs *MemoryBotStore) UpdateBot(p *oauth.AccessResponse) error {
+	bs.mu.Lock()
+	defer bs.mu.Unlock()
+
+	k := BotKey(p)
+	if _, ok := bs.bots[k]; !ok {
+		return ErrBotNotFound
+	}
+
+	bs.bots[k] = p
+	return nil
+}
+
+// RemoveBot removes a stored payload given its key.
+func (bs *MemoryBotStore) RemoveBot(key string) error {
+	bs.mu.Lock()
+	defer bs.mu.Unlock()
+
+	if _, ok := bs.bots[key]; !ok {
 		return ErrBotNotFound
 	}
 
-	delete(bs.bots, team)
+	delete(bs.bots, key)
 	return nil
 }
 
 // AllBots gets all stored payloads.
 func (bs *MemoryBotStore) AllBots() ([]*oauth.AccessResponse, error) {
+	bs.mu.RLock()
+	defer bs.mu.RUnlock()
+
 	bots := make([]*oauth.AccessResponse, 0, len(bs.bots))
 	for _, b := range bs.bots {
 		bots = append(bots, b)
@@ -71,7 +205,7 @@ func (bs *MemoryBotStore) AllBots() ([]*oauth.AccessResponse, error) {
 	return bots, nil
 }
 
-var _ BotStore = &MemoryBotStore{}
+var _ UpdateBotStore = &MemoryBotStore{}
 
 // ConversationStore defines the interface for storing conversation data.
 type ConversationStore interface {
@@ -97,47 +231,122 @@ type ConversationStore interface {
 	End(user, channel, team string) error
 }
 
+// StartIfNotActiveStore is implemented by ConversationStores that can check
+// for an active conversation and start a new one as a single atomic operation.
+type StartIfNotActiveStore interface {
+	ConversationStore
+
+	// StartIfNotActive starts a new conversation if there is no active one,
+	// returning false if there was
+	StartIfNotActive(user, channel, team, id string) (bool, error)
+}
+
+// StartIfNotActive starts a conversation in cs if there is no active one, returning false if there was.
+//
+// If cs does not implement StartIfNotActiveStore, it relies on Start failing with
+// ErrConversationExists for an active conversation, which all stores are expected to do.
+func StartIfNotActive(cs ConversationStore, user, channel, team, id string) (bool, error) {
+	if s, ok := cs.(StartIfNotActiveStore); ok {
+		return s.StartIfNotActive(user, channel, team, id)
+	}
+
+	err := cs.Start(user, channel, team, id)
+	if errors.Cause(err) == ErrConversationExists {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// VersionedConversationStore is implemented by ConversationStores that keep a version for the state
+// of a conversation, which changes on every state transition, so transitions can be made with compare-and-set.
+//
+// Versions start at 1 when a conversation is started, 0 is never a valid version.
+type VersionedConversationStore interface {
+	ConversationStore
+
+	// ActiveVersion returns the active conversation id and state, along with the version of the state
+	ActiveVersion(user, channel, team string) (id, state string, version int64, err error)
+
+	// CompareAndSetState sets the state of the active conversation if it is still at version,
+	// returning the new version, or ErrStateChanged if there was a transition since
+	CompareAndSetState(user, channel, team string, version int64, state string) (int64, error)
+}
+
+// ActiveVersion gets the active conversation in cs along with the version of its state,
+// which is 0 if cs does not implement VersionedConversationStore.
+func ActiveVersion(cs ConversationStore, user, channel, team string) (id, state string, version int64, err error) {
+	if vs, ok := cs.(VersionedConversationStore); ok {
+		return vs.ActiveVersion(user, channel, team)
+	}
+
+	id, state, err = cs.Active(user, channel, team)
+	return
+}
+
 type convdata struct {
 	id, state string
+	version   int64
 }
 
-// MemoryConversationStore is an in-memory implementation of ConversationStore.
+// MemoryConversationStore is an in-memory implementation of ConversationStore, safe for concurrent use.
 //
 // ffjson: skip
 type MemoryConversationStore struct {
+	mu     sync.RWMutex
 	active map[string]*convdata
 	data   map[string]map[string]string
 }
 
 // NewMemoryConversationStore creates a new MemoryConversationStore object.
 func NewMemoryConversationStore() *MemoryConversationStore {
-	return &MemoryConversationStore{make(map[string]*convdata), make(map[string]map[string]string)}
+	return &MemoryConversationStore{active: make(map[string]*convdata), data: make(map[string]map[string]string)}
 }
 
 // Start starts a conversation
 func (s *MemoryConversationStore) Start(user, channel, team, id string) error {
+	ok, _ := s.StartIfNotActive(user, channel, team, id)
+	if !ok {
+		return ErrConversationExists
+	}
+
+	return nil
+}
+
+// StartIfNotActive starts a conversation if there is no active one.
+func (s *MemoryConversationStore) StartIfNotActive(user, channel, team, id string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	i := user + "_" + channel + "_" + team
 	if _, ok := s.active[i]; ok {
-		return ErrConversationExists
+		return false, nil
 	}
 
-	s.active[i] = &convdata{id, "start"}
+	s.active[i] = &convdata{id, "start", 1}
 	s.data[i] = make(map[string]string)
-	return nil
+	return true, nil
 }
 
 // IsActive checks if a conversation is active.
 func (s *MemoryConversationStore) IsActive(user, channel, team string) bool {
-	i := user + "_" + channel + "_" + team
-	_, ok := s.active[i]
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	_, ok := s.active[user+"_"+channel+"_"+team]
 	return ok
 }
 
 // Active gets the active conversation.
 func (s *MemoryConversationStore) Active(user, channel, team string) (id, state string, err error) {
-	i := user + "_" + channel + "_" + team
-	c, ok := s.active[i]
+	s.mu.RLock()
+	defer s.mu.RUnlock()
 
+	c, ok := s.active[user+"_"+channel+"_"+team]
 	if !ok {
 		err = ErrConversationNotFound
 		return
@@ -149,22 +358,59 @@ func (s *MemoryConversationStore) Active(user, channel, team string) (id, state
 
 // SetState sets the state for the active conversation.
 func (s *MemoryConversationStore) SetState(user, channel, team, state string) error {
-	i := user + "_" + channel + "_" + team
-	c, ok := s.active[i]
+	s.mu.Lock()
+	defer s.mu.Unlock()
 
+	c, ok := s.active[user+"_"+channel+"_"+team]
 	if !ok {
 		return ErrConversationNotFound
 	}
 
 	c.state = state
+	c.version++
 	return nil
 }
 
+// ActiveVersion gets the active conversation with the version of its state.
+func (s *MemoryConversationStore) ActiveVersion(user, channel, team string) (id, state string, version int64, err error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	c, ok := s.active[user+"_"+channel+"_"+team]
+	if !ok {
+		err = ErrConversationNotFound
+		return
+	}
+
+	id, state, version = c.id, c.state, c.version
+	return
+}
+
+// CompareAndSetState sets the state for the active conversation if it is still at version.
+func (s *MemoryConversationStore) CompareAndSetState(user, channel, team string, version int64, state string) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, ok := s.active[user+"_"+channel+"_"+team]
+	if !ok {
+		return 0, ErrConversationNotFound
+	}
+
+	if c.version != version {
+		return 0, ErrStateChanged
+	}
+
+	c.state = state
+	c.version++
+	return c.version, nil
+}
+
 // SetData sets the value for a key in the current conversation.
 func (s *MemoryConversationStore) SetData(user, channel, team, key, value string) error {
-	i := user + "_" + channel + "_" + team
-	d, ok := s.data[i]
+	s.mu.Lock()
+	defer s.mu.Unlock()
 
+	d, ok := s.data[user+"_"+channel+"_"+team]
 	if !ok {
 		return ErrConversationNotFound
 	}
@@ -175,9 +421,10 @@ func (s *MemoryConversationStore) SetData(user, channel, team, key, value string
 
 // GetData gets the stored value for a key for a conversation.
 func (s *MemoryConversationStore) GetData(user, channel, team, key string) (string, error) {
-	i := user + "_" + channel + "_" + team
-	d, ok := s.data[i]
+	s.mu.RLock()
+	defer s.mu.RUnlock()
 
+	d, ok := s.data[user+"_"+channel+"_"+team]
 	if !ok {
 		return "", ErrConversationNotFound
 	}
@@ -192,6 +439,9 @@ func (s *MemoryConversationStore) GetData(user, channel, team, key string) (stri
 
 // End ends the conversation.
 func (s *MemoryConversationStore) End(user, channel, team string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	i := user + "_" + channel + "_" + team
 	if _, ok := s.active[i]; !ok {
 		return ErrConversationNotFound
@@ -199,8 +449,233 @@ func (s *MemoryConversationStore) End(user, channel, team string) error {
 
 	delete(s.active, i)
 	delete(s.data, i)
+	return nil
+}
+
+var (
+	_ StartIfNotActiveStore      = &MemoryConversationStore{}
+	_ VersionedConversationStore = &MemoryConversationStore{}
+)
+
+// DefaultUserCacheTTL is the duration for which a MemoryUserStore created by
+// the Controller keeps a user before fetching it again.
+const DefaultUserCacheTTL = 1 * time.Hour
+
+// UserStore defines the interface for caching user data for a team.
+type UserStore interface {
+	// GetUser gets a cached user, returns ErrUserNotFound if the user isn't cached
+	GetUser(team, id string) (*users.User, error)
+
+	// SetUser caches a user for a team
+	SetUser(team string, user *users.User) error
+
+	// RemoveUser invalidates a cached user
+	RemoveUser(team, id string) error
+}
+
+type cacheduser struct {
+	user    *users.User
+	expires time.Time
+}
+
+// MemoryUserStore is an in-memory implementation of UserStore
+// that expires users after a fixed duration.
+//
+// ffjson: skip
+type MemoryUserStore struct {
+	ttl    time.Duration
+	mu     sync.RWMutex
+	users  map[string]*cacheduser
+	pruned time.Time
+}
+
+// NewMemoryUserStore creates a new MemoryUserStore object that keeps users for ttl.
+func NewMemoryUserStore(ttl time.Duration) *MemoryUserStore {
+	return &MemoryUserStore{ttl: ttl, users: make(map[string]*cacheduser)}
+}
+
+// GetUser gets a cached user if it hasn't expired.
+func (s *MemoryUserStore) GetUser(team, id string) (*users.User, error) {
+	s.mu.RLock()
+	u, ok := s.users[team+"_"+id]
+	s.mu.RUnlock()
+
+	if !ok || time.Now().After(u.expires) {
+		return nil, ErrUserNotFound
+	}
+
+	return u.user, nil
+}
+
+// SetUser caches a user.
+func (s *MemoryUserStore) SetUser(team string, user *users.User) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+
+	// drop expired users once in a while, instead of on every call
+	if now.Sub(s.pruned) > s.ttl {
+		for k, u := range s.users {
+			if now.After(u.expires) {
+				delete(s.users, k)
+			}
+		}
 
+		s.pruned = now
+	}
+
+	s.users[team+"_"+user.ID] = &cacheduser{user, now.Add(s.ttl)}
+	return nil
+}
+
+// RemoveUser removes a cached user.
+func (s *MemoryUserStore) RemoveUser(team, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.users, team+"_"+id)
 	return nil
 }
 
-var _ ConversationStore = &MemoryConversationStore{}
+var _ UserStore = &MemoryUserStore{}
+
+// DefaultStateTTL is how long an OAuth state issued by the default StateStore stays valid.
+const DefaultStateTTL = 10 * time.Minute
+
+// StateStore issues and verifies the state values passed through slack's OAuth flow,
+// to protect the OAuth redirect from CSRF.
+//
+// States must be random, expire, and only verify once.
+type StateStore interface {
+	// NewState issues a new state value
+	NewState() (string, error)
+
+	// VerifyState checks that a state was issued by the store and consumes it
+	VerifyState(state string) error
+}
+
+// MemoryStateStore is an in-memory implementation of StateStore.
+//
+// ffjson: skip
+type MemoryStateStore struct {
+	ttl    time.Duration
+	mu     sync.Mutex
+	states map[string]time.Time
+}
+
+// NewMemoryStateStore creates a new MemoryStateStore object that keeps states valid for ttl.
+func NewMemoryStateStore(ttl time.Duration) *MemoryStateStore {
+	return &MemoryStateStore{ttl: ttl, states: make(map[string]time.Time)}
+}
+
+// NewState creates a new random state.
+func (s *MemoryStateStore) NewState() (string, error) {
+	state, err := RandomState()
+	if err != nil {
+		return "", err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// drop expired states so abandoned installs don't pile up
+	now := time.Now()
+	for k, exp := range s.states {
+		if now.After(exp) {
+			delete(s.states, k)
+		}
+	}
+
+	s.states[state] = now.Add(s.ttl)
+	return state, nil
+}
+
+// VerifyState checks and removes a state.
+func (s *MemoryStateStore) VerifyState(state string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	exp, ok := s.states[state]
+	if !ok {
+		return ErrInvalidState
+	}
+
+	delete(s.states, state)
+
+	if time.Now().After(exp) {
+		return ErrStateExpired
+	}
+
+	return nil
+}
+
+var _ StateStore = &MemoryStateStore{}
+
+// RandomState generates a random, url safe state value.
+//
+// It can be used by StateStore implementations to generate states.
+func RandomState() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
+
+// DefaultSeenTTL is how long the default SeenStore remembers an event,
+// slack stops retrying an event well within it.
+const DefaultSeenTTL = 1 * time.Hour
+
+// SeenStore remembers the ids of the events and messages that were handled,
+// so the Controller can drop events slack retries and messages delivered more than once.
+type SeenStore interface {
+	// Seen marks an id as seen, returning true if it was already seen.
+	// Checking and marking must be atomic, so only one of concurrent calls for an id returns false.
+	Seen(id string) (bool, error)
+}
+
+// MemorySeenStore is an in-memory implementation of SeenStore
+// that forgets ids after a fixed duration.
+//
+// ffjson: skip
+type MemorySeenStore struct {
+	ttl    time.Duration
+	mu     sync.Mutex
+	ids    map[string]time.Time
+	pruned time.Time
+}
+
+// NewMemorySeenStore creates a new MemorySeenStore object that remembers ids for ttl.
+func NewMemorySeenStore(ttl time.Duration) *MemorySeenStore {
+	return &MemorySeenStore{ttl: ttl, ids: make(map[string]time.Time)}
+}
+
+// Seen marks an id as seen.
+func (s *MemorySeenStore) Seen(id string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+
+	// drop expired ids once in a while, instead of on every event
+	if now.Sub(s.pruned) > s.ttl {
+		for k, exp := range s.ids {
+			if now.After(exp) {
+				delete(s.ids, k)
+			}
+		}
+
+		s.pruned = now
+	}
+
+	if exp, ok := s.ids[id]; ok && !now.After(exp) {
+		return true, nil
+	}
+
+	s.ids[id] = now.Add(s.ttl)
+	return false, nil
+}
+
+var _ SeenStore = &MemorySeenStore{}
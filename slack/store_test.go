@@ -1,10 +1,13 @@
 package slack
 
 import (
+	"errors"
 	"reflect"
 	"testing"
+	"time"
 
 	"suy.io/bots/slack/api/oauth"
+	"suy.io/bots/slack/api/users"
 )
 
 func TestNewMemoryBotStore(t *testing.T) {
@@ -12,7 +15,7 @@ func TestNewMemoryBotStore(t *testing.T) {
 		name string
 		want *MemoryBotStore
 	}{
-		{"", &MemoryBotStore{make(map[string]*oauth.AccessResponse)}},
+		{"", &MemoryBotStore{bots: make(map[string]*oauth.AccessResponse)}},
 	}
 
 	for _, tt := range tests {
@@ -50,6 +53,63 @@ func TestMemoryBotStore_AddBot(t *testing.T) {
 	}
 }
 
+// botStoreOnly hides the UpdateBot method of a store.
+type botStoreOnly struct {
+	BotStore
+}
+
+// failingAddBotStore fails the first AddBot call.
+type failingAddBotStore struct {
+	BotStore
+	failed bool
+}
+
+func (bs *failingAddBotStore) AddBot(p *oauth.AccessResponse) error {
+	if !bs.failed {
+		bs.failed = true
+		return errors.New("add failed")
+	}
+
+	return bs.BotStore.AddBot(p)
+}
+
+func TestUpdateBot(t *testing.T) {
+	old := &oauth.AccessResponse{TeamID: "T1", Bot: &oauth.Bot{BotAccessToken: "xoxb-old"}}
+	p := &oauth.AccessResponse{TeamID: "T1", Bot: &oauth.Bot{BotAccessToken: "xoxb-new"}}
+
+	tests := []struct {
+		name      string
+		wrap      func(BotStore) BotStore
+		add       bool
+		wantToken string
+		wantErr   error
+	}{
+		{"atomic", func(bs BotStore) BotStore { return bs }, true, "xoxb-new", nil},
+		{"atomic missing", func(bs BotStore) BotStore { return bs }, false, "", ErrBotNotFound},
+		{"fallback", func(bs BotStore) BotStore { return botStoreOnly{bs} }, true, "xoxb-new", nil},
+		{"fallback missing", func(bs BotStore) BotStore { return botStoreOnly{bs} }, false, "", ErrBotNotFound},
+		{"fallback restores", func(bs BotStore) BotStore { return &failingAddBotStore{BotStore: bs} }, true, "xoxb-old", errors.New("add failed")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inner := NewMemoryBotStore()
+			if tt.add {
+				inner.AddBot(old)
+			}
+
+			err := UpdateBot(tt.wrap(inner), p)
+			if (err == nil) != (tt.wantErr == nil) || err != nil && err.Error() != tt.wantErr.Error() {
+				t.Errorf("UpdateBot() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if got, _ := inner.GetBot("T1"); tt.wantToken != "" && (got == nil || got.Bot.BotAccessToken != tt.wantToken) {
+				t.Errorf("UpdateBot() stored %+v, want token %v", got, tt.wantToken)
+			}
+		})
+	}
+}
+
 func TestMemoryBotStore_GetBot(t *testing.T) {
 	type args struct {
 		team string
@@ -149,6 +209,71 @@ func TestMemoryBotStore_AllBots(t *testing.T) {
 	}
 }
 
+func TestBotKey(t *testing.T) {
+	tests := []struct {
+		name string
+		p    *oauth.AccessResponse
+		want string
+	}{
+		{"", &oauth.AccessResponse{TeamID: "T1234567"}, "T1234567"},
+		{"", &oauth.AccessResponse{TeamID: "T1234567", EnterpriseID: "E1234567"}, "E1234567/T1234567"},
+		{"", &oauth.AccessResponse{TeamID: "E1234567", EnterpriseID: "E1234567", IsEnterpriseInstall: true}, "E1234567"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := BotKey(tt.p); got != tt.want {
+				t.Errorf("BotKey() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFindBot(t *testing.T) {
+	type args struct {
+		enterprise string
+		team       string
+	}
+
+	workspace := &oauth.AccessResponse{TeamID: "T1111111"}
+	grid := &oauth.AccessResponse{TeamID: "T2222222", EnterpriseID: "E1111111"}
+	org := &oauth.AccessResponse{TeamID: "E2222222", EnterpriseID: "E2222222", IsEnterpriseInstall: true}
+
+	bs := NewMemoryBotStore()
+	bs.AddBot(workspace)
+	bs.AddBot(grid)
+	bs.AddBot(org)
+
+	tests := []struct {
+		name    string
+		args    args
+		want    *oauth.AccessResponse
+		wantErr bool
+	}{
+		{"", args{"", "T1111111"}, workspace, false},
+		{"", args{"E1111111", "T2222222"}, grid, false},
+		{"", args{"", "T2222222"}, nil, true},
+		{"", args{"E2222222", "T3333333"}, org, false},
+		{"", args{"E3333333", "T1111111"}, workspace, false},
+		{"", args{"E3333333", "T3333333"}, nil, true},
+		{"", args{"", ""}, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FindBot(bs, tt.args.enterprise, tt.args.team)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("FindBot() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if got != tt.want {
+				t.Errorf("FindBot() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestNewMemoryConversationStore(t *testing.T) {
 	tests := []struct {
 		name string
@@ -183,6 +308,7 @@ func TestMemoryConversationStore_Start(t *testing.T) {
 		wantErr bool
 	}{
 		{"", s, args{"U1234567", "C1234567", "T1234567", "test"}, false},
+		{"", s, args{"U1234567", "C1234567", "T1234567", "test"}, true},
 	}
 
 	for _, tt := range tests {
@@ -194,6 +320,50 @@ func TestMemoryConversationStore_Start(t *testing.T) {
 	}
 }
 
+// startOnlyStore hides StartIfNotActive, to test the fallback to Start.
+type startOnlyStore struct {
+	ConversationStore
+}
+
+func TestStartIfNotActive(t *testing.T) {
+	type args struct {
+		user    string
+		channel string
+		team    string
+		id      string
+	}
+
+	s, fallback := NewMemoryConversationStore(), &startOnlyStore{NewMemoryConversationStore()}
+
+	tests := []struct {
+		name    string
+		cs      ConversationStore
+		args    args
+		want    bool
+		wantErr bool
+	}{
+		{"", s, args{"U1234567", "C1234567", "T1234567", "test"}, true, false},
+		{"", s, args{"U1234567", "C1234567", "T1234567", "test"}, false, false},
+		{"", s, args{"U1234567", "C7654321", "T1234567", "test"}, true, false},
+		{"", fallback, args{"U1234567", "C1234567", "T1234567", "test"}, true, false},
+		{"", fallback, args{"U1234567", "C1234567", "T1234567", "test"}, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := StartIfNotActive(tt.cs, tt.args.user, tt.args.channel, tt.args.team, tt.args.id)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("StartIfNotActive() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if got != tt.want {
+				t.Errorf("StartIfNotActive() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestMemoryConversationStore_IsActive(t *testing.T) {
 	type args struct {
 		user    string
@@ -393,3 +563,187 @@ func TestMemoryConversationStore_End(t *testing.T) {
 		})
 	}
 }
+
+func TestNewMemoryUserStore(t *testing.T) {
+	tests := []struct {
+		name string
+		ttl  time.Duration
+		want *MemoryUserStore
+	}{
+		{"", time.Minute, &MemoryUserStore{ttl: time.Minute, users: make(map[string]*cacheduser)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewMemoryUserStore(tt.ttl); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewMemoryUserStore() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMemoryUserStore_GetUser(t *testing.T) {
+	type args struct {
+		team string
+		id   string
+	}
+
+	s := NewMemoryUserStore(time.Minute)
+	s.SetUser("T1234567", &users.User{ID: "U1234567", Name: "bob"})
+
+	expired := NewMemoryUserStore(-time.Minute)
+	expired.SetUser("T1234567", &users.User{ID: "U1234567", Name: "bob"})
+
+	tests := []struct {
+		name    string
+		s       *MemoryUserStore
+		args    args
+		want    *users.User
+		wantErr bool
+	}{
+		{"", s, args{"T1234567", "U1234567"}, &users.User{ID: "U1234567", Name: "bob"}, false},
+		{"", s, args{"T1234568", "U1234567"}, nil, true},
+		{"", expired, args{"T1234567", "U1234567"}, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.s.GetUser(tt.args.team, tt.args.id)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MemoryUserStore.GetUser() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("MemoryUserStore.GetUser() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMemoryUserStore_SetUser(t *testing.T) {
+	s := NewMemoryUserStore(-time.Minute)
+	s.SetUser("T1234567", &users.User{ID: "U1234567"})
+	s.SetUser("T1234567", &users.User{ID: "U7654321"})
+
+	// the first user expired before the second was set
+	if _, ok := s.users["T1234567_U1234567"]; ok || len(s.users) != 1 {
+		t.Errorf("MemoryUserStore.SetUser() kept %v users, want expired users dropped", len(s.users))
+	}
+}
+
+func TestMemoryUserStore_RemoveUser(t *testing.T) {
+	type args struct {
+		team string
+		id   string
+	}
+
+	s := NewMemoryUserStore(time.Minute)
+	s.SetUser("T1234567", &users.User{ID: "U1234567"})
+
+	tests := []struct {
+		name string
+		s    *MemoryUserStore
+		args args
+	}{
+		{"", s, args{"T1234567", "U1234567"}},
+		{"", s, args{"T1234567", "U7654321"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.s.RemoveUser(tt.args.team, tt.args.id); err != nil {
+				t.Errorf("MemoryUserStore.RemoveUser() error = %v", err)
+			}
+
+			if _, err := tt.s.GetUser(tt.args.team, tt.args.id); err != ErrUserNotFound {
+				t.Errorf("MemoryUserStore.RemoveUser() did not remove user")
+			}
+		})
+	}
+}
+
+func TestMemoryStateStore_VerifyState(t *testing.T) {
+	s := NewMemoryStateStore(time.Minute)
+
+	state, err := s.NewState()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expired := NewMemoryStateStore(-time.Minute)
+
+	expiredState, err := expired.NewState()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		s       *MemoryStateStore
+		state   string
+		wantErr error
+	}{
+		{"", s, "", ErrInvalidState},
+		{"", s, "bob-lob-law", ErrInvalidState},
+		{"", s, state, nil},
+		{"", s, state, ErrInvalidState},
+		{"", expired, expiredState, ErrStateExpired},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.s.VerifyState(tt.state); err != tt.wantErr {
+				t.Errorf("MemoryStateStore.VerifyState() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRandomState(t *testing.T) {
+	a, err := RandomState()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := RandomState()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(a) != 32 || a == b {
+		t.Errorf("RandomState() = %v, %v, want distinct 32 character states", a, b)
+	}
+}
+
+func TestMemorySeenStore_Seen(t *testing.T) {
+	s := NewMemorySeenStore(time.Hour)
+	expired := NewMemorySeenStore(-time.Second)
+
+	tests := []struct {
+		name string
+		s    *MemorySeenStore
+		id   string
+		want bool
+	}{
+		{"", s, "Ev1", false},
+		{"", s, "Ev1", true},
+		{"", s, "Ev2", false},
+		{"", s, "Ev1", true},
+		{"", expired, "Ev1", false},
+		{"", expired, "Ev1", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.s.Seen(tt.id)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got != tt.want {
+				t.Errorf("MemorySeenStore.Seen() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
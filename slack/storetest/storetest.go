@@ -0,0 +1,544 @@
+// Package storetest provides conformance tests for implementations of the slack store interfaces.
+//
+// A store implementation can be checked from its own tests with
+//
+//	func TestMyBotStore(t *testing.T) {
+//		storetest.TestBotStore(t, func() slack.BotStore { return NewMyBotStore() })
+//	}
+//
+// Every call to the passed function has to return an empty store.
+package storetest // import "suy.io/bots/slack/storetest"
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/pkg/errors"
+
+	"suy.io/bots/slack"
+	"suy.io/bots/slack/api/oauth"
+)
+
+// Concurrency is the number of goroutines used by the concurrency tests.
+const Concurrency = 32
+
+func bot(enterprise, team string, orgWide bool) *oauth.AccessResponse {
+	return &oauth.AccessResponse{
+		AccessToken:         "xoxp-" + team,
+		Scope:               "bot",
+		TeamID:              team,
+		TeamName:            "Team " + team,
+		EnterpriseID:        enterprise,
+		IsEnterpriseInstall: orgWide,
+		Bot:                 &oauth.Bot{BotUserID: "U" + team, BotAccessToken: "xoxb-" + team},
+	}
+}
+
+func wantErr(t *testing.T, method string, err, want error) {
+	t.Helper()
+
+	if errors.Cause(err) != want {
+		t.Errorf("%s error = %v, want %v", method, err, want)
+	}
+}
+
+// TestBotStore runs the behavioral tests for a slack.BotStore.
+func TestBotStore(t *testing.T, newStore func() slack.BotStore) {
+	t.Run("AddGet", func(t *testing.T) {
+		bs := newStore()
+
+		bots := []*oauth.AccessResponse{bot("", "T1", false), bot("E1", "T2", false), bot("E2", "", true)}
+		for _, p := range bots {
+			if err := bs.AddBot(p); err != nil {
+				t.Fatalf("AddBot() error = %v", err)
+			}
+		}
+
+		for _, p := range bots {
+			got, err := bs.GetBot(slack.BotKey(p))
+			if err != nil {
+				t.Errorf("GetBot(%v) error = %v", slack.BotKey(p), err)
+				continue
+			}
+
+			if !reflect.DeepEqual(got, p) {
+				t.Errorf("GetBot(%v) = %+v, want %+v", slack.BotKey(p), got, p)
+			}
+		}
+
+		_, err := bs.GetBot("T2")
+		wantErr(t, "GetBot() of a team only installed in an enterprise", err, slack.ErrBotNotFound)
+	})
+
+	t.Run("AddDuplicate", func(t *testing.T) {
+		bs := newStore()
+
+		if err := bs.AddBot(bot("", "T1", false)); err != nil {
+			t.Fatalf("AddBot() error = %v", err)
+		}
+
+		wantErr(t, "AddBot() of an added bot", bs.AddBot(bot("", "T1", false)), slack.ErrBotAlreadyAdded)
+	})
+
+	t.Run("GetMissing", func(t *testing.T) {
+		_, err := newStore().GetBot("T1")
+		wantErr(t, "GetBot()", err, slack.ErrBotNotFound)
+	})
+
+	t.Run("Remove", func(t *testing.T) {
+		bs := newStore()
+		bs.AddBot(bot("", "T1", false))
+		bs.AddBot(bot("", "T2", false))
+
+		if err := bs.RemoveBot("T1"); err != nil {
+			t.Fatalf("RemoveBot() error = %v", err)
+		}
+
+		_, err := bs.GetBot("T1")
+		wantErr(t, "GetBot() of a removed bot", err, slack.ErrBotNotFound)
+		wantErr(t, "RemoveBot() of a removed bot", bs.RemoveBot("T1"), slack.ErrBotNotFound)
+
+		if _, err := bs.GetBot("T2"); err != nil {
+			t.Errorf("GetBot() of a bot that was not removed error = %v", err)
+		}
+
+		if err := bs.AddBot(bot("", "T1", false)); err != nil {
+			t.Errorf("AddBot() of a removed bot error = %v", err)
+		}
+	})
+
+	t.Run("AllBots", func(t *testing.T) {
+		bs := newStore()
+
+		all, err := bs.AllBots()
+		if err != nil || len(all) != 0 {
+			t.Fatalf("AllBots() of an empty store = %v, %v", all, err)
+		}
+
+		bs.AddBot(bot("", "T1", false))
+		bs.AddBot(bot("E1", "T2", false))
+		bs.AddBot(bot("", "T3", false))
+		bs.RemoveBot("T3")
+
+		all, err = bs.AllBots()
+		if err != nil {
+			t.Fatalf("AllBots() error = %v", err)
+		}
+
+		if got, want := botKeys(all), []string{"E1/T2", "T1"}; !reflect.DeepEqual(got, want) {
+			t.Errorf("AllBots() = %v, want %v", got, want)
+		}
+	})
+}
+
+func botKeys(bots []*oauth.AccessResponse) []string {
+	ans := make([]string, 0, len(bots))
+	for _, p := range bots {
+		ans = append(ans, slack.BotKey(p))
+	}
+
+	sort.Strings(ans)
+	return ans
+}
+
+// TestBotStoreConcurrent runs the concurrency tests for a slack.BotStore,
+// that is expected to be safe for use by multiple goroutines.
+func TestBotStoreConcurrent(t *testing.T, newStore func() slack.BotStore) {
+	t.Run("AddDistinct", func(t *testing.T) {
+		bs := newStore()
+
+		var wg sync.WaitGroup
+		for i := 0; i < Concurrency; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+
+				team := fmt.Sprintf("T%d", i)
+				if err := bs.AddBot(bot("", team, false)); err != nil {
+					t.Errorf("AddBot() error = %v", err)
+				}
+
+				if _, err := bs.GetBot(team); err != nil {
+					t.Errorf("GetBot() error = %v", err)
+				}
+
+				bs.AllBots()
+			}(i)
+		}
+
+		wg.Wait()
+
+		if all, err := bs.AllBots(); err != nil || len(all) != Concurrency {
+			t.Errorf("AllBots() got %v bots, %v, want %v", len(all), err, Concurrency)
+		}
+	})
+
+	t.Run("AddSame", func(t *testing.T) {
+		bs := newStore()
+
+		n := countSuccesses(func() error { return bs.AddBot(bot("", "T1", false)) })
+		if n != 1 {
+			t.Errorf("concurrent AddBot() of the same bot succeeded %v times, want 1", n)
+		}
+	})
+
+	t.Run("RemoveSame", func(t *testing.T) {
+		bs := newStore()
+		bs.AddBot(bot("", "T1", false))
+
+		n := countSuccesses(func() error { return bs.RemoveBot("T1") })
+		if n != 1 {
+			t.Errorf("concurrent RemoveBot() of the same bot succeeded %v times, want 1", n)
+		}
+	})
+}
+
+// countSuccesses runs f from Concurrency goroutines at once, returning how many calls succeeded.
+func countSuccesses(f func() error) int {
+	var (
+		wg    sync.WaitGroup
+		mu    sync.Mutex
+		n     int
+		start = make(chan struct{})
+	)
+
+	for i := 0; i < Concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+
+			if f() == nil {
+				mu.Lock()
+				n++
+				mu.Unlock()
+			}
+		}()
+	}
+
+	close(start)
+	wg.Wait()
+
+	return n
+}
+
+// TestConversationStore runs the behavioral tests for a slack.ConversationStore.
+func TestConversationStore(t *testing.T, newStore func() slack.ConversationStore) {
+	t.Run("Missing", func(t *testing.T) {
+		cs := newStore()
+
+		if cs.IsActive("U1", "C1", "T1") {
+			t.Errorf("IsActive() = true for a conversation that was not started")
+		}
+
+		_, _, err := cs.Active("U1", "C1", "T1")
+		wantErr(t, "Active()", err, slack.ErrConversationNotFound)
+		wantErr(t, "SetState()", cs.SetState("U1", "C1", "T1", "next"), slack.ErrConversationNotFound)
+		wantErr(t, "SetData()", cs.SetData("U1", "C1", "T1", "k", "v"), slack.ErrConversationNotFound)
+
+		_, err = cs.GetData("U1", "C1", "T1", "k")
+		wantErr(t, "GetData()", err, slack.ErrConversationNotFound)
+		wantErr(t, "End()", cs.End("U1", "C1", "T1"), slack.ErrConversationNotFound)
+	})
+
+	t.Run("Lifecycle", func(t *testing.T) {
+		cs := newStore()
+
+		if err := cs.Start("U1", "C1", "T1", "conv"); err != nil {
+			t.Fatalf("Start() error = %v", err)
+		}
+
+		wantErr(t, "Start() of an active conversation", cs.Start("U1", "C1", "T1", "other"), slack.ErrConversationExists)
+
+		if !cs.IsActive("U1", "C1", "T1") {
+			t.Errorf("IsActive() = false for a started conversation")
+		}
+
+		if id, state, err := cs.Active("U1", "C1", "T1"); err != nil || id != "conv" || state != "start" {
+			t.Errorf("Active() = %v, %v, %v, want conv, start, nil", id, state, err)
+		}
+
+		if err := cs.SetState("U1", "C1", "T1", "next"); err != nil {
+			t.Errorf("SetState() error = %v", err)
+		}
+
+		if _, state, _ := cs.Active("U1", "C1", "T1"); state != "next" {
+			t.Errorf("Active() state = %v, want next", state)
+		}
+
+		if err := cs.End("U1", "C1", "T1"); err != nil {
+			t.Errorf("End() error = %v", err)
+		}
+
+		if cs.IsActive("U1", "C1", "T1") {
+			t.Errorf("IsActive() = true for an ended conversation")
+		}
+
+		if err := cs.Start("U1", "C1", "T1", "again"); err != nil {
+			t.Errorf("Start() of an ended conversation error = %v", err)
+		}
+	})
+
+	t.Run("Data", func(t *testing.T) {
+		cs := newStore()
+		cs.Start("U1", "C1", "T1", "conv")
+
+		_, err := cs.GetData("U1", "C1", "T1", "k")
+		wantErr(t, "GetData() of a key that was not set", err, slack.ErrItemNotFound)
+
+		cs.SetData("U1", "C1", "T1", "k", "v1")
+		if err := cs.SetData("U1", "C1", "T1", "k", "v2"); err != nil {
+			t.Errorf("SetData() error = %v", err)
+		}
+
+		if v, err := cs.GetData("U1", "C1", "T1", "k"); err != nil || v != "v2" {
+			t.Errorf("GetData() = %v, %v, want v2, nil", v, err)
+		}
+
+		cs.End("U1", "C1", "T1")
+		cs.Start("U1", "C1", "T1", "conv")
+
+		_, err = cs.GetData("U1", "C1", "T1", "k")
+		wantErr(t, "GetData() of a key set in an ended conversation", err, slack.ErrItemNotFound)
+	})
+
+	t.Run("Isolation", func(t *testing.T) {
+		cs := newStore()
+
+		keys := [][3]string{{"U1", "C1", "T1"}, {"U2", "C1", "T1"}, {"U1", "C2", "T1"}, {"U1", "C1", "T2"}}
+		for i, k := range keys {
+			if err := cs.Start(k[0], k[1], k[2], fmt.Sprintf("conv%d", i)); err != nil {
+				t.Fatalf("Start(%v) error = %v", k, err)
+			}
+
+			cs.SetData(k[0], k[1], k[2], "k", fmt.Sprintf("v%d", i))
+		}
+
+		cs.End("U1", "C1", "T1")
+
+		for i, k := range keys[1:] {
+			if id, _, err := cs.Active(k[0], k[1], k[2]); err != nil || id != fmt.Sprintf("conv%d", i+1) {
+				t.Errorf("Active(%v) = %v, %v, want conv%d", k, id, err, i+1)
+			}
+
+			if v, err := cs.GetData(k[0], k[1], k[2], "k"); err != nil || v != fmt.Sprintf("v%d", i+1) {
+				t.Errorf("GetData(%v) = %v, %v, want v%d", k, v, err, i+1)
+			}
+		}
+	})
+}
+
+// TestConversationStoreConcurrent runs the concurrency tests for a slack.ConversationStore,
+// that is expected to be safe for use by multiple goroutines.
+func TestConversationStoreConcurrent(t *testing.T, newStore func() slack.ConversationStore) {
+	t.Run("StartSame", func(t *testing.T) {
+		cs := newStore()
+
+		n := countSuccesses(func() error { return cs.Start("U1", "C1", "T1", "conv") })
+		if n != 1 {
+			t.Errorf("concurrent Start() of the same conversation succeeded %v times, want 1", n)
+		}
+	})
+
+	t.Run("Data", func(t *testing.T) {
+		cs := newStore()
+		cs.Start("U1", "C1", "T1", "conv")
+
+		var wg sync.WaitGroup
+		for i := 0; i < Concurrency; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+
+				k := fmt.Sprintf("k%d", i)
+				if err := cs.SetData("U1", "C1", "T1", k, k); err != nil {
+					t.Errorf("SetData() error = %v", err)
+				}
+
+				cs.SetState("U1", "C1", "T1", k)
+				cs.Active("U1", "C1", "T1")
+				cs.GetData("U1", "C1", "T1", k)
+			}(i)
+		}
+
+		wg.Wait()
+
+		for i := 0; i < Concurrency; i++ {
+			k := fmt.Sprintf("k%d", i)
+			if v, err := cs.GetData("U1", "C1", "T1", k); err != nil || v != k {
+				t.Errorf("GetData(%v) = %v, %v, want %v", k, v, err, k)
+			}
+		}
+	})
+
+	t.Run("Conversations", func(t *testing.T) {
+		cs := newStore()
+
+		var wg sync.WaitGroup
+		for i := 0; i < Concurrency; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+
+				user := fmt.Sprintf("U%d", i)
+				if err := cs.Start(user, "C1", "T1", user); err != nil {
+					t.Errorf("Start() error = %v", err)
+				}
+
+				cs.SetData(user, "C1", "T1", "k", user)
+
+				if err := cs.End(user, "C1", "T1"); err != nil {
+					t.Errorf("End() error = %v", err)
+				}
+			}(i)
+		}
+
+		wg.Wait()
+	})
+}
+
+// TestUpdateBotStore runs the tests for replacing bots in a slack.UpdateBotStore.
+func TestUpdateBotStore(t *testing.T, newStore func() slack.UpdateBotStore) {
+	t.Run("Update", func(t *testing.T) {
+		bs := newStore()
+
+		wantErr(t, "UpdateBot() of a missing bot", bs.UpdateBot(bot("", "T1", false)), slack.ErrBotNotFound)
+
+		if _, err := bs.GetBot("T1"); errors.Cause(err) != slack.ErrBotNotFound {
+			t.Errorf("GetBot() after UpdateBot() of a missing bot error = %v, want %v", err, slack.ErrBotNotFound)
+		}
+
+		bs.AddBot(bot("", "T1", false))
+		bs.AddBot(bot("", "T2", false))
+
+		p := bot("", "T1", false)
+		p.Bot.BotAccessToken, p.Bot.RefreshToken, p.Bot.ExpiresAt = "xoxb-rotated", "xoxe-1-rotated", 1600000000
+
+		if err := bs.UpdateBot(p); err != nil {
+			t.Fatalf("UpdateBot() error = %v", err)
+		}
+
+		if got, err := bs.GetBot("T1"); err != nil || !reflect.DeepEqual(got, p) {
+			t.Errorf("GetBot() of an updated bot = %+v, %v, want %+v", got, err, p)
+		}
+
+		if got, err := bs.GetBot("T2"); err != nil || !reflect.DeepEqual(got, bot("", "T2", false)) {
+			t.Errorf("GetBot() of a bot that was not updated = %+v, %v", got, err)
+		}
+	})
+
+	t.Run("Concurrent", func(t *testing.T) {
+		bs := newStore()
+		bs.AddBot(bot("", "T1", false))
+
+		n := countSuccesses(func() error { return bs.UpdateBot(bot("", "T1", false)) })
+		if n != Concurrency {
+			t.Errorf("concurrent UpdateBot() of the same bot succeeded %v times, want %v", n, Concurrency)
+		}
+
+		if _, err := bs.GetBot("T1"); err != nil {
+			t.Errorf("GetBot() after concurrent UpdateBot() error = %v", err)
+		}
+	})
+}
+
+// TestVersionedConversationStore runs the tests for the compare-and-set state transitions
+// of a slack.VersionedConversationStore.
+func TestVersionedConversationStore(t *testing.T, newStore func() slack.VersionedConversationStore) {
+	t.Run("Versions", func(t *testing.T) {
+		cs := newStore()
+
+		_, _, _, err := cs.ActiveVersion("U1", "C1", "T1")
+		wantErr(t, "ActiveVersion()", err, slack.ErrConversationNotFound)
+
+		_, err = cs.CompareAndSetState("U1", "C1", "T1", 1, "next")
+		wantErr(t, "CompareAndSetState()", err, slack.ErrConversationNotFound)
+
+		cs.Start("U1", "C1", "T1", "conv")
+
+		id, state, v1, err := cs.ActiveVersion("U1", "C1", "T1")
+		if err != nil || id != "conv" || state != "start" || v1 != 1 {
+			t.Fatalf("ActiveVersion() = %v, %v, %v, %v, want conv, start, 1, nil", id, state, v1, err)
+		}
+
+		v2, err := cs.CompareAndSetState("U1", "C1", "T1", v1, "next")
+		if err != nil || v2 == v1 {
+			t.Fatalf("CompareAndSetState() = %v, %v, want a new version", v2, err)
+		}
+
+		_, err = cs.CompareAndSetState("U1", "C1", "T1", v1, "other")
+		wantErr(t, "CompareAndSetState() with a stale version", err, slack.ErrStateChanged)
+
+		if _, state, v, _ := cs.ActiveVersion("U1", "C1", "T1"); state != "next" || v != v2 {
+			t.Errorf("ActiveVersion() = %v, %v, want next, %v", state, v, v2)
+		}
+
+		cs.SetState("U1", "C1", "T1", "set")
+
+		_, err = cs.CompareAndSetState("U1", "C1", "T1", v2, "other")
+		wantErr(t, "CompareAndSetState() after SetState()", err, slack.ErrStateChanged)
+
+		cs.End("U1", "C1", "T1")
+		cs.Start("U1", "C1", "T1", "conv")
+
+		if _, _, v, _ := cs.ActiveVersion("U1", "C1", "T1"); v != 1 {
+			t.Errorf("ActiveVersion() of a restarted conversation = %v, want 1", v)
+		}
+	})
+
+	t.Run("Concurrent", func(t *testing.T) {
+		cs := newStore()
+		cs.Start("U1", "C1", "T1", "conv")
+
+		n := countSuccesses(func() error {
+			_, err := cs.CompareAndSetState("U1", "C1", "T1", 1, "next")
+			return err
+		})
+
+		if n != 1 {
+			t.Errorf("concurrent CompareAndSetState() from the same version succeeded %v times, want 1", n)
+		}
+	})
+}
+
+// TestSeenStore runs the behavioral tests for a slack.SeenStore,
+// created with a TTL long enough to not expire during the tests.
+func TestSeenStore(t *testing.T, newStore func() slack.SeenStore) {
+	t.Run("Seen", func(t *testing.T) {
+		ss := newStore()
+
+		for _, c := range []struct {
+			id   string
+			want bool
+		}{{"Ev1", false}, {"Ev1", true}, {"Ev2", false}, {"Ev1", true}, {"Ev2", true}} {
+			got, err := ss.Seen(c.id)
+			if err != nil {
+				t.Fatalf("Seen() error = %v", err)
+			}
+
+			if got != c.want {
+				t.Errorf("Seen(%v) = %v, want %v", c.id, got, c.want)
+			}
+		}
+	})
+
+	t.Run("Concurrent", func(t *testing.T) {
+		ss := newStore()
+
+		n := countSuccesses(func() error {
+			seen, err := ss.Seen("Ev1")
+			if err == nil && seen {
+				return errors.New("already seen")
+			}
+
+			return err
+		})
+
+		if n != 1 {
+			t.Errorf("concurrent Seen() of the same id returned false %v times, want 1", n)
+		}
+	})
+}
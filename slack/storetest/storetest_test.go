@@ -0,0 +1,23 @@
+package storetest
+
+import (
+	"testing"
+
+	"suy.io/bots/slack"
+)
+
+func TestMemoryBotStore(t *testing.T) {
+	TestBotStore(t, func() slack.BotStore { return slack.NewMemoryBotStore() })
+	TestBotStoreConcurrent(t, func() slack.BotStore { return slack.NewMemoryBotStore() })
+	TestUpdateBotStore(t, func() slack.UpdateBotStore { return slack.NewMemoryBotStore() })
+}
+
+func TestMemoryConversationStore(t *testing.T) {
+	TestConversationStore(t, func() slack.ConversationStore { return slack.NewMemoryConversationStore() })
+	TestConversationStoreConcurrent(t, func() slack.ConversationStore { return slack.NewMemoryConversationStore() })
+	TestVersionedConversationStore(t, func() slack.VersionedConversationStore { return slack.NewMemoryConversationStore() })
+}
+
+func TestMemorySeenStore(t *testing.T) {
+	TestSeenStore(t, func() slack.SeenStore { return slack.NewMemorySeenStore(slack.DefaultSeenTTL) })
+}
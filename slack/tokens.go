@@ -0,0 +1,116 @@
+package slack
+
+import (
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"suy.io/bots/slack/api/oauth"
+)
+
+// TokenRefreshMargin is how long before its expiry a rotating token is refreshed.
+const TokenRefreshMargin = 5 * time.Minute
+
+// tokenRefresher refreshes expiring bot and user tokens using oauth.v2.access,
+// and persists the rotated tokens in the BotStore.
+//
+// Refreshes for a team are serialized, so a refresh token is only used once,
+// while refreshes for different teams run at the same time.
+//
+// ffjson: skip
+type tokenRefresher struct {
+	clientID     string
+	clientSecret string
+	bots         BotStore
+
+	mu    sync.Mutex
+	teams map[string]*sync.Mutex
+}
+
+// newTokenRefresher creates a new tokenRefresher
+func newTokenRefresher(clientID, clientSecret string, bots BotStore) *tokenRefresher {
+	return &tokenRefresher{clientID: clientID, clientSecret: clientSecret, bots: bots, teams: make(map[string]*sync.Mutex)}
+}
+
+// expiring checks if a token expiring at the given unix timestamp needs to be refreshed.
+func expiring(expiresAt int64) bool {
+	return expiresAt != 0 && time.Now().Add(TokenRefreshMargin).Unix() >= expiresAt
+}
+
+// rotatingToken gets pointers to a token in a payload, along with its refresh token and expiry.
+type rotatingToken func(p *oauth.AccessResponse) (token, refreshToken *string, expiresAt *int64)
+
+func botToken(p *oauth.AccessResponse) (*string, *string, *int64) {
+	return &p.Bot.BotAccessToken, &p.Bot.RefreshToken, &p.Bot.ExpiresAt
+}
+
+func userToken(p *oauth.AccessResponse) (*string, *string, *int64) {
+	return &p.AccessToken, &p.RefreshToken, &p.ExpiresAt
+}
+
+// lock gets the lock for refreshing the tokens of a team.
+func (r *tokenRefresher) lock(team string) *sync.Mutex {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	l, ok := r.teams[team]
+	if !ok {
+		l = &sync.Mutex{}
+		r.teams[team] = l
+	}
+
+	return l
+}
+
+// refresh gets a fresh bot token for a team, rotating it if the stored one is expiring.
+func (r *tokenRefresher) refresh(team string) (*oauth.AccessResponse, error) {
+	return r.rotate(team, botToken)
+}
+
+// refreshUser gets a fresh user token for a team, rotating it if the stored one is expiring.
+func (r *tokenRefresher) refreshUser(team string) (*oauth.AccessResponse, error) {
+	return r.rotate(team, userToken)
+}
+
+func (r *tokenRefresher) rotate(team string, tokens rotatingToken) (*oauth.AccessResponse, error) {
+	l := r.lock(team)
+	l.Lock()
+	defer l.Unlock()
+
+	p, err := r.bots.GetBot(team)
+	if err != nil {
+		return nil, errors.Wrap(err, "Token Refresh Failed")
+	}
+
+	// another bot instance for the team may have already rotated the token
+	_, refreshToken, expiresAt := tokens(p)
+	if !expiring(*expiresAt) {
+		return p, nil
+	}
+
+	if *refreshToken == "" {
+		return nil, ErrNoRefreshToken
+	}
+
+	res, err := oauth.V2Access(&oauth.V2AccessRequest{
+		ClientID:     r.clientID,
+		ClientSecret: r.clientSecret,
+		GrantType:    "refresh_token",
+		RefreshToken: *refreshToken,
+	})
+
+	if err != nil {
+		return nil, errors.Wrap(err, "Token Refresh Failed")
+	}
+
+	np := clonePayload(p)
+	token, refreshToken, expiresAt := tokens(np)
+	*token, *refreshToken, *expiresAt = res.AccessToken, res.RefreshToken, oauth.ExpiresAt(res.ExpiresIn)
+
+	if err := UpdateBot(r.bots, np); err != nil {
+		return nil, errors.Wrap(err, "Token Refresh Failed")
+	}
+
+	return np, nil
+}
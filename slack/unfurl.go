@@ -0,0 +1,107 @@
+package slack
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+
+	"suy.io/bots/slack/api/chat"
+	"suy.io/bots/slack/api/rtm"
+)
+
+// UnfurlHandler creates the preview for a shared link.
+// Returning a nil attachment leaves the link as it is.
+type UnfurlHandler func(link *rtm.Link, bot *Bot) (*chat.Attachment, error)
+
+// ffjson: skip
+type unfurlPattern struct {
+	re      *regexp.Regexp
+	handler UnfurlHandler
+}
+
+// UnfurlRegistry is a mapping of link domains and URL patterns to UnfurlHandlers.
+//
+// ffjson: skip
+type UnfurlRegistry struct {
+	domains  map[string]UnfurlHandler
+	patterns []*unfurlPattern
+}
+
+// NewUnfurlRegistry creates a new UnfurlRegistry object.
+func NewUnfurlRegistry() *UnfurlRegistry {
+	return &UnfurlRegistry{domains: make(map[string]UnfurlHandler)}
+}
+
+// AddDomain adds a handler for all links on a domain and its subdomains.
+func (r *UnfurlRegistry) AddDomain(domain string, handler UnfurlHandler) error {
+	domain = strings.ToLower(domain)
+	if _, ok := r.domains[domain]; ok {
+		return ErrUnfurlHandlerExists
+	}
+
+	r.domains[domain] = handler
+	return nil
+}
+
+// AddPattern adds a handler for all links matching a pattern.
+// Patterns are matched in the order they are added, before domains.
+func (r *UnfurlRegistry) AddPattern(pattern *regexp.Regexp, handler UnfurlHandler) error {
+	for _, p := range r.patterns {
+		if p.re.String() == pattern.String() {
+			return ErrUnfurlHandlerExists
+		}
+	}
+
+	r.patterns = append(r.patterns, &unfurlPattern{pattern, handler})
+	return nil
+}
+
+// Get gets the handler for a link.
+func (r *UnfurlRegistry) Get(link *rtm.Link) (UnfurlHandler, error) {
+	for _, p := range r.patterns {
+		if p.re.MatchString(link.URL) {
+			return p.handler, nil
+		}
+	}
+
+	host := strings.ToLower(link.Domain)
+	if u, err := url.Parse(link.URL); err == nil && u.Hostname() != "" {
+		host = strings.ToLower(u.Hostname())
+	}
+
+	for host != "" {
+		if h, ok := r.domains[host]; ok {
+			return h, nil
+		}
+
+		i := strings.Index(host, ".")
+		if i == -1 {
+			break
+		}
+
+		host = host[i+1:]
+	}
+
+	return nil, ErrUnfurlHandlerNotFound
+}
+
+// unfurl runs the registered handlers for the passed links and collects the results.
+func (r *UnfurlRegistry) unfurl(links []*rtm.Link, bot *Bot) map[string]*chat.Attachment {
+	unfurls := make(map[string]*chat.Attachment)
+
+	for _, l := range links {
+		h, err := r.Get(l)
+		if err != nil {
+			continue
+		}
+
+		a, err := h(l, bot)
+		if err != nil || a == nil {
+			continue
+		}
+
+		unfurls[l.URL] = a
+	}
+
+	return unfurls
+}
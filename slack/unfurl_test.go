@@ -0,0 +1,131 @@
+package slack
+
+import (
+	"reflect"
+	"regexp"
+	"testing"
+
+	"suy.io/bots/slack/api/chat"
+	"suy.io/bots/slack/api/rtm"
+)
+
+func TestNewUnfurlRegistry(t *testing.T) {
+	tests := []struct {
+		name string
+		want *UnfurlRegistry
+	}{
+		{"", &UnfurlRegistry{domains: make(map[string]UnfurlHandler)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewUnfurlRegistry(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewUnfurlRegistry() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnfurlRegistry_AddDomain(t *testing.T) {
+	type args struct {
+		domain  string
+		handler UnfurlHandler
+	}
+
+	r := NewUnfurlRegistry()
+	h := func(*rtm.Link, *Bot) (*chat.Attachment, error) { return nil, nil }
+
+	tests := []struct {
+		name    string
+		r       *UnfurlRegistry
+		args    args
+		wantErr bool
+	}{
+		{"", r, args{"example.com", h}, false},
+		{"", r, args{"Example.com", h}, true},
+		{"", r, args{"docs.example.com", h}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.r.AddDomain(tt.args.domain, tt.args.handler); (err != nil) != tt.wantErr {
+				t.Errorf("UnfurlRegistry.AddDomain() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestUnfurlRegistry_AddPattern(t *testing.T) {
+	type args struct {
+		pattern *regexp.Regexp
+		handler UnfurlHandler
+	}
+
+	r := NewUnfurlRegistry()
+	h := func(*rtm.Link, *Bot) (*chat.Attachment, error) { return nil, nil }
+
+	tests := []struct {
+		name    string
+		r       *UnfurlRegistry
+		args    args
+		wantErr bool
+	}{
+		{"", r, args{regexp.MustCompile(`^https://example\.com/issues/\d+`), h}, false},
+		{"", r, args{regexp.MustCompile(`^https://example\.com/issues/\d+`), h}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.r.AddPattern(tt.args.pattern, tt.args.handler); (err != nil) != tt.wantErr {
+				t.Errorf("UnfurlRegistry.AddPattern() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestUnfurlRegistry_Get(t *testing.T) {
+	type args struct {
+		link *rtm.Link
+	}
+
+	handler := func(title string) UnfurlHandler {
+		return func(*rtm.Link, *Bot) (*chat.Attachment, error) { return &chat.Attachment{Title: title}, nil }
+	}
+
+	r := NewUnfurlRegistry()
+	r.AddDomain("example.com", handler("domain"))
+	r.AddDomain("docs.example.com", handler("docs"))
+	r.AddPattern(regexp.MustCompile(`^https://example\.com/issues/\d+`), handler("issue"))
+
+	tests := []struct {
+		name    string
+		r       *UnfurlRegistry
+		args    args
+		want    string
+		wantErr bool
+	}{
+		{"", r, args{&rtm.Link{Domain: "example.com", URL: "https://example.com/issues/42"}}, "issue", false},
+		{"", r, args{&rtm.Link{Domain: "example.com", URL: "https://example.com/pulls/42"}}, "domain", false},
+		{"", r, args{&rtm.Link{Domain: "example.com", URL: "https://www.example.com/"}}, "domain", false},
+		{"", r, args{&rtm.Link{Domain: "example.com", URL: "https://docs.example.com/a"}}, "docs", false},
+		{"", r, args{&rtm.Link{Domain: "example.org", URL: "https://example.org/"}}, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.r.Get(tt.args.link)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UnfurlRegistry.Get() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if got == nil {
+				return
+			}
+
+			if a, _ := got(tt.args.link, nil); a.Title != tt.want {
+				t.Errorf("UnfurlRegistry.Get() = %v, want %v", a.Title, tt.want)
+			}
+		})
+	}
+}
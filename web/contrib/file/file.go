@@ -0,0 +1,476 @@
+// Package file implements web stores persisted to a local file,
+// for single node deployments that need to keep their data across restarts.
+package file // import "suy.io/bots/web/contrib/file"
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"suy.io/bots/internal/filekv"
+	"suy.io/bots/web"
+)
+
+const (
+	botsBucket          = "bots"
+	conversationsBucket = "conversations"
+)
+
+func botKey(id web.BotID) string {
+	return strconv.FormatInt(int64(id), 10)
+}
+
+// itemsBucket is the bucket storing the items in a thread of a bot.
+func itemsBucket(bot web.BotID, thread web.ItemID) string {
+	return "items:" + botKey(bot) + ":" + strconv.FormatInt(int64(thread), 10)
+}
+
+// itemKey pads item ids so keys sort in the same order as ids.
+func itemKey(id web.ItemID) string {
+	return fmt.Sprintf("%020d", int64(id))
+}
+
+// FileControllerStore is a web.ControllerStore storing bots and their items in a file.
+type FileControllerStore struct {
+	mu     sync.Mutex
+	db     *filekv.DB
+	stores map[web.BotID]*FileItemStore
+}
+
+// NewFileControllerStore opens or creates a FileControllerStore at path.
+func NewFileControllerStore(path string) (*FileControllerStore, error) {
+	db, err := filekv.Open(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "NewFileControllerStore Failed")
+	}
+
+	return &FileControllerStore{db: db, stores: make(map[web.BotID]*FileItemStore)}, nil
+}
+
+// Add adds a new bot.
+func (s *FileControllerStore) Add(id web.BotID) error {
+	return s.db.Update(func(tx *filekv.Tx) error {
+		if _, ok := tx.Get(botsBucket, botKey(id)); ok {
+			return web.ErrBotAlreadyAdded
+		}
+
+		tx.Put(botsBucket, botKey(id), "")
+		return nil
+	})
+}
+
+// Get gets the ItemStore for a bot.
+func (s *FileControllerStore) Get(id web.BotID) (web.ItemStore, error) {
+	if _, ok := s.db.Get(botsBucket, botKey(id)); !ok {
+		return nil, web.ErrBotNotFound
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	is, ok := s.stores[id]
+	if !ok {
+		is = &FileItemStore{db: s.db, bot: id}
+		s.stores[id] = is
+	}
+
+	return is, nil
+}
+
+// Remove removes a bot along with all its items.
+func (s *FileControllerStore) Remove(id web.BotID) error {
+	err := s.db.Update(func(tx *filekv.Tx) error {
+		if _, ok := tx.Get(botsBucket, botKey(id)); !ok {
+			return web.ErrBotNotFound
+		}
+
+		tx.Delete(botsBucket, botKey(id))
+		for _, b := range tx.Buckets("items:" + botKey(id) + ":") {
+			tx.DeleteBucket(b)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	delete(s.stores, id)
+	s.mu.Unlock()
+
+	return nil
+}
+
+// Compact rewrites the file with only the data currently stored.
+func (s *FileControllerStore) Compact() error {
+	return s.db.Compact()
+}
+
+// Close closes the file.
+func (s *FileControllerStore) Close() error {
+	return s.db.Close()
+}
+
+var _ web.ControllerStore = &FileControllerStore{}
+
+// storedItem keeps the type of an item next to it, to decode it as a message or a thread.
+type storedItem struct {
+	Type web.ItemType    `json:"type"`
+	Item json.RawMessage `json:"item"`
+}
+
+func encodeItem(item web.Item) (string, error) {
+	d, err := json.Marshal(item)
+	if err != nil {
+		return "", err
+	}
+
+	s, err := json.Marshal(&storedItem{item.ItemType(), d})
+	return string(s), err
+}
+
+func decodeItem(s string) (web.Item, error) {
+	si := &storedItem{}
+	if err := json.Unmarshal([]byte(s), si); err != nil {
+		return nil, errors.Wrap(err, "Could not decode item")
+	}
+
+	var item web.Item
+	switch si.Type {
+	case web.MessageItemType:
+		item = &web.Message{}
+	case web.ThreadItemType:
+		item = &web.Thread{}
+	default:
+		return nil, web.ErrInvalidItem
+	}
+
+	if err := json.Unmarshal(si.Item, item); err != nil {
+		return nil, errors.Wrap(err, "Could not decode item")
+	}
+
+	return item, nil
+}
+
+func setCursors(item web.Item, prev, next *web.Cursor) {
+	switch i := item.(type) {
+	case *web.Message:
+		i.Prev, i.Next = prev, next
+	case *web.Thread:
+		i.Prev, i.Next = prev, next
+	}
+}
+
+func cursors(item web.Item) (prev, next *web.Cursor) {
+	switch i := item.(type) {
+	case *web.Message:
+		return i.Prev, i.Next
+	case *web.Thread:
+		return i.Prev, i.Next
+	}
+
+	return nil, nil
+}
+
+// FileItemStore is a web.ItemStore for a single bot, created by FileControllerStore.
+type FileItemStore struct {
+	db  *filekv.DB
+	bot web.BotID
+}
+
+// Add adds an item to its thread, setting its cursors and updating the cursors of its neighbours,
+// all in a single write.
+func (s *FileItemStore) Add(item web.Item) error {
+	if item == nil {
+		return web.ErrInvalidItem
+	}
+
+	if item.ItemType() == web.ThreadItemType && item.ItemID() == web.ItemID(0) {
+		return web.ErrCannotAddThreadZero
+	}
+
+	b, k := itemsBucket(s.bot, item.ThreadItemID()), itemKey(item.ItemID())
+	cursor := &web.Cursor{Type: item.ItemType(), ID: item.ItemID()}
+
+	return s.db.Update(func(tx *filekv.Tx) error {
+		var pItem, nItem web.Item
+		for _, key := range tx.Keys(b) {
+			if key == k {
+				continue
+			}
+
+			if key > k {
+				d, _ := tx.Get(b, key)
+				i, err := decodeItem(d)
+				if err != nil {
+					return errors.Wrap(err, "Add Failed")
+				}
+
+				nItem = i
+				break
+			}
+
+			d, _ := tx.Get(b, key)
+			i, err := decodeItem(d)
+			if err != nil {
+				return errors.Wrap(err, "Add Failed")
+			}
+
+			pItem = i
+		}
+
+		var prev, next *web.Cursor
+
+		if pItem != nil {
+			p, _ := cursors(pItem)
+			setCursors(pItem, p, cursor)
+			prev = &web.Cursor{Type: pItem.ItemType(), ID: pItem.ItemID()}
+
+			if err := putItem(tx, b, pItem); err != nil {
+				return errors.Wrap(err, "Add Failed")
+			}
+		}
+
+		if nItem != nil {
+			_, n := cursors(nItem)
+			setCursors(nItem, cursor, n)
+			next = &web.Cursor{Type: nItem.ItemType(), ID: nItem.ItemID()}
+
+			if err := putItem(tx, b, nItem); err != nil {
+				return errors.Wrap(err, "Add Failed")
+			}
+		}
+
+		setCursors(item, prev, next)
+
+		if err := putItem(tx, b, item); err != nil {
+			return errors.Wrap(err, "Add Failed")
+		}
+
+		return nil
+	})
+}
+
+func putItem(tx *filekv.Tx, bucket string, item web.Item) error {
+	d, err := encodeItem(item)
+	if err != nil {
+		return err
+	}
+
+	tx.Put(bucket, itemKey(item.ItemID()), d)
+	return nil
+}
+
+// Get gets an item in a thread.
+func (s *FileItemStore) Get(id, thread web.ItemID) (web.Item, error) {
+	b := itemsBucket(s.bot, thread)
+
+	d, ok := s.db.Get(b, itemKey(id))
+	if !ok {
+		if len(s.db.Keys(b)) == 0 {
+			return nil, web.ErrThreadNotFound
+		}
+
+		return nil, web.ErrItemNotFound
+	}
+
+	return decodeItem(d)
+}
+
+// Update updates an existing item.
+func (s *FileItemStore) Update(item web.Item) error {
+	if item == nil {
+		return web.ErrInvalidItem
+	}
+
+	b := itemsBucket(s.bot, item.ThreadItemID())
+
+	return s.db.Update(func(tx *filekv.Tx) error {
+		if _, ok := tx.Get(b, itemKey(item.ItemID())); !ok {
+			return web.ErrItemNotFound
+		}
+
+		if err := putItem(tx, b, item); err != nil {
+			return errors.Wrap(err, "Update Failed")
+		}
+
+		return nil
+	})
+}
+
+// All gets all items in a thread, in order.
+func (s *FileItemStore) All(thread web.ItemID) ([]web.Item, error) {
+	b := itemsBucket(s.bot, thread)
+
+	keys := s.db.Keys(b)
+	if len(keys) == 0 {
+		return nil, web.ErrThreadNotFound
+	}
+
+	ans := make([]web.Item, 0, len(keys))
+	for _, k := range keys {
+		d, ok := s.db.Get(b, k)
+		if !ok {
+			continue
+		}
+
+		item, err := decodeItem(d)
+		if err != nil {
+			return nil, errors.Wrap(err, "All Failed")
+		}
+
+		ans = append(ans, item)
+	}
+
+	return ans, nil
+}
+
+var _ web.ItemStore = &FileItemStore{}
+
+type conversation struct {
+	ID    string            `json:"id"`
+	State string            `json:"state"`
+	Data  map[string]string `json:"data"`
+}
+
+// FileConversationStore is a web.ConversationStore storing conversations in a file.
+type FileConversationStore struct {
+	db *filekv.DB
+}
+
+// NewFileConversationStore opens or creates a FileConversationStore at path.
+func NewFileConversationStore(path string) (*FileConversationStore, error) {
+	db, err := filekv.Open(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "NewFileConversationStore Failed")
+	}
+
+	return &FileConversationStore{db}, nil
+}
+
+func (cs *FileConversationStore) get(get func(string, string) (string, bool), bot web.BotID) (*conversation, error) {
+	d, ok := get(conversationsBucket, botKey(bot))
+	if !ok {
+		return nil, web.ErrConversationNotFound
+	}
+
+	c := &conversation{}
+	if err := json.Unmarshal([]byte(d), c); err != nil {
+		return nil, errors.Wrap(err, "Could not decode conversation")
+	}
+
+	return c, nil
+}
+
+// update runs f on the active conversation of a bot and stores the result.
+func (cs *FileConversationStore) update(bot web.BotID, f func(*conversation)) error {
+	return cs.db.Update(func(tx *filekv.Tx) error {
+		c, err := cs.get(tx.Get, bot)
+		if err != nil {
+			return err
+		}
+
+		f(c)
+
+		d, err := json.Marshal(c)
+		if err != nil {
+			return err
+		}
+
+		tx.Put(conversationsBucket, botKey(bot), string(d))
+		return nil
+	})
+}
+
+// Start starts a conversation of specified ID with the specified Bot.
+func (cs *FileConversationStore) Start(bot web.BotID, id string) error {
+	d, err := json.Marshal(&conversation{id, "start", make(map[string]string)})
+	if err != nil {
+		return errors.Wrap(err, "Start Failed")
+	}
+
+	return cs.db.Update(func(tx *filekv.Tx) error {
+		if _, ok := tx.Get(conversationsBucket, botKey(bot)); ok {
+			return web.ErrConversationExists
+		}
+
+		tx.Put(conversationsBucket, botKey(bot), string(d))
+		return nil
+	})
+}
+
+// IsActive returns true if the bot has an active conversation.
+func (cs *FileConversationStore) IsActive(bot web.BotID) bool {
+	_, ok := cs.db.Get(conversationsBucket, botKey(bot))
+	return ok
+}
+
+// Active returns the conversation id and state for the bot.
+func (cs *FileConversationStore) Active(bot web.BotID) (id, state string, err error) {
+	c, err := cs.get(cs.db.Get, bot)
+	if err != nil {
+		return "", "", err
+	}
+
+	return c.ID, c.State, nil
+}
+
+// SetState sets the state of the active conversation.
+func (cs *FileConversationStore) SetState(bot web.BotID, state string) error {
+	return cs.update(bot, func(c *conversation) { c.State = state })
+}
+
+// SetData sets a key-value pair for the active conversation.
+func (cs *FileConversationStore) SetData(bot web.BotID, key, value string) error {
+	return cs.update(bot, func(c *conversation) {
+		if c.Data == nil {
+			c.Data = make(map[string]string)
+		}
+
+		c.Data[key] = value
+	})
+}
+
+// GetData gets data with specified key for the active conversation.
+func (cs *FileConversationStore) GetData(bot web.BotID, key string) (string, error) {
+	c, err := cs.get(cs.db.Get, bot)
+	if err != nil {
+		return "", err
+	}
+
+	ans, ok := c.Data[key]
+	if !ok {
+		return "", web.ErrItemNotFound
+	}
+
+	return ans, nil
+}
+
+// End ends the active conversation of the bot.
+func (cs *FileConversationStore) End(bot web.BotID) error {
+	return cs.db.Update(func(tx *filekv.Tx) error {
+		if _, ok := tx.Get(conversationsBucket, botKey(bot)); !ok {
+			return web.ErrConversationNotFound
+		}
+
+		tx.Delete(conversationsBucket, botKey(bot))
+		return nil
+	})
+}
+
+// Compact rewrites the file with only the conversations currently active.
+func (cs *FileConversationStore) Compact() error {
+	return cs.db.Compact()
+}
+
+// Close closes the file.
+func (cs *FileConversationStore) Close() error {
+	return cs.db.Close()
+}
+
+var _ web.ConversationStore = &FileConversationStore{}
@@ -0,0 +1,247 @@
+package file
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"suy.io/bots/web"
+	"suy.io/bots/web/storetest"
+)
+
+func testPath(t *testing.T) (string, func()) {
+	dir, err := ioutil.TempDir("", "webfile")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return filepath.Join(dir, "test.db"), func() { os.RemoveAll(dir) }
+}
+
+func TestFileControllerStore(t *testing.T) {
+	path, done := testPath(t)
+	defer done()
+
+	s, err := NewFileControllerStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		f       func() error
+		wantErr error
+	}{
+		{"", func() error { return s.Add(1) }, nil},
+		{"", func() error { return s.Add(1) }, web.ErrBotAlreadyAdded},
+		{"", func() error { _, err := s.Get(1); return err }, nil},
+		{"", func() error { _, err := s.Get(2); return err }, web.ErrBotNotFound},
+		{"", func() error { return s.Remove(2) }, web.ErrBotNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.f(); err != tt.wantErr {
+				t.Errorf("FileControllerStore error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	is, _ := s.Get(1)
+	is.Add(&web.Message{ID: 1, Type: web.MessageItemType})
+
+	if err := s.Remove(1); err != nil {
+		t.Fatalf("FileControllerStore.Remove() error = %v", err)
+	}
+
+	s.Add(1)
+	is, _ = s.Get(1)
+	if _, err := is.Get(1, 0); err != web.ErrThreadNotFound {
+		t.Errorf("FileControllerStore.Remove() kept items of removed bot")
+	}
+
+	s.Close()
+}
+
+func TestFileItemStore(t *testing.T) {
+	path, done := testPath(t)
+	defer done()
+
+	s, err := NewFileControllerStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s.Add(1)
+	is, _ := s.Get(1)
+
+	if err := is.Add(&web.Thread{ID: 0, Type: web.ThreadItemType}); err != web.ErrCannotAddThreadZero {
+		t.Errorf("FileItemStore.Add() error = %v, want %v", err, web.ErrCannotAddThreadZero)
+	}
+
+	// added out of order, cursors have to follow ids
+	for _, item := range []web.Item{
+		&web.Message{ID: 10, Type: web.MessageItemType, Text: "a"},
+		&web.Message{ID: 30, Type: web.MessageItemType, Text: "c"},
+		&web.Thread{ID: 20, Type: web.ThreadItemType},
+	} {
+		if err := is.Add(item); err != nil {
+			t.Fatalf("FileItemStore.Add() error = %v", err)
+		}
+	}
+
+	if err := is.Update(&web.Message{ID: 40}); err != web.ErrItemNotFound {
+		t.Errorf("FileItemStore.Update() error = %v, want %v", err, web.ErrItemNotFound)
+	}
+
+	s.Close()
+
+	s, err = NewFileControllerStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer s.Close()
+	is, _ = s.Get(1)
+
+	tests := []struct {
+		name    string
+		id      web.ItemID
+		thread  web.ItemID
+		want    web.Item
+		wantErr error
+	}{
+		{"", 10, 0, &web.Message{ID: 10, Type: web.MessageItemType, Text: "a", Next: &web.Cursor{Type: web.ThreadItemType, ID: 20}}, nil},
+		{"", 20, 0, &web.Thread{ID: 20, Type: web.ThreadItemType, Prev: &web.Cursor{Type: web.MessageItemType, ID: 10}, Next: &web.Cursor{Type: web.MessageItemType, ID: 30}}, nil},
+		{"", 30, 0, &web.Message{ID: 30, Type: web.MessageItemType, Text: "c", Prev: &web.Cursor{Type: web.ThreadItemType, ID: 20}}, nil},
+		{"", 40, 0, nil, web.ErrItemNotFound},
+		{"", 10, 20, nil, web.ErrThreadNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := is.Get(tt.id, tt.thread)
+			if err != tt.wantErr {
+				t.Errorf("FileItemStore.Get() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FileItemStore.Get() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	all, err := is.(*FileItemStore).All(0)
+	if err != nil || len(all) != 3 || all[0].ItemID() != 10 || all[2].ItemID() != 30 {
+		t.Errorf("FileItemStore.All() = %v, %v", all, err)
+	}
+}
+
+func TestFileConversationStore(t *testing.T) {
+	path, done := testPath(t)
+	defer done()
+
+	cs, err := NewFileConversationStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := cs.SetData(1, "foo", "bar"); err != web.ErrConversationNotFound {
+		t.Errorf("FileConversationStore.SetData() error = %v, want %v", err, web.ErrConversationNotFound)
+	}
+
+	if err := cs.Start(1, "conv"); err != nil {
+		t.Fatalf("FileConversationStore.Start() error = %v", err)
+	}
+
+	if err := cs.Start(1, "conv"); err != web.ErrConversationExists {
+		t.Errorf("FileConversationStore.Start() error = %v, want %v", err, web.ErrConversationExists)
+	}
+
+	cs.SetState(1, "next")
+	cs.SetData(1, "foo", "bar")
+	cs.Close()
+
+	cs, err = NewFileConversationStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer cs.Close()
+
+	if id, state, err := cs.Active(1); err != nil || id != "conv" || state != "next" {
+		t.Errorf("FileConversationStore.Active() = %v, %v, %v, want conv, next", id, state, err)
+	}
+
+	if v, err := cs.GetData(1, "foo"); err != nil || v != "bar" {
+		t.Errorf("FileConversationStore.GetData() = %v, %v, want bar", v, err)
+	}
+
+	if _, err := cs.GetData(1, "nope"); err != web.ErrItemNotFound {
+		t.Errorf("FileConversationStore.GetData() error = %v, want %v", err, web.ErrItemNotFound)
+	}
+
+	if err := cs.End(1); err != nil {
+		t.Errorf("FileConversationStore.End() error = %v", err)
+	}
+
+	if cs.IsActive(1) {
+		t.Errorf("FileConversationStore.IsActive() = true after End")
+	}
+}
+
+func TestFileStoresConformance(t *testing.T) {
+	dir, err := ioutil.TempDir("", "webfile")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	n := 0
+	path := func() string {
+		n++
+		return filepath.Join(dir, fmt.Sprintf("%d.db", n))
+	}
+
+	newControllerStore := func() web.ControllerStore {
+		s, err := NewFileControllerStore(path())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return s
+	}
+
+	newItemStore := func() web.ItemStore {
+		s := newControllerStore()
+		s.Add(1)
+
+		is, err := s.Get(1)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return is
+	}
+
+	newConversationStore := func() web.ConversationStore {
+		cs, err := NewFileConversationStore(path())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return cs
+	}
+
+	storetest.TestControllerStore(t, newControllerStore)
+	storetest.TestControllerStoreConcurrent(t, newControllerStore)
+	storetest.TestItemStore(t, newItemStore)
+	storetest.TestItemStoreConcurrent(t, newItemStore)
+	storetest.TestConversationStore(t, newConversationStore)
+	storetest.TestConversationStoreConcurrent(t, newConversationStore)
+}
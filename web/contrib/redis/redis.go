@@ -15,6 +15,7 @@ import (
 // TODO: improve pagination capabilities
 
 type RedisControllerStore struct {
+	mu     sync.Mutex
 	client *redis.Client
 	bots   map[web.BotID]*RedisItemStore
 }
@@ -30,35 +31,63 @@ func NewRedisControllerStore(host string) (*RedisControllerStore, error) {
 		return nil, err
 	}
 
-	return &RedisControllerStore{c, make(map[web.BotID]*RedisItemStore)}, nil
+	return &RedisControllerStore{client: c, bots: make(map[web.BotID]*RedisItemStore)}, nil
 }
 
 func (rcs *RedisControllerStore) Add(botID web.BotID) error {
-	return rcs.client.SAdd("bots", int64(botID)).Err()
-}
+	n, err := rcs.client.SAdd("bots", int64(botID)).Result()
+	if err != nil {
+		return err
+	}
 
-func (rcs *RedisControllerStore) Get(botID web.BotID) (web.ItemStore, error) {
-	is, ok := rcs.bots[botID]
-	if ok {
-		return is, nil
+	if n == 0 {
+		return web.ErrBotAlreadyAdded
 	}
 
+	return nil
+}
+
+func (rcs *RedisControllerStore) Get(botID web.BotID) (web.ItemStore, error) {
 	if !rcs.client.SIsMember("bots", int64(botID)).Val() {
 		return nil, web.ErrBotNotFound
 	}
 
-	is = newRedisItemStore(rcs.client, botID)
-	rcs.bots[botID] = is
+	rcs.mu.Lock()
+	defer rcs.mu.Unlock()
+
+	is, ok := rcs.bots[botID]
+	if !ok {
+		is = newRedisItemStore(rcs.client, botID)
+		rcs.bots[botID] = is
+	}
+
 	return is, nil
 }
 
 func (rcs *RedisControllerStore) Remove(botID web.BotID) error {
+	rcs.mu.Lock()
 	delete(rcs.bots, botID)
+	rcs.mu.Unlock()
+
+	n, err := rcs.client.SRem("bots", int64(botID)).Result()
+	if err != nil {
+		return err
+	}
 
-	if err := rcs.client.SRem("bots", int64(botID)).Err(); err != nil {
+	if n == 0 {
+		return web.ErrBotNotFound
+	}
+
+	// clear the items of the bot
+	keys, err := rcs.client.Keys(strconv.FormatInt(int64(botID), 10) + ":[0-9]*").Result()
+	if err != nil {
 		return err
 	}
 
+	if len(keys) > 0 {
+		return rcs.client.Del(keys...).Err()
+	}
+
 	return nil
 }
 
@@ -78,6 +107,14 @@ func newRedisItemStore(c *redis.Client, b web.BotID) *RedisItemStore {
 }
 
 func (is *RedisItemStore) Add(item web.Item) error {
+	if item == nil {
+		return web.ErrInvalidItem
+	}
+
+	if item.ItemType() == web.ThreadItemType && item.ItemID() == web.ItemID(0) {
+		return web.ErrCannotAddThreadZero
+	}
+
 	is.mu.Lock()
 	defer is.mu.Unlock()
 
@@ -249,6 +286,14 @@ func (is *RedisItemStore) get(id, threadID string) (web.Item, error) {
 	key := strconv.FormatInt(int64(is.botid), 10) + ":" + threadID + ":" + id
 
 	res, err := is.client.Get(key).Result()
+	if err == redis.Nil {
+		if n, _ := is.client.ZCard(strconv.FormatInt(int64(is.botid), 10) + ":" + threadID).Result(); n == 0 {
+			return nil, web.ErrThreadNotFound
+		}
+
+		return nil, web.ErrItemNotFound
+	}
+
 	if err != nil {
 		return nil, err
 	}
@@ -295,7 +340,24 @@ func (is *RedisItemStore) Last(n int64, thread web.ItemID) (items []web.Item, er
 }
 
 func (is *RedisItemStore) Update(item web.Item) error {
+	if item == nil {
+		return web.ErrInvalidItem
+	}
+
+	is.mu.Lock()
+	defer is.mu.Unlock()
+
 	botidstr, threadidstr := strconv.FormatInt(int64(is.botid), 10), strconv.FormatInt(int64(item.ThreadItemID()), 10)
+
+	n, err := is.client.Exists(botidstr + ":" + threadidstr + ":" + strconv.FormatInt(int64(item.ItemID()), 10)).Result()
+	if err != nil {
+		return err
+	}
+
+	if n == 0 {
+		return web.ErrItemNotFound
+	}
+
 	return is.save(item, botidstr+":"+threadidstr)
 }
 
@@ -320,49 +382,101 @@ func NewRedisConversationStore(host string) (*RedisConversationStore, error) {
 }
 
 func (cs *RedisConversationStore) Start(botid web.BotID, id string) error {
-	if err := cs.client.Set(strconv.Itoa(int(botid)), id, 0).Err(); err != nil {
+	k := strconv.Itoa(int(botid))
+
+	ok, err := cs.client.SetNX(k, id, 0).Result()
+	if err != nil {
 		return err
 	}
 
-	return cs.client.Set(strconv.Itoa(int(botid))+":state", "start", 0).Err()
+	if !ok {
+		return web.ErrConversationExists
+	}
+
+	// clear data left behind by a conversation that was not ended cleanly
+	if err := cs.client.Del(k + ":data").Err(); err != nil {
+		return err
+	}
+
+	return cs.client.Set(k+":state", "start", 0).Err()
 }
 
 func (cs *RedisConversationStore) IsActive(botid web.BotID) bool {
 	return cs.client.Get(strconv.Itoa(int(botid))).Err() == nil
 }
 
+// active checks that a conversation is active, before changing it.
+func (cs *RedisConversationStore) active(botid web.BotID) error {
+	n, err := cs.client.Exists(strconv.Itoa(int(botid))).Result()
+	if err != nil {
+		return err
+	}
+
+	if n == 0 {
+		return web.ErrConversationNotFound
+	}
+
+	return nil
+}
+
 func (cs *RedisConversationStore) Active(botid web.BotID) (id, state string, err error) {
 	id, err = cs.client.Get(strconv.Itoa(int(botid))).Result()
+	if err == redis.Nil {
+		return "", "", web.ErrConversationNotFound
+	}
+
 	if err != nil {
 		return "", "", err
 	}
 
-	state = cs.client.Get(strconv.Itoa(int(botid)) + ":state").String()
+	state, err = cs.client.Get(strconv.Itoa(int(botid)) + ":state").Result()
+	if err == redis.Nil {
+		err = nil
+	}
+
 	return
 }
 
 func (cs *RedisConversationStore) SetState(botid web.BotID, state string) error {
+	if err := cs.active(botid); err != nil {
+		return err
+	}
+
 	return cs.client.Set(strconv.Itoa(int(botid))+":state", state, 0).Err()
 }
 
 func (cs *RedisConversationStore) SetData(botid web.BotID, key, value string) error {
+	if err := cs.active(botid); err != nil {
+		return err
+	}
+
 	return cs.client.HSet(strconv.Itoa(int(botid))+":data", key, value).Err()
 }
 
 func (cs *RedisConversationStore) GetData(botid web.BotID, key string) (string, error) {
-	return cs.client.HGet(strconv.Itoa(int(botid))+":data", key).Result()
+	if err := cs.active(botid); err != nil {
+		return "", err
+	}
+
+	v, err := cs.client.HGet(strconv.Itoa(int(botid))+":data", key).Result()
+	if err == redis.Nil {
+		return "", web.ErrItemNotFound
+	}
+
+	return v, err
 }
 
 func (cs *RedisConversationStore) End(botid web.BotID) error {
-	if err := cs.client.HDel(strconv.Itoa(int(botid)) + ":data").Err(); err != nil {
+	n, err := cs.client.Del(strconv.Itoa(int(botid))).Result()
+	if err != nil {
 		return err
 	}
 
-	if err := cs.client.Del(strconv.Itoa(int(botid)) + ":state").Err(); err != nil {
-		return err
+	if n == 0 {
+		return web.ErrConversationNotFound
 	}
 
-	return cs.client.Del(strconv.Itoa(int(botid))).Err()
+	return cs.client.Del(strconv.Itoa(int(botid))+":state", strconv.Itoa(int(botid))+":data").Err()
 }
 
 var _ web.ConversationStore = &RedisConversationStore{}
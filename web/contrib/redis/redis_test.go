@@ -0,0 +1,60 @@
+package redis
+
+import (
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+
+	"suy.io/bots/web"
+	"suy.io/bots/web/storetest"
+)
+
+func TestRedisStoresConformance(t *testing.T) {
+	s, err := miniredis.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer s.Close()
+
+	newControllerStore := func() web.ControllerStore {
+		s.FlushAll()
+
+		cs, err := NewRedisControllerStore(s.Addr())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return cs
+	}
+
+	newItemStore := func() web.ItemStore {
+		cs := newControllerStore()
+		cs.Add(1)
+
+		is, err := cs.Get(1)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return is
+	}
+
+	newConversationStore := func() web.ConversationStore {
+		s.FlushAll()
+
+		cs, err := NewRedisConversationStore(s.Addr())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return cs
+	}
+
+	storetest.TestControllerStore(t, newControllerStore)
+	storetest.TestControllerStoreConcurrent(t, newControllerStore)
+	storetest.TestItemStore(t, newItemStore)
+	storetest.TestItemStoreConcurrent(t, newItemStore)
+	storetest.TestConversationStore(t, newConversationStore)
+	storetest.TestConversationStoreConcurrent(t, newConversationStore)
+}
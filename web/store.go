@@ -129,7 +129,12 @@ func (store *MemoryItemStore) Update(item Item) error {
 		return ErrInvalidItem
 	}
 
-	return store.messages[item.ThreadItemID()].Set(item)
+	s, ok := store.messages[item.ThreadItemID()]
+	if !ok {
+		return ErrThreadNotFound
+	}
+
+	return s.Set(item)
 }
 
 // Get gets a specific item in a specific thread.
@@ -0,0 +1,424 @@
+// Package storetest provides conformance tests for implementations of the web store interfaces.
+//
+// A store implementation can be checked from its own tests with
+//
+//	func TestMyItemStore(t *testing.T) {
+//		storetest.TestItemStore(t, func() web.ItemStore { return NewMyItemStore() })
+//	}
+//
+// Every call to the passed function has to return an empty store.
+package storetest // import "suy.io/bots/web/storetest"
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/pkg/errors"
+
+	"suy.io/bots/web"
+)
+
+// Concurrency is the number of goroutines used by the concurrency tests.
+const Concurrency = 32
+
+func wantErr(t *testing.T, method string, err, want error) {
+	t.Helper()
+
+	if errors.Cause(err) != want {
+		t.Errorf("%s error = %v, want %v", method, err, want)
+	}
+}
+
+// countSuccesses runs f from Concurrency goroutines at once, returning how many calls succeeded.
+func countSuccesses(f func() error) int {
+	var (
+		wg    sync.WaitGroup
+		mu    sync.Mutex
+		n     int
+		start = make(chan struct{})
+	)
+
+	for i := 0; i < Concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+
+			if f() == nil {
+				mu.Lock()
+				n++
+				mu.Unlock()
+			}
+		}()
+	}
+
+	close(start)
+	wg.Wait()
+
+	return n
+}
+
+// TestControllerStore runs the behavioral tests for a web.ControllerStore.
+func TestControllerStore(t *testing.T, newStore func() web.ControllerStore) {
+	t.Run("Add", func(t *testing.T) {
+		s := newStore()
+
+		if err := s.Add(1); err != nil {
+			t.Fatalf("Add() error = %v", err)
+		}
+
+		wantErr(t, "Add() of an added bot", s.Add(1), web.ErrBotAlreadyAdded)
+
+		is, err := s.Get(1)
+		if err != nil || is == nil {
+			t.Errorf("Get() = %v, %v, want an ItemStore", is, err)
+		}
+	})
+
+	t.Run("Missing", func(t *testing.T) {
+		s := newStore()
+
+		_, err := s.Get(1)
+		wantErr(t, "Get()", err, web.ErrBotNotFound)
+		wantErr(t, "Remove()", s.Remove(1), web.ErrBotNotFound)
+	})
+
+	t.Run("Remove", func(t *testing.T) {
+		s := newStore()
+		s.Add(1)
+		s.Add(2)
+
+		if err := s.Remove(1); err != nil {
+			t.Fatalf("Remove() error = %v", err)
+		}
+
+		_, err := s.Get(1)
+		wantErr(t, "Get() of a removed bot", err, web.ErrBotNotFound)
+
+		if _, err := s.Get(2); err != nil {
+			t.Errorf("Get() of a bot that was not removed error = %v", err)
+		}
+
+		if err := s.Add(1); err != nil {
+			t.Errorf("Add() of a removed bot error = %v", err)
+		}
+	})
+
+	t.Run("Items", func(t *testing.T) {
+		s := newStore()
+		s.Add(1)
+		s.Add(2)
+
+		is, _ := s.Get(1)
+		if err := is.Add(&web.Message{ID: 1, Type: web.MessageItemType}); err != nil {
+			t.Fatalf("ItemStore.Add() error = %v", err)
+		}
+
+		is, _ = s.Get(2)
+		if _, err := is.Get(1, 0); err == nil {
+			t.Errorf("ItemStore.Get() found an item added for another bot")
+		}
+	})
+}
+
+// TestControllerStoreConcurrent runs the concurrency tests for a web.ControllerStore,
+// that is expected to be safe for use by multiple goroutines.
+func TestControllerStoreConcurrent(t *testing.T, newStore func() web.ControllerStore) {
+	t.Run("AddSame", func(t *testing.T) {
+		s := newStore()
+
+		if n := countSuccesses(func() error { return s.Add(1) }); n != 1 {
+			t.Errorf("concurrent Add() of the same bot succeeded %v times, want 1", n)
+		}
+	})
+
+	t.Run("AddDistinct", func(t *testing.T) {
+		s := newStore()
+
+		var wg sync.WaitGroup
+		for i := 0; i < Concurrency; i++ {
+			wg.Add(1)
+			go func(id web.BotID) {
+				defer wg.Done()
+
+				if err := s.Add(id); err != nil {
+					t.Errorf("Add() error = %v", err)
+				}
+
+				if _, err := s.Get(id); err != nil {
+					t.Errorf("Get() error = %v", err)
+				}
+			}(web.BotID(i))
+		}
+
+		wg.Wait()
+	})
+}
+
+func message(id, thread web.ItemID) *web.Message {
+	return &web.Message{ID: id, ThreadID: thread, Type: web.MessageItemType, Source: web.UserItemSource, Text: fmt.Sprint(id)}
+}
+
+func cursors(item web.Item) (prev, next *web.Cursor) {
+	switch i := item.(type) {
+	case *web.Message:
+		return i.Prev, i.Next
+	case *web.Thread:
+		return i.Prev, i.Next
+	}
+
+	return nil, nil
+}
+
+func cursor(item web.Item) *web.Cursor {
+	if item == nil {
+		return nil
+	}
+
+	return &web.Cursor{Type: item.ItemType(), ID: item.ItemID()}
+}
+
+// checkChain checks that the items of a thread are linked in order of their ids.
+func checkChain(t *testing.T, is web.ItemStore, thread web.ItemID, items []web.Item) {
+	t.Helper()
+
+	for i, item := range items {
+		got, err := is.Get(item.ItemID(), thread)
+		if err != nil {
+			t.Errorf("Get(%v, %v) error = %v", item.ItemID(), thread, err)
+			continue
+		}
+
+		var wantPrev, wantNext *web.Cursor
+		if i > 0 {
+			wantPrev = cursor(items[i-1])
+		}
+
+		if i < len(items)-1 {
+			wantNext = cursor(items[i+1])
+		}
+
+		prev, next := cursors(got)
+		if !reflect.DeepEqual(prev, wantPrev) || !reflect.DeepEqual(next, wantNext) {
+			t.Errorf("Get(%v, %v) cursors = %v, %v, want %v, %v", item.ItemID(), thread, prev, next, wantPrev, wantNext)
+		}
+	}
+}
+
+// TestItemStore runs the behavioral tests for a web.ItemStore.
+func TestItemStore(t *testing.T, newStore func() web.ItemStore) {
+	t.Run("Invalid", func(t *testing.T) {
+		is := newStore()
+
+		wantErr(t, "Add() of nil", is.Add(nil), web.ErrInvalidItem)
+		wantErr(t, "Add() of thread zero", is.Add(&web.Thread{ID: 0, Type: web.ThreadItemType}), web.ErrCannotAddThreadZero)
+		wantErr(t, "Update() of nil", is.Update(nil), web.ErrInvalidItem)
+	})
+
+	t.Run("Missing", func(t *testing.T) {
+		is := newStore()
+		is.Add(message(1, 0))
+
+		_, err := is.Get(1, 42)
+		wantErr(t, "Get() in a missing thread", err, web.ErrThreadNotFound)
+
+		_, err = is.Get(2, 0)
+		wantErr(t, "Get() of a missing item", err, web.ErrItemNotFound)
+		wantErr(t, "Update() of a missing item", is.Update(message(2, 0)), web.ErrItemNotFound)
+
+		if err := is.Update(message(1, 42)); err == nil {
+			t.Errorf("Update() in a missing thread error = nil, want error")
+		}
+	})
+
+	t.Run("Cursors", func(t *testing.T) {
+		is := newStore()
+
+		m1, t2, m3, m4 := message(10, 0), &web.Thread{ID: 20, Type: web.ThreadItemType}, message(30, 0), message(40, 0)
+		for _, item := range []web.Item{m3, m1, m4, t2} {
+			if err := is.Add(item); err != nil {
+				t.Fatalf("Add() error = %v", err)
+			}
+		}
+
+		checkChain(t, is, 0, []web.Item{m1, t2, m3, m4})
+	})
+
+	t.Run("Threads", func(t *testing.T) {
+		is := newStore()
+
+		is.Add(message(1, 0))
+		is.Add(message(2, 42))
+		is.Add(message(3, 42))
+
+		checkChain(t, is, 0, []web.Item{message(1, 0)})
+		checkChain(t, is, 42, []web.Item{message(2, 42), message(3, 42)})
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		is := newStore()
+		is.Add(message(1, 0))
+		is.Add(message(2, 0))
+
+		got, _ := is.Get(2, 0)
+		m := got.(*web.Message)
+		m.Text = "updated"
+
+		if err := is.Update(m); err != nil {
+			t.Fatalf("Update() error = %v", err)
+		}
+
+		got, _ = is.Get(2, 0)
+		if got.(*web.Message).Text != "updated" {
+			t.Errorf("Get() after Update() text = %v, want updated", got.(*web.Message).Text)
+		}
+
+		checkChain(t, is, 0, []web.Item{message(1, 0), message(2, 0)})
+	})
+}
+
+// TestItemStoreConcurrent runs the concurrency tests for a web.ItemStore,
+// that is expected to be safe for use by multiple goroutines.
+func TestItemStoreConcurrent(t *testing.T, newStore func() web.ItemStore) {
+	t.Run("Add", func(t *testing.T) {
+		is := newStore()
+
+		var wg sync.WaitGroup
+		for i := 1; i <= Concurrency; i++ {
+			wg.Add(1)
+			go func(id web.ItemID) {
+				defer wg.Done()
+
+				if err := is.Add(message(id, 0)); err != nil {
+					t.Errorf("Add() error = %v", err)
+				}
+			}(web.ItemID(i))
+		}
+
+		wg.Wait()
+
+		items := make([]web.Item, 0, Concurrency)
+		for i := 1; i <= Concurrency; i++ {
+			items = append(items, message(web.ItemID(i), 0))
+		}
+
+		checkChain(t, is, 0, items)
+	})
+}
+
+// TestConversationStore runs the behavioral tests for a web.ConversationStore.
+func TestConversationStore(t *testing.T, newStore func() web.ConversationStore) {
+	t.Run("Missing", func(t *testing.T) {
+		cs := newStore()
+
+		if cs.IsActive(1) {
+			t.Errorf("IsActive() = true for a conversation that was not started")
+		}
+
+		_, _, err := cs.Active(1)
+		wantErr(t, "Active()", err, web.ErrConversationNotFound)
+		wantErr(t, "SetState()", cs.SetState(1, "next"), web.ErrConversationNotFound)
+		wantErr(t, "SetData()", cs.SetData(1, "k", "v"), web.ErrConversationNotFound)
+
+		_, err = cs.GetData(1, "k")
+		wantErr(t, "GetData()", err, web.ErrConversationNotFound)
+		wantErr(t, "End()", cs.End(1), web.ErrConversationNotFound)
+	})
+
+	t.Run("Lifecycle", func(t *testing.T) {
+		cs := newStore()
+
+		if err := cs.Start(1, "conv"); err != nil {
+			t.Fatalf("Start() error = %v", err)
+		}
+
+		wantErr(t, "Start() of an active conversation", cs.Start(1, "other"), web.ErrConversationExists)
+
+		if id, state, err := cs.Active(1); err != nil || id != "conv" || state != "start" {
+			t.Errorf("Active() = %v, %v, %v, want conv, start, nil", id, state, err)
+		}
+
+		cs.SetState(1, "next")
+		if _, state, _ := cs.Active(1); state != "next" {
+			t.Errorf("Active() state = %v, want next", state)
+		}
+
+		if cs.IsActive(2) {
+			t.Errorf("IsActive() = true for another bot")
+		}
+
+		if err := cs.End(1); err != nil {
+			t.Errorf("End() error = %v", err)
+		}
+
+		if cs.IsActive(1) {
+			t.Errorf("IsActive() = true for an ended conversation")
+		}
+	})
+
+	t.Run("Data", func(t *testing.T) {
+		cs := newStore()
+		cs.Start(1, "conv")
+
+		_, err := cs.GetData(1, "k")
+		wantErr(t, "GetData() of a key that was not set", err, web.ErrItemNotFound)
+
+		cs.SetData(1, "k", "v1")
+		if err := cs.SetData(1, "k", "v2"); err != nil {
+			t.Errorf("SetData() error = %v", err)
+		}
+
+		if v, err := cs.GetData(1, "k"); err != nil || v != "v2" {
+			t.Errorf("GetData() = %v, %v, want v2, nil", v, err)
+		}
+
+		cs.End(1)
+		cs.Start(1, "conv")
+
+		_, err = cs.GetData(1, "k")
+		wantErr(t, "GetData() of a key set in an ended conversation", err, web.ErrItemNotFound)
+	})
+}
+
+// TestConversationStoreConcurrent runs the concurrency tests for a web.ConversationStore,
+// that is expected to be safe for use by multiple goroutines.
+func TestConversationStoreConcurrent(t *testing.T, newStore func() web.ConversationStore) {
+	t.Run("StartSame", func(t *testing.T) {
+		cs := newStore()
+
+		if n := countSuccesses(func() error { return cs.Start(1, "conv") }); n != 1 {
+			t.Errorf("concurrent Start() of the same conversation succeeded %v times, want 1", n)
+		}
+	})
+
+	t.Run("Data", func(t *testing.T) {
+		cs := newStore()
+		cs.Start(1, "conv")
+
+		var wg sync.WaitGroup
+		for i := 0; i < Concurrency; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+
+				k := fmt.Sprintf("k%d", i)
+				if err := cs.SetData(1, k, k); err != nil {
+					t.Errorf("SetData() error = %v", err)
+				}
+
+				cs.GetData(1, k)
+			}(i)
+		}
+
+		wg.Wait()
+
+		for i := 0; i < Concurrency; i++ {
+			k := fmt.Sprintf("k%d", i)
+			if v, err := cs.GetData(1, k); err != nil || v != k {
+				t.Errorf("GetData(%v) = %v, %v, want %v", k, v, err, k)
+			}
+		}
+	})
+}
@@ -0,0 +1,20 @@
+package storetest
+
+import (
+	"testing"
+
+	"suy.io/bots/web"
+)
+
+func TestMemoryControllerStore(t *testing.T) {
+	TestControllerStore(t, func() web.ControllerStore { return web.NewMemoryControllerStore() })
+}
+
+func TestMemoryItemStore(t *testing.T) {
+	TestItemStore(t, func() web.ItemStore { return web.NewMemoryItemStore() })
+	TestItemStoreConcurrent(t, func() web.ItemStore { return web.NewMemoryItemStore() })
+}
+
+func TestMemoryConversationStore(t *testing.T) {
+	TestConversationStore(t, func() web.ConversationStore { return web.NewMemoryConversationStore() })
+}